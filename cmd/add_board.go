@@ -39,6 +39,7 @@ var addBoardCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		parentID, _ := cmd.Flags().GetString("parent-id")
 		index, _ := cmd.Flags().GetInt("index")
@@ -57,7 +58,17 @@ var addBoardCmd = &cobra.Command{
 		}
 
 		// 创建画板块
-		createdBlocks, err := client.CreateBlock(documentID, parentID, []*larkdocx.Block{boardBlock}, index)
+		// 追加到末尾不受其他位置修改的影响；指定位置插入时要求父块的子块未变化
+		var touched []string
+		if index >= 0 {
+			touched = append(touched, parentID)
+		}
+		revision, err := newRevisionGuard(cmd, documentID).resolve(ctx, touched...)
+		if err != nil {
+			return err
+		}
+
+		createdBlocks, newRevision, err := client.CreateBlockAtRevision(ctx, documentID, parentID, []*larkdocx.Block{boardBlock}, index, revision)
 		if err != nil {
 			return err
 		}
@@ -76,13 +87,14 @@ var addBoardCmd = &cobra.Command{
 			whiteboardID = *createdBlocks[0].Board.Token
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]any{
 				"block_id":      boardBlockID,
 				"whiteboard_id": whiteboardID,
 				"document_id":   documentID,
+				"revision_id":   newRevision,
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -91,6 +103,7 @@ var addBoardCmd = &cobra.Command{
 			if whiteboardID != "" {
 				fmt.Printf("  画板 ID: %s\n", whiteboardID)
 			}
+			fmt.Printf("  文档版本: %d\n", newRevision)
 		}
 
 		return nil
@@ -101,5 +114,6 @@ func init() {
 	docCmd.AddCommand(addBoardCmd)
 	addBoardCmd.Flags().String("parent-id", "", "父块 ID（默认: 文档根节点）")
 	addBoardCmd.Flags().Int("index", -1, "插入位置索引（-1 表示末尾）")
-	addBoardCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(addBoardCmd)
+	addRevisionFlags(addBoardCmd)
 }
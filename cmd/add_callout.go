@@ -56,6 +56,7 @@ var addCalloutCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		content := args[1]
 		parentID, _ := cmd.Flags().GetString("parent-id")
@@ -92,7 +93,17 @@ var addCalloutCmd = &cobra.Command{
 		}
 
 		// 创建 callout 块
-		createdBlocks, err := client.CreateBlock(documentID, parentID, []*larkdocx.Block{calloutBlock}, index)
+		// 追加到末尾不受其他位置修改的影响；指定位置插入时要求父块的子块未变化
+		var touched []string
+		if index >= 0 {
+			touched = append(touched, parentID)
+		}
+		revision, err := newRevisionGuard(cmd, documentID).resolve(ctx, touched...)
+		if err != nil {
+			return err
+		}
+
+		createdBlocks, newRevision, err := client.CreateBlockAtRevision(ctx, documentID, parentID, []*larkdocx.Block{calloutBlock}, index, revision)
 		if err != nil {
 			return err
 		}
@@ -122,24 +133,26 @@ var addCalloutCmd = &cobra.Command{
 			},
 		}
 
-		_, err = client.CreateBlock(documentID, calloutBlockID, []*larkdocx.Block{textBlock}, 0)
+		_, newRevision, err = client.CreateBlockAtRevision(ctx, documentID, calloutBlockID, []*larkdocx.Block{textBlock}, 0, newRevision)
 		if err != nil {
 			return fmt.Errorf("添加高亮块内容失败: %w", err)
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]any{
 				"block_id":     calloutBlockID,
 				"callout_type": calloutType,
 				"content":      content,
+				"revision_id":  newRevision,
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
 			fmt.Printf("高亮块添加成功！\n")
 			fmt.Printf("  块 ID: %s\n", calloutBlockID)
 			fmt.Printf("  类型: %s\n", calloutType)
+			fmt.Printf("  文档版本: %d\n", newRevision)
 		}
 
 		return nil
@@ -152,5 +165,6 @@ func init() {
 	addCalloutCmd.Flags().Int("index", -1, "插入位置索引（-1 表示末尾）")
 	addCalloutCmd.Flags().String("callout-type", "info", "高亮块类型 (info/warning/error/success)")
 	addCalloutCmd.Flags().String("icon", "", "自定义图标（emoji shortcode）")
-	addCalloutCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(addCalloutCmd)
+	addRevisionFlags(addCalloutCmd)
 }
@@ -1,27 +1,40 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
 	"github.com/riba2534/feishu-cli/internal/client"
 	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
 	"github.com/spf13/cobra"
 )
 
 var addCommentCmd = &cobra.Command{
 	Use:   "add <file_token>",
 	Short: "添加评论",
-	Long: `为文档添加全文评论。
+	Long: `为文档添加评论，默认为全文评论。
 
 参数:
   file_token    文档 Token
   --type        文件类型（必填）
   --text        评论内容（必填）
+  --quote       引用的文本，创建针对该文本的局部评论
+  --block-id    引用文本所在的块 ID（仅 docx），会校验引用文本存在于该块中，
+                且在整个文档中只出现一次；未指定 --quote 时引用整个块的文本
+
+飞书评论接口只按引用文本定位局部评论，不记录块的位置。指定 --block-id 时，
+引用文本在文档中出现多次会返回错误，请引用足够长、唯一的片段。
 
 示例:
   # 添加评论
   feishu-cli comment add doccnXXX --type docx --text "这是一条评论"
 
+  # 针对某个段落添加局部评论
+  feishu-cli comment add doccnXXX --type docx --block-id doxcnBLOCK --quote "有问题的句子" --text "这里需要修改"
+
   # JSON 格式输出
   feishu-cli comment add doccnXXX --type docx --text "评论内容" --output json`,
 	Args: cobra.ExactArgs(1),
@@ -30,35 +43,99 @@ var addCommentCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		fileType, _ := cmd.Flags().GetString("type")
 		text, _ := cmd.Flags().GetString("text")
+		quote, _ := cmd.Flags().GetString("quote")
+		blockID, _ := cmd.Flags().GetString("block-id")
 		output, _ := cmd.Flags().GetString("output")
 
-		commentID, err := client.CreateComment(fileToken, fileType, text)
+		if blockID != "" {
+			if fileType != "docx" {
+				return fmt.Errorf("--block-id 仅支持 docx 文档")
+			}
+			var err error
+			quote, err = resolveCommentQuote(ctx, fileToken, blockID, quote)
+			if err != nil {
+				return err
+			}
+		}
+
+		var commentID string
+		var err error
+		if quote != "" {
+			commentID, err = client.CreatePartialComment(ctx, fileToken, fileType, quote, text)
+		} else {
+			commentID, err = client.CreateComment(ctx, fileToken, fileType, text)
+		}
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"comment_id": commentID,
+				"quote":      quote,
 			}); err != nil {
 				return err
 			}
 		} else {
 			fmt.Printf("评论添加成功！\n")
 			fmt.Printf("  评论 ID: %s\n", commentID)
+			if quote != "" {
+				fmt.Printf("  引用:    %s\n", quote)
+			}
 		}
 
 		return nil
 	},
 }
 
+// resolveCommentQuote 校验引用文本存在于指定块中且在文档中唯一，未指定引用文本时使用整个块的文本
+func resolveCommentQuote(ctx context.Context, documentID, blockID, quote string) (string, error) {
+	blocks, err := client.GetAllBlocks(ctx, documentID)
+	if err != nil {
+		return "", fmt.Errorf("获取块失败: %w", err)
+	}
+	return checkCommentQuote(blocks, blockID, quote)
+}
+
+// checkCommentQuote 在文档块中校验引用文本
+// 局部评论只记录引用文本，文本在文档中出现多次时评论可能落在其他位置，因此返回错误
+func checkCommentQuote(blocks []*larkdocx.Block, blockID, quote string) (string, error) {
+	var block *larkdocx.Block
+	for _, b := range blocks {
+		if client.StringVal(b.BlockId) == blockID {
+			block = b
+			break
+		}
+	}
+	if block == nil {
+		return "", fmt.Errorf("文档中不存在块 %s", blockID)
+	}
+
+	text := converter.BlockPlainText(block)
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("块 %s 没有可引用的文本", blockID)
+	}
+	if quote == "" {
+		quote = text
+	} else if !strings.Contains(text, quote) {
+		return "", fmt.Errorf("引用文本不在块 %s 中", blockID)
+	}
+	if n := converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{}).CountQuote(quote); n > 1 {
+		return "", fmt.Errorf("引用文本在文档中出现 %d 次，无法确定评论位置，请引用更长的唯一片段", n)
+	}
+	return quote, nil
+}
+
 func init() {
 	commentCmd.AddCommand(addCommentCmd)
 	addCommentCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
 	addCommentCmd.Flags().String("text", "", "评论内容（必填）")
-	addCommentCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addCommentCmd.Flags().String("quote", "", "引用的文本（创建局部评论）")
+	addCommentCmd.Flags().String("block-id", "", "引用文本所在的块 ID（仅 docx）")
+	addOutputFlag(addCommentCmd)
 	mustMarkFlagRequired(addCommentCmd, "type", "text")
 }
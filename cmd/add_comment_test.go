@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+func TestCheckCommentQuote(t *testing.T) {
+	md := "使用 Redis 作为缓存。\n\n使用 Redis 保存会话。\n"
+	result, err := converter.NewMarkdownToBlock([]byte(md), converter.ConvertOptions{}, "").ConvertWithTableData()
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	blocks := converter.BuildDocumentBlocks(result)
+	blockID := *blocks[0].BlockId
+
+	tests := []struct {
+		name    string
+		blockID string
+		quote   string
+		want    string
+		wantErr bool
+	}{
+		{"块内唯一的文本", blockID, "作为缓存", "作为缓存", false},
+		{"引用整个块", blockID, "", "使用 Redis 作为缓存。", false},
+		{"文本在文档中出现多次", blockID, "使用 Redis", "", true},
+		{"文本不在块中", blockID, "保存会话", "", true},
+		{"块不存在", "missing", "作为缓存", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkCommentQuote(blocks, tt.blockID, tt.quote)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCommentQuote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkCommentQuote() = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentAdd_RejectsAmbiguousBlockQuote(t *testing.T) {
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/open-apis/auth/"):
+			_, _ = io.WriteString(w, `{"code":0,"msg":"ok","tenant_access_token":"t-test","expire":7200}`)
+		case r.URL.Path == "/open-apis/docx/v1/documents/doc1/blocks":
+			_, _ = io.WriteString(w, `{"code":0,"msg":"success","data":{"has_more":false,"items":[
+				{"block_id":"doc1","block_type":1,"children":["b1","b2"],"page":{"elements":[]}},
+				{"block_id":"b1","block_type":2,"parent_id":"doc1","text":{"elements":[{"text_run":{"content":"使用 Redis 作为缓存。"}}]}},
+				{"block_id":"b2","block_type":2,"parent_id":"doc1","text":{"elements":[{"text_run":{"content":"使用 Redis 保存会话。"}}]}}
+			]}}`)
+		default:
+			created = true
+			_, _ = io.WriteString(w, `{"code":0,"msg":"success","data":{"comment_id":"c1"}}`)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("FEISHU_PROFILE", "")
+	t.Setenv("FEISHU_APP_ID", "cli_test")
+	t.Setenv("FEISHU_APP_SECRET", "secret")
+	t.Setenv("FEISHU_BASE_URL", server.URL)
+
+	rootCmd.SetArgs([]string{"comment", "add", "doc1", "--type", "docx", "--block-id", "b1", "--quote", "使用 Redis", "--text", "说明持久化策略"})
+	defer rootCmd.SetArgs(nil)
+	_, err := rootCmd.ExecuteContextC(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "出现 2 次") {
+		t.Fatalf("引用文本不唯一时应返回错误, 实际: %v", err)
+	}
+	if created {
+		t.Error("引用文本不唯一时不应创建评论")
+	}
+}
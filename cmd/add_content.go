@@ -30,6 +30,8 @@ var addContentCmd = &cobra.Command{
   --block-id, -b       父块 ID（默认: 文档根节点）
   --index, -i          插入位置索引（-1 表示末尾）
   --upload-images      上传 Markdown 中的本地图片
+  --expect-revision    期望的文档版本号，文档已被修改时拒绝写入（退出码 3）
+  --rebase             版本冲突但插入位置未受影响时，基于最新版本继续写入
   --output, -o         输出格式 (json)
 
 示例:
@@ -52,6 +54,7 @@ var addContentCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		contentStr, _ := cmd.Flags().GetString("content")
 		contentFile, _ := cmd.Flags().GetString("content-file")
@@ -127,17 +130,27 @@ var addContentCmd = &cobra.Command{
 			blockID = documentID
 		}
 
-		createdBlocks, err := client.CreateBlock(documentID, blockID, blocks, index)
+		// 追加到末尾不受其他位置修改的影响；指定位置插入时要求父块的子块未变化
+		var touched []string
+		if index >= 0 {
+			touched = append(touched, blockID)
+		}
+		revision, err := newRevisionGuard(cmd, documentID).resolve(ctx, touched...)
+		if err != nil {
+			return err
+		}
+
+		createdBlocks, newRevision, err := client.CreateBlockAtRevision(ctx, documentID, blockID, blocks, index, revision)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(createdBlocks); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(createdBlocks); err != nil {
 				return err
 			}
 		} else {
-			fmt.Printf("成功添加 %d 个块！\n", len(createdBlocks))
+			fmt.Printf("成功添加 %d 个块！(文档版本: %d)\n", len(createdBlocks), newRevision)
 			for i, block := range createdBlocks {
 				if block.BlockId != nil {
 					fmt.Printf("  [%d] 块ID: %s\n", i+1, *block.BlockId)
@@ -158,5 +171,6 @@ func init() {
 	addContentCmd.Flags().StringP("block-id", "b", "", "父块ID (默认: 文档根节点)")
 	addContentCmd.Flags().IntP("index", "i", -1, "插入位置索引 (-1 表示末尾)")
 	addContentCmd.Flags().Bool("upload-images", false, "上传 Markdown 中的本地图片")
-	addContentCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(addContentCmd)
+	addRevisionFlags(addContentCmd)
 }
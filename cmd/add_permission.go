@@ -56,6 +56,7 @@ var addPermissionCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		docToken := args[0]
 		docType, _ := cmd.Flags().GetString("doc-type")
 		memberType, _ := cmd.Flags().GetString("member-type")
@@ -69,10 +70,21 @@ var addPermissionCmd = &cobra.Command{
 			Perm:       perm,
 		}
 
-		if err := client.AddPermission(docToken, docType, member, notification); err != nil {
+		if err := client.AddPermission(ctx, docToken, docType, member, notification); err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"doc_token":   docToken,
+				"doc_type":    docType,
+				"member_type": memberType,
+				"member_id":   memberID,
+				"perm":        perm,
+			})
+		}
+
 		fmt.Printf("权限添加成功！\n")
 		fmt.Printf("  文档: %s\n", docToken)
 		fmt.Printf("  成员: %s（%s）\n", memberID, memberType)
@@ -88,5 +100,6 @@ func init() {
 	addPermissionCmd.Flags().String("member-id", "", "成员标识")
 	addPermissionCmd.Flags().String("perm", "", "权限级别（view/edit/full_access）")
 	addPermissionCmd.Flags().Bool("notification", false, "发送通知给成员")
+	addOutputFlag(addPermissionCmd)
 	mustMarkFlagRequired(addPermissionCmd, "member-type", "member-id", "perm")
 }
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api <method> <path>",
+	Short: "直接调用开放平台接口",
+	Long: `直接调用任意飞书开放平台接口，用于尚未提供专门命令的新接口。
+
+鉴权、限流重试、--timeout 和 --debug 与其他命令一致，默认使用应用身份
+（tenant_access_token）调用，--as user 时使用用户身份（User Access Token）。
+
+参数:
+  method    HTTP 方法: GET, POST, PUT, PATCH, DELETE
+  path      接口路径，以 /open-apis/ 开头，可带查询参数
+
+选项:
+  -d, --data          JSON 请求体，@file 从文件读取，@- 从标准输入读取
+  -q, --query         查询参数 key=value，可重复指定
+  --as                调用身份: tenant（默认）或 user
+  --paginate          按 has_more 和 page_token 自动获取所有分页，
+                      并将各页 data 中的数组合并后输出
+  -o, --output        输出格式（默认输出完整的 JSON 响应）
+
+接口返回的 code 非 0 时命令失败，错误信息包含 code、msg 和 log_id。
+
+示例:
+  # 获取文档基本信息
+  feishu-cli api GET /open-apis/docx/v1/documents/<document_id>
+
+  # 带查询参数并获取所有分页
+  feishu-cli api GET /open-apis/drive/v1/files -q folder_token=<token> -q page_size=200 --paginate
+
+  # 以用户身份调用
+  feishu-cli api GET /open-apis/authen/v1/user_info --as user
+
+  # 从文件读取请求体
+  feishu-cli api POST /open-apis/im/v1/messages -q receive_id_type=email --data @message.json
+
+  # 过滤输出
+  feishu-cli api GET /open-apis/wiki/v2/spaces --paginate --jq '.data.items[].name'`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		data, _ := cmd.Flags().GetString("data")
+		queries, _ := cmd.Flags().GetStringArray("query")
+		as, _ := cmd.Flags().GetString("as")
+		paginate, _ := cmd.Flags().GetBool("paginate")
+
+		req := client.APIRequest{
+			Method: strings.ToUpper(args[0]),
+			Path:   args[1],
+		}
+		query, err := parseAPIQuery(queries)
+		if err != nil {
+			return newUsageError(err)
+		}
+		req.Query = query
+		if req.Body, err = readAPIBody(data); err != nil {
+			return err
+		}
+
+		switch as {
+		case "tenant":
+		case "user":
+			userAccessToken, _ := cmd.Flags().GetString("user-access-token")
+			if userAccessToken == "" {
+				userAccessToken = config.Get().UserAccessToken
+			}
+			if userAccessToken == "" {
+				if userAccessToken, err = client.GetUserAccessToken(); err != nil {
+					return err
+				}
+			}
+			req.UserAccessToken = userAccessToken
+		default:
+			return newUsageError(fmt.Errorf("--as 只支持 tenant 或 user: %s", as))
+		}
+
+		if !paginate {
+			body, err := client.CallAPI(ctx, req)
+			if err != nil {
+				return err
+			}
+			if !json.Valid(body) {
+				// 文件下载等非 JSON 响应原样输出
+				return writeRawResult(body)
+			}
+			return printOutput(json.RawMessage(body))
+		}
+
+		var merged *output.Object
+		err = client.CallAPIPages(ctx, req, func(page []byte) error {
+			v, err := output.Normalize(json.RawMessage(page))
+			if err != nil {
+				return fmt.Errorf("解析响应失败: %w", err)
+			}
+			obj, ok := v.(*output.Object)
+			if !ok {
+				return fmt.Errorf("响应不是 JSON 对象，无法分页")
+			}
+			merged = mergeAPIPage(merged, obj)
+			return nil
+		})
+		if merged != nil {
+			if printErr := printOutput(merged); printErr != nil && err == nil {
+				err = printErr
+			}
+		}
+		return err
+	},
+}
+
+// parseAPIQuery 解析 key=value 形式的查询参数
+func parseAPIQuery(pairs []string) (url.Values, error) {
+	query := url.Values{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("查询参数格式应为 key=value: %s", pair)
+		}
+		query.Add(key, value)
+	}
+	return query, nil
+}
+
+// readAPIBody 读取请求体：JSON 字符串、@file 或 @-（标准输入），为空时返回 nil
+func readAPIBody(data string) (any, error) {
+	if data == "" {
+		return nil, nil
+	}
+	raw := []byte(data)
+	if name, ok := strings.CutPrefix(data, "@"); ok {
+		var err error
+		if name == "-" {
+			raw, err = io.ReadAll(os.Stdin)
+		} else {
+			raw, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取请求体失败: %w", err)
+		}
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("请求体不是有效的 JSON")
+	}
+	return json.RawMessage(raw), nil
+}
+
+// mergeAPIPage 将一页响应合并到已有结果：data 中的数组依次拼接，其余字段取最后一页，
+// 合并后不再包含分页 token
+func mergeAPIPage(merged, page *output.Object) *output.Object {
+	if merged == nil {
+		merged = page
+	} else if pageData, ok := page.Get("data"); ok {
+		mergedData, _ := merged.Get("data")
+		dst, ok1 := mergedData.(*output.Object)
+		src, ok2 := pageData.(*output.Object)
+		if ok1 && ok2 {
+			for _, key := range src.Keys {
+				value := src.Values[key]
+				if items, ok := value.([]any); ok {
+					prev, _ := dst.Get(key)
+					prevItems, _ := prev.([]any)
+					value = append(prevItems, items...)
+				}
+				dst.Set(key, value)
+			}
+		}
+	}
+	if data, ok := merged.Get("data"); ok {
+		if obj, ok := data.(*output.Object); ok {
+			obj.Delete("page_token")
+			obj.Delete("next_page_token")
+		}
+	}
+	return merged
+}
+
+// writeRawResult 将非 JSON 响应原样写入标准输出
+func writeRawResult(body []byte) error {
+	_, err := resultWriter().Write(body)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+	apiCmd.Flags().StringP("data", "d", "", "JSON 请求体，@file 从文件读取，@- 从标准输入读取")
+	apiCmd.Flags().StringArrayP("query", "q", nil, "查询参数 key=value（可重复）")
+	apiCmd.Flags().String("as", "tenant", "调用身份: tenant 或 user")
+	apiCmd.Flags().String("user-access-token", "", "User Access Token（--as user 时使用，默认读取 auth login 保存的 token）")
+	apiCmd.Flags().Bool("paginate", false, "自动获取所有分页并合并结果")
+	addOutputFlag(apiCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/output"
+)
+
+func TestMergeAPIPage(t *testing.T) {
+	var merged *output.Object
+	for _, page := range []string{
+		`{"code":0,"data":{"items":[{"id":1}],"has_more":true,"page_token":"p2"}}`,
+		`{"code":0,"data":{"items":[{"id":2},{"id":3}],"has_more":false}}`,
+	} {
+		v, err := output.Normalize(json.RawMessage(page))
+		if err != nil {
+			t.Fatalf("Normalize 返回错误: %v", err)
+		}
+		merged = mergeAPIPage(merged, v.(*output.Object))
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	want := `{"code":0,"data":{"items":[{"id":1},{"id":2},{"id":3}],"has_more":false}}`
+	if string(data) != want {
+		t.Errorf("合并结果 = %s, 期望 %s", data, want)
+	}
+}
+
+func TestParseAPIQuery(t *testing.T) {
+	query, err := parseAPIQuery([]string{"a=1", "a=2", "b=x=y"})
+	if err != nil {
+		t.Fatalf("parseAPIQuery 返回错误: %v", err)
+	}
+	if got := query.Encode(); got != "a=1&a=2&b=x%3Dy" {
+		t.Errorf("parseAPIQuery = %q", got)
+	}
+	if _, err := parseAPIQuery([]string{"novalue"}); err == nil {
+		t.Error("缺少 = 时应返回错误")
+	}
+}
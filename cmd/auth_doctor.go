@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/token"
+	"github.com/spf13/cobra"
+)
+
+// doctorCheck 诊断检查项
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // ok, warn, fail
+	Detail string `json:"detail"`
+}
+
+// commandScopeStatus 命令的权限检查结果
+type commandScopeStatus struct {
+	Command       string   `json:"command"`
+	MissingTenant []string `json:"missing_tenant,omitempty"`
+	MissingUser   []string `json:"missing_user,omitempty"`
+}
+
+// doctorReport 诊断结果
+type doctorReport struct {
+	Profile    string                `json:"profile"`
+	AppID      string                `json:"app_id"`
+	BaseURL    string                `json:"base_url"`
+	Checks     []doctorCheck         `json:"checks"`
+	AppScopes  []string              `json:"app_scopes,omitempty"`
+	UserScopes []string              `json:"user_scopes,omitempty"`
+	Commands   []*commandScopeStatus `json:"commands,omitempty"`
+}
+
+func (r *doctorReport) add(name, status, format string, args ...any) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+var authDoctorCmd = &cobra.Command{
+	Use:   "doctor [command...]",
+	Short: "诊断应用凭证、权限和用户授权",
+	Long: `检查 CLI 能否正常调用开放平台 API，并列出各命令缺少的权限。
+
+检查项:
+  网络连通   能否访问 base_url
+  应用凭证   app_id / app_secret 能否换取 tenant_access_token
+  应用权限   应用已开通的 scope（需要应用开通 application:application:self_manage）
+  用户授权   User Access Token 是否有效、剩余有效期和已授权的 scope
+
+随后按命令检查所需的应用身份和用户身份 scope，列出缺少权限的命令和开通地址。
+指定命令时只检查该命令，可用于执行前的权限预检。
+
+存在失败的检查项时命令以非 0 状态退出。
+
+示例:
+  # 全面诊断
+  feishu-cli auth doctor
+
+  # 检查导入命令所需的权限
+  feishu-cli auth doctor doc import
+
+  # JSON 格式输出
+  feishu-cli auth doctor -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		output, _ := cmd.Flags().GetString("output")
+		cfg := config.Get()
+
+		// 指定命令时只检查该命令
+		var only []string
+		if len(args) > 0 {
+			key := strings.Join(args, " ")
+			if _, ok := commandScopes[key]; !ok {
+				return fmt.Errorf("未知命令或命令不需要权限: %s", key)
+			}
+			only = []string{key}
+		} else {
+			for key := range commandScopes {
+				only = append(only, key)
+			}
+			sort.Strings(only)
+		}
+
+		report := &doctorReport{Profile: cfg.Profile, AppID: cfg.AppID, BaseURL: cfg.BaseURL}
+		if report.Profile == "" {
+			report.Profile = config.DefaultProfile
+		}
+
+		// 1. 网络连通，不通时跳过需要调用 API 的检查
+		elapsed, status, err := client.PingBaseURL(ctx)
+		online := err == nil
+		if online {
+			report.add("网络连通", "ok", "%s（HTTP %d，%v）", cfg.BaseURL, status, elapsed.Round(time.Millisecond))
+		} else {
+			report.add("网络连通", "fail", "%v", err)
+		}
+
+		// 2. 应用凭证和应用权限
+		var appScopes map[string]bool
+		if err := config.Validate(); err != nil {
+			report.add("应用凭证", "fail", "%v", err)
+		} else if !online {
+			report.add("应用凭证", "warn", "网络不通，跳过检查")
+		} else if expire, err := client.CheckAppCredentials(ctx); err != nil {
+			report.add("应用凭证", "fail", "%v", err)
+		} else {
+			report.add("应用凭证", "ok", "tenant_access_token 获取成功（有效期 %d 秒）", expire)
+
+			scopes, err := client.GetAppScopes(ctx)
+			if err != nil {
+				report.add("应用权限", "warn", "%v（开通 application:application:self_manage 后可检查各命令的应用权限）", err)
+			} else {
+				sort.Strings(scopes)
+				report.AppScopes = scopes
+				appScopes = toSet(scopes)
+				report.add("应用权限", "ok", "已开通 %d 个 scope", len(scopes))
+			}
+		}
+
+		// 3. 用户授权
+		userScopes := checkUserToken(cmd, report, only, online)
+
+		// 4. 各命令的权限
+		for _, key := range only {
+			req := commandScopes[key]
+			status := &commandScopeStatus{Command: key}
+			if appScopes != nil {
+				status.MissingTenant = missingScopes(req.Tenant, appScopes)
+				// 用户身份的 scope 也需要应用先开通
+				status.MissingTenant = append(status.MissingTenant, missingScopes(req.User, appScopes)...)
+			}
+			if userScopes != nil {
+				status.MissingUser = missingScopes(req.User, userScopes)
+			}
+			if len(status.MissingTenant) > 0 || len(status.MissingUser) > 0 || len(args) > 0 {
+				report.Commands = append(report.Commands, status)
+			}
+		}
+
+		if isStructuredOutput(output) {
+			if err := printOutput(report); err != nil {
+				return err
+			}
+		} else {
+			printDoctorReport(report, appScopes != nil)
+		}
+
+		failed := 0
+		for _, c := range report.Checks {
+			if c.Status == "fail" {
+				failed++
+			}
+		}
+		for _, c := range report.Commands {
+			if len(c.MissingTenant) > 0 || len(c.MissingUser) > 0 {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("诊断发现 %d 个问题", failed)
+		}
+		return nil
+	},
+}
+
+// checkUserToken 检查 User Access Token，返回已授权的 scope；scope 未知时返回 nil
+// online 为 false 时只检查本地保存的 token，不调用 API 验证
+func checkUserToken(cmd *cobra.Command, report *doctorReport, commands []string, online bool) map[string]bool {
+	needUser := false
+	for _, key := range commands {
+		if len(commandScopes[key].User) > 0 {
+			needUser = true
+		}
+	}
+	// 不需要用户授权的命令，未登录不算问题
+	missingStatus := "warn"
+	if needUser && len(commands) == 1 {
+		missingStatus = "fail"
+	}
+
+	if envToken := config.Get().UserAccessToken; envToken != "" {
+		if !online {
+			report.add("用户授权", "warn", "使用环境变量或配置中的 user_access_token，网络不通，跳过验证")
+		} else if name, err := client.GetCurrentUserName(cmd.Context(), envToken); err != nil {
+			report.add("用户授权", "fail", "环境变量或配置中的 user_access_token 不可用: %v", err)
+		} else {
+			report.add("用户授权", "ok", "%s（来自环境变量或配置，无法检查 scope）", name)
+		}
+		return nil
+	}
+
+	tok, err := token.LoadToken()
+	if err != nil {
+		report.add("用户授权", missingStatus, "未登录，search 等用户身份命令需要先运行 feishu-cli auth login")
+		return nil
+	}
+	if tok.IsExpired() {
+		report.add("用户授权", missingStatus, "User Access Token 已过期（%s），请运行 feishu-cli auth refresh", tok.FormatExpiry())
+		return nil
+	}
+
+	name := "已登录"
+	if online {
+		if name, err = client.GetCurrentUserName(cmd.Context(), tok.AccessToken); err != nil {
+			report.add("用户授权", "fail", "User Access Token 不可用: %v", err)
+			return nil
+		}
+	}
+	remaining := tok.GetRemainingTime().Round(time.Minute)
+	if tok.Scope == "" {
+		report.add("用户授权", "ok", "%s，剩余 %v（该 token 未记录 scope，重新登录后可检查用户权限）", name, remaining)
+		return nil
+	}
+	report.UserScopes = strings.Fields(tok.Scope)
+	report.add("用户授权", "ok", "%s，剩余 %v，已授权 %d 个 scope", name, remaining, len(report.UserScopes))
+	return toSet(report.UserScopes)
+}
+
+// printDoctorReport 输出诊断结果
+func printDoctorReport(r *doctorReport, appScopesKnown bool) {
+	icons := map[string]string{"ok": "✅", "warn": "⚠️ ", "fail": "❌"}
+
+	fmt.Println("=")
+	fmt.Println("飞书 CLI 诊断")
+	fmt.Println("=")
+	fmt.Println()
+	fmt.Printf("配置档案: %s\n", r.Profile)
+	fmt.Printf("App ID:   %s\n", r.AppID)
+	fmt.Printf("API 地址: %s\n", r.BaseURL)
+	fmt.Println()
+
+	for _, c := range r.Checks {
+		fmt.Printf("%s %-8s %s\n", icons[c.Status], c.Name, c.Detail)
+	}
+	fmt.Println()
+
+	if len(r.Commands) == 0 {
+		if appScopesKnown {
+			fmt.Println("所有命令所需的权限均已开通")
+		}
+		return
+	}
+
+	fmt.Println("命令权限:")
+	var missing []string
+	for _, c := range r.Commands {
+		if len(c.MissingTenant) == 0 && len(c.MissingUser) == 0 {
+			req := commandScopes[c.Command]
+			icon := "✅"
+			if !appScopesKnown {
+				// 无法获取应用权限列表时只列出所需权限
+				icon = "• "
+			}
+			fmt.Printf("  %s %s\n", icon, c.Command)
+			printScopeList("应用身份", req.Tenant)
+			printScopeList("用户身份", req.User)
+			continue
+		}
+		fmt.Printf("  ❌ %s\n", c.Command)
+		printScopeList("缺少应用权限", c.MissingTenant)
+		printScopeList("缺少用户授权", c.MissingUser)
+		missing = append(missing, c.MissingTenant...)
+	}
+
+	if len(missing) > 0 {
+		fmt.Println()
+		fmt.Printf("开通地址: %s\n", scopeConsoleURL(r.BaseURL, r.AppID, uniqueStrings(missing)))
+	}
+}
+
+// printScopeList 输出 scope 要求，"|" 分隔的备选项显示为 "或"
+func printScopeList(label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	var parts []string
+	for _, item := range items {
+		parts = append(parts, strings.ReplaceAll(item, "|", " 或 "))
+	}
+	fmt.Printf("       %s: %s\n", label, strings.Join(parts, "; "))
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+func uniqueStrings(items []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func init() {
+	authCmd.AddCommand(authDoctorCmd)
+	addOutputFlag(authDoctorCmd)
+}
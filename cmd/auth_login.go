@@ -1,13 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -17,11 +16,16 @@ import (
 )
 
 var (
-	loginPort  int
-	loginHost  string
-	loginScope string
+	loginPort      int
+	loginHost      string
+	loginScope     string
+	loginNoBrowser bool
+	loginPKCE      bool
 )
 
+// loginWaitTimeout 等待用户完成授权的最长时间
+const loginWaitTimeout = 5 * time.Minute
+
 // authLoginCmd 登录命令
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
@@ -34,15 +38,42 @@ var authLoginCmd = &cobra.Command{
 3. 用户授权后，飞书重定向到本地服务器
 4. 自动换取并保存 User Access Token
 
-Token 将保存到 ~/.lark_user_token 文件，后续命令可自动使用。`,
+远程开发机、容器或 CI 环境中浏览器无法访问本地服务器时，使用 --no-browser:
+1. 命令输出授权链接，在任意设备的浏览器中打开并授权
+2. 授权后浏览器跳转到回调地址（页面无法打开是正常的）
+3. 复制地址栏中的完整地址（或其中的 code 参数）粘贴到终端
+
+回调地址（默认 http://127.0.0.1:8080/callback）需要在开放平台应用的
+"安全设置 > 重定向 URL" 中登记；--no-browser 模式下同样使用该地址。
+授权回调会校验随机生成的 state，防止使用其他登录流程的授权码。
+
+--pkce 使用 PKCE（RFC 7636）保护授权码，换取 token 时需要提供本次登录生成的
+code_verifier，即使授权码被截获也无法使用。该模式使用 OAuth 2.0 标准令牌接口，
+scope 中需包含 offline_access 才会返回 refresh_token。
+
+Token 将保存到当前配置档案的 token 文件，后续命令可自动使用:
+  默认配置:  ~/.lark_user_token
+  命名档案:  ~/.feishu-cli/tokens/<profile>.json（通过 --profile 指定）
+
+示例:
+  # 本机浏览器授权
+  feishu-cli auth login
+
+  # 远程开发机上授权
+  feishu-cli auth login --no-browser --pkce --scope "offline_access search:message"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// 1. 检查 App ID 和 App Secret 配置
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		cfg := config.Get()
 
+		if loginNoBrowser && loginPort == 0 {
+			return fmt.Errorf("--no-browser 模式需要指定已在开放平台登记的回调端口，不能使用 --port 0")
+		}
+
 		// 2. 确定回调地址
 		redirectURI := fmt.Sprintf("http://%s:%d/callback", loginHost, loginPort)
 
@@ -52,142 +83,41 @@ Token 将保存到 ~/.lark_user_token 文件，后续命令可自动使用。`,
 			return err
 		}
 
-		// 4. 创建 channel 用于接收授权码
-		codeChan := make(chan string, 1)
-		errChan := make(chan error, 1)
-
-		// 5. 启动 HTTP 服务器
-		mux := http.NewServeMux()
-		server := &http.Server{
-			Addr:    fmt.Sprintf("%s:%d", loginHost, loginPort),
-			Handler: mux,
-		}
-
-		// 处理回调
-		mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-			code := r.URL.Query().Get("code")
-			state := r.URL.Query().Get("state")
-			errorMsg := r.URL.Query().Get("error")
-
-			if errorMsg != "" {
-				errChan <- fmt.Errorf("授权失败: %s", errorMsg)
-				http.Error(w, "授权失败，请返回终端查看错误信息", http.StatusBadRequest)
-				return
-			}
-
-			if code == "" {
-				errChan <- fmt.Errorf("未收到授权码")
-				http.Error(w, "未收到授权码", http.StatusBadRequest)
-				return
-			}
-
-			// 简单的 state 验证（可选）
-			_ = state
-
-			codeChan <- code
-
-			// 返回成功页面
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>授权成功</title>
-    <style>
-        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; display: flex; justify-content: center; align-items: center; height: 100vh; margin: 0; background: #f5f5f5; }
-        .container { text-align: center; background: white; padding: 40px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
-        .success-icon { font-size: 64px; color: #52c41a; margin-bottom: 20px; }
-        h1 { color: #333; margin-bottom: 10px; }
-        p { color: #666; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="success-icon">✓</div>
-        <h1>授权成功</h1>
-        <p>请返回终端查看 token 信息</p>
-        <p>您可以关闭此页面</p>
-    </div>
-</body>
-</html>`)
-		})
-
-		// 6. 启动服务器
-		listener, err := net.Listen("tcp", server.Addr)
+		state, err := client.NewOAuthState()
 		if err != nil {
-			return fmt.Errorf("启动本地服务器失败: %w", err)
+			return err
 		}
-		actualPort := listener.Addr().(*net.TCPAddr).Port
-		actualAddr := fmt.Sprintf("http://%s:%d/callback", loginHost, actualPort)
-
-		// 更新 OAuth 客户端的 redirect URI
-		oauthClient.RedirectURI = actualAddr
-
-		go func() {
-			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
-				errChan <- fmt.Errorf("服务器错误: %w", err)
+		if loginPKCE {
+			if oauthClient.CodeVerifier, err = client.NewPKCEVerifier(); err != nil {
+				return err
 			}
-		}()
-
-		// 7. 生成授权 URL 并提示用户
-		authorizeURL := oauthClient.GetAuthorizeURL("random-state", loginScope)
-
-		fmt.Println("=")
-		fmt.Println("飞书用户授权")
-		fmt.Println("=")
-		fmt.Println()
-		fmt.Printf("应用 ID: %s\n", cfg.AppID)
-		fmt.Printf("回调地址: %s\n", actualAddr)
-		fmt.Println()
-		fmt.Println("请在浏览器中访问以下链接进行授权:")
-		fmt.Println()
-		fmt.Println(authorizeURL)
-		fmt.Println()
-		fmt.Println("等待授权...")
-		fmt.Println()
-
-		// 8. 等待授权码或错误
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		// 处理信号
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		}
 
+		// 4. 等待授权码
 		var authCode string
-		select {
-		case authCode = <-codeChan:
-			// 收到授权码
-		case err := <-errChan:
+		if loginNoBrowser {
+			authCode, err = waitPastedAuthCode(ctx, oauthClient, state, cfg.AppID)
+		} else {
+			authCode, err = waitCallbackAuthCode(ctx, oauthClient, state, cfg.AppID)
+		}
+		if err != nil {
 			return err
-		case <-sigChan:
-			fmt.Println("\n已取消授权")
-			server.Shutdown(ctx)
-			return nil
-		case <-time.After(5 * time.Minute):
-			server.Shutdown(ctx)
-			return fmt.Errorf("授权超时，请重新运行命令")
 		}
 
-		// 9. 关闭服务器
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer shutdownCancel()
-		server.Shutdown(shutdownCtx)
-
 		fmt.Println("已收到授权码，正在换取 token...")
 
-		// 10. 用授权码换取 token
+		// 5. 用授权码换取 token
 		userToken, err := oauthClient.ExchangeCodeForToken(authCode)
 		if err != nil {
 			return fmt.Errorf("换取 token 失败: %w", err)
 		}
 
-		// 11. 保存 token
+		// 6. 保存 token
 		if err := token.SaveToken(userToken); err != nil {
 			return err
 		}
 
-		// 12. 显示成功信息
+		// 7. 显示成功信息
 		fmt.Println()
 		fmt.Println("=")
 		fmt.Println("授权成功！")
@@ -201,7 +131,7 @@ Token 将保存到 ~/.lark_user_token 文件，后续命令可自动使用。`,
 			userToken.RefreshToken[max(0, len(userToken.RefreshToken)-10):])
 		fmt.Printf("过期时间: %s\n", userToken.FormatExpiry())
 		fmt.Println()
-		fmt.Printf("Token 已保存到: ~/.lark_user_token\n")
+		fmt.Printf("Token 已保存到: %s\n", token.DisplayPath())
 		fmt.Println()
 		fmt.Println("现在您可以使用需要 User Access Token 的命令，如:")
 		fmt.Println("  feishu-cli search messages \"关键词\"")
@@ -211,11 +141,155 @@ Token 将保存到 ~/.lark_user_token 文件，后续命令可自动使用。`,
 	},
 }
 
+// printLoginHeader 输出授权提示和授权链接
+func printLoginHeader(appID, redirectURI, authorizeURL string) {
+	fmt.Println("=")
+	fmt.Println("飞书用户授权")
+	fmt.Println("=")
+	fmt.Println()
+	fmt.Printf("应用 ID: %s\n", appID)
+	fmt.Printf("回调地址: %s\n", redirectURI)
+	fmt.Println()
+	fmt.Println("请在浏览器中访问以下链接进行授权:")
+	fmt.Println()
+	fmt.Println(authorizeURL)
+	fmt.Println()
+}
+
+// waitCallbackAuthCode 启动本地 HTTP 服务器接收授权回调，返回授权码
+// ctx 取消时返回取消原因，由 Execute 映射为中断或超时的退出码
+func waitCallbackAuthCode(ctx context.Context, oauthClient *client.OAuthClient, state, appID string) (string, error) {
+	codeChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", loginHost, loginPort),
+		Handler: mux,
+	}
+
+	// 处理回调
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		code, err := client.ParseAuthorizationResponse("?"+r.URL.RawQuery, state)
+		if err != nil {
+			// state 不匹配的请求可能来自其他页面，忽略并继续等待
+			if r.URL.Query().Get("error") != "" {
+				select {
+				case errChan <- err:
+				default:
+				}
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case codeChan <- code:
+		default:
+		}
+
+		// 返回成功页面
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>授权成功</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; display: flex; justify-content: center; align-items: center; height: 100vh; margin: 0; background: #f5f5f5; }
+        .container { text-align: center; background: white; padding: 40px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .success-icon { font-size: 64px; color: #52c41a; margin-bottom: 20px; }
+        h1 { color: #333; margin-bottom: 10px; }
+        p { color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="success-icon">✓</div>
+        <h1>授权成功</h1>
+        <p>请返回终端查看 token 信息</p>
+        <p>您可以关闭此页面</p>
+    </div>
+</body>
+</html>`)
+	})
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return "", fmt.Errorf("启动本地服务器失败: %w（无法使用本地回调时可加 --no-browser）", err)
+	}
+	actualPort := listener.Addr().(*net.TCPAddr).Port
+	// 更新 OAuth 客户端的 redirect URI
+	oauthClient.RedirectURI = fmt.Sprintf("http://%s:%d/callback", loginHost, actualPort)
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("服务器错误: %w", err)
+		}
+	}()
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	printLoginHeader(appID, oauthClient.RedirectURI, oauthClient.GetAuthorizeURL(state, loginScope))
+	fmt.Println("等待授权...")
+	fmt.Println()
+
+	select {
+	case code := <-codeChan:
+		return code, nil
+	case err := <-errChan:
+		return "", err
+	case <-ctx.Done():
+		return "", fmt.Errorf("已取消授权: %w", context.Cause(ctx))
+	case <-time.After(loginWaitTimeout):
+		return "", fmt.Errorf("授权超时，请重新运行命令")
+	}
+}
+
+// waitPastedAuthCode 输出授权链接，从标准输入读取用户粘贴的回调地址或授权码
+// ctx 取消时返回取消原因，由 Execute 映射为中断或超时的退出码
+func waitPastedAuthCode(ctx context.Context, oauthClient *client.OAuthClient, state, appID string) (string, error) {
+	printLoginHeader(appID, oauthClient.RedirectURI, oauthClient.GetAuthorizeURL(state, loginScope))
+	fmt.Println("授权后浏览器会跳转到回调地址（页面可能无法打开），")
+	fmt.Println("请复制地址栏中的完整地址粘贴到下方并回车:")
+	fmt.Println()
+
+	type result struct {
+		line string
+		err  error
+	}
+	lines := make(chan result, 1)
+	go func() {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line != "" {
+			err = nil
+		}
+		lines <- result{line, err}
+	}()
+
+	select {
+	case r := <-lines:
+		if r.err != nil {
+			return "", fmt.Errorf("读取输入失败: %w", r.err)
+		}
+		return client.ParseAuthorizationResponse(r.line, state)
+	case <-ctx.Done():
+		return "", fmt.Errorf("已取消授权: %w", context.Cause(ctx))
+	case <-time.After(loginWaitTimeout):
+		return "", fmt.Errorf("授权超时，请重新运行命令")
+	}
+}
+
 func init() {
 	authCmd.AddCommand(authLoginCmd)
 	authLoginCmd.Flags().IntVarP(&loginPort, "port", "p", 8080, "本地服务器端口（0 表示随机端口）")
 	authLoginCmd.Flags().StringVarP(&loginHost, "host", "H", "127.0.0.1", "本地服务器主机地址")
 	authLoginCmd.Flags().StringVarP(&loginScope, "scope", "s", "", "OAuth scope（多个 scope 用空格分隔，如：\"contact:user.read chat:message\"）")
+	authLoginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "不启动本地服务器，手动粘贴授权后的回调地址或授权码（适用于远程开发机、容器、CI）")
+	authLoginCmd.Flags().BoolVar(&loginPKCE, "pkce", false, "使用 PKCE 保护授权码（OAuth 2.0 标准令牌接口）")
 }
 
 func min(a, b int) int {
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+func TestWaitCallbackAuthCode_Canceled(t *testing.T) {
+	oldHost, oldPort := loginHost, loginPort
+	loginHost, loginPort = "127.0.0.1", 0
+	defer func() { loginHost, loginPort = oldHost, oldPort }()
+
+	for _, cause := range []error{errCommandInterrupted, errCommandTimeout} {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+
+		code, err := waitCallbackAuthCode(ctx, &client.OAuthClient{AppID: "cli_test"}, "state", "cli_test")
+		if code != "" || !errors.Is(err, cause) {
+			t.Fatalf("waitCallbackAuthCode() = %q, %v, 期望返回取消原因 %v", code, err, cause)
+		}
+
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		want := map[error]int{errCommandInterrupted: exitCodeInterrupted, errCommandTimeout: exitCodeTimeout}[cause]
+		if got := exitCodeFor(cmd, err); got != want {
+			t.Errorf("%v: 退出码 = %d, 期望 %d", cause, got, want)
+		}
+	}
+}
@@ -44,7 +44,7 @@ var authRefreshCmd = &cobra.Command{
 
 		// 4. 刷新 token
 		fmt.Println("正在刷新 token...")
-		newToken, err := oauthClient.RefreshUserAccessToken(tok.RefreshToken)
+		newToken, err := oauthClient.RefreshUserAccessToken(tok)
 		if err != nil {
 			return fmt.Errorf("刷新 token 失败: %w", err)
 		}
@@ -65,7 +65,7 @@ var authRefreshCmd = &cobra.Command{
 			newToken.AccessToken[max(0, len(newToken.AccessToken)-10):])
 		fmt.Printf("新的过期时间: %s\n", newToken.FormatExpiry())
 		fmt.Println()
-		fmt.Println("Token 已更新到: ~/.lark_user_token")
+		fmt.Printf("Token 已更新到: %s\n", token.DisplayPath())
 		fmt.Println()
 
 		return nil
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/riba2534/feishu-cli/internal/config"
 	"github.com/riba2534/feishu-cli/internal/token"
@@ -18,8 +19,14 @@ var authStatusCmd = &cobra.Command{
 检查以下位置的 token（按优先级排序）:
 1. FEISHU_USER_ACCESS_TOKEN 环境变量
 2. 配置文件中的 user_access_token
-3. ~/.lark_user_token 文件`,
+3. 安全存储（系统密钥环或加密文件，见 feishu-cli config secret）
+4. 当前配置档案的明文 token 文件（默认 ~/.lark_user_token，命名档案为 ~/.feishu-cli/tokens/<profile>.json）`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(tokenStatus())
+		}
+
 		fmt.Println("=")
 		fmt.Println("User Access Token 状态")
 		fmt.Println("=")
@@ -62,7 +69,7 @@ var authStatusCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Println("Token 来源: ~/.lark_user_token")
+		fmt.Printf("Token 来源: %s\n", token.DisplayPath())
 		fmt.Println()
 		fmt.Println("Token 信息:")
 		fmt.Printf("  Access Token:  %s...%s\n",
@@ -87,6 +94,28 @@ var authStatusCmd = &cobra.Command{
 	},
 }
 
+// tokenStatus 返回 token 状态，不包含 token 内容
+func tokenStatus() map[string]any {
+	if os.Getenv("FEISHU_USER_ACCESS_TOKEN") != "" {
+		return map[string]any{"logged_in": true, "source": "env"}
+	}
+	if config.Get().UserAccessToken != "" {
+		return map[string]any{"logged_in": true, "source": "config"}
+	}
+	tok, err := token.LoadToken()
+	if err != nil {
+		return map[string]any{"logged_in": false}
+	}
+	return map[string]any{
+		"logged_in":  true,
+		"source":     token.DisplayPath(),
+		"expired":    tok.IsExpired(),
+		"expires_at": time.Unix(tok.ExpiresAt, 0).Format(time.RFC3339),
+		"scope":      tok.Scope,
+	}
+}
+
 func init() {
 	authCmd.AddCommand(authStatusCmd)
+	addOutputFlag(authStatusCmd)
 }
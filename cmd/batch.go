@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/batch"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "按计划文件批量执行操作",
+	Long: `按 YAML 计划文件批量执行操作，如创建文件夹、文档、表格，设置权限，发送消息等。
+
+子命令:
+  run       执行计划文件
+  actions   列出计划中可用的操作
+
+示例:
+  # 执行计划
+  feishu-cli batch run plan.yaml
+
+  # 查看可用的操作及参数
+  feishu-cli batch actions`,
+}
+
+var batchRunCmd = &cobra.Command{
+	Use:   "run <plan.yaml>",
+	Short: "执行计划文件",
+	Long: `执行 YAML 计划文件中的步骤，每个步骤完成后输出一行 JSON 结果（JSONL）。
+
+计划文件格式:
+  vars:                        # 变量，通过 ${{ vars.<名称> }} 引用
+    project: Apollo
+  concurrency: 2               # 同时执行的步骤数，默认 1
+  steps:
+    - id: folder               # 步骤 ID，默认 step-<序号>
+      action: folder.create    # 操作，见 feishu-cli batch actions
+      with:                    # 操作参数
+        name: ${{ vars.project }}
+    - id: doc
+      action: doc.create
+      with:
+        title: ${{ vars.project }} 设计文档
+        folder_token: ${{ steps.folder.folder_token }}
+    - action: msg.send
+      needs: [doc]             # 额外的依赖
+      continue-on-error: true  # 失败后继续执行其他步骤
+      with:
+        receive_id: ${{ env.CHAT_ID }}
+        text: 文档已创建: ${{ steps.doc.url }}
+
+表达式:
+  ${{ vars.<名称> }}           计划中的变量，可被 --var 和 --vars 覆盖
+  ${{ steps.<ID>.<字段> }}     之前步骤的输出，引用的步骤自动成为依赖
+  ${{ env.<名称> }}            环境变量
+  参数值恰好是一个表达式时保留引用值的类型（如数组），否则按字符串拼接。
+
+执行规则:
+  - 步骤在依赖的步骤成功后执行，依赖失败或被跳过时跳过该步骤
+  - 步骤失败时不再启动新的步骤，命令返回失败；设置 continue-on-error 的步骤除外
+  - 执行前校验整个计划（操作、参数、引用），有错误时不执行任何步骤
+
+结果日志:
+  每行一个 JSON 对象，包含 id、action、status（success/failed/skipped/planned）、
+  outputs、error 和 duration_ms，执行统计输出到 stderr。
+
+选项:
+  --var         设置变量 key=value，可重复指定
+  --vars        从 YAML/JSON 文件读取变量，可重复指定
+  --concurrency 覆盖计划中的 concurrency
+  --dry-run     （全局参数）只校验计划并输出展开后的参数，不执行任何操作
+  --log         将结果日志写入文件（默认输出到 stdout）
+
+示例:
+  # 执行计划
+  feishu-cli batch run project.yaml
+
+  # 覆盖变量并预览
+  feishu-cli batch run project.yaml --var project=Apollo --dry-run
+
+  # 结果写入日志文件，并发执行
+  feishu-cli batch run project.yaml --log result.jsonl --concurrency 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		varFiles, _ := cmd.Flags().GetStringArray("vars")
+		vars, _ := cmd.Flags().GetStringArray("var")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		logFile, _ := cmd.Flags().GetString("log")
+
+		if concurrency < 0 {
+			return newUsageError(fmt.Errorf("--concurrency 不能为负数: %d", concurrency))
+		}
+		overrides, err := loadTemplateVars(varFiles, nil, vars)
+		if err != nil {
+			return newUsageError(err)
+		}
+		plan, err := batch.LoadPlan(args[0])
+		if err != nil {
+			return newUsageError(err)
+		}
+		if err := plan.Validate(batchActions); err != nil {
+			return newUsageError(err)
+		}
+
+		// 操作执行过程中的提示信息输出到 stderr，stdout 只包含结果日志
+		var w io.Writer
+		if logFile != "" {
+			f, err := os.Create(logFile)
+			if err != nil {
+				return fmt.Errorf("创建日志文件失败: %w", err)
+			}
+			defer f.Close()
+			w = f
+		} else {
+			w = resultWriter()
+		}
+		if resultStdout == nil {
+			resultStdout = os.Stdout
+			os.Stdout = os.Stderr
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		var writeErr error
+		runner := &batch.Runner{
+			Actions:     batchActions,
+			Vars:        overrides,
+			Concurrency: concurrency,
+			DryRun:      dryRun,
+			OnResult: func(res *batch.Result) {
+				if err := enc.Encode(res); err != nil && writeErr == nil {
+					writeErr = fmt.Errorf("写入结果日志失败: %w", err)
+				}
+				if res.Status == batch.StatusFailed {
+					fmt.Fprintf(os.Stderr, "步骤 %s 失败: %s\n", res.ID, res.Error)
+				}
+			},
+		}
+		summary, err := runner.Run(ctx, plan)
+		if summary != nil {
+			if dryRun {
+				fmt.Fprintf(os.Stderr, "预览完成: 共 %d 个步骤\n", summary.Total)
+			} else {
+				fmt.Fprintf(os.Stderr, "执行完成: 共 %d 个步骤，成功 %d，失败 %d，跳过 %d\n",
+					summary.Total, summary.Succeeded, summary.Failed, summary.Skipped)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if writeErr != nil {
+			return writeErr
+		}
+		if summary.Failed > 0 {
+			// continue-on-error 的步骤失败时命令仍返回失败，便于脚本判断
+			return fmt.Errorf("%d 个步骤失败", summary.Failed)
+		}
+		return nil
+	},
+}
+
+var batchActionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "列出计划中可用的操作",
+	Long: `列出 batch run 计划中可用的操作、参数（* 为必填）和输出字段。
+
+示例:
+  feishu-cli batch actions`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := make([]string, 0, len(batchActions))
+		for name := range batchActions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			action := batchActions[name]
+			required := map[string]bool{}
+			for _, p := range action.Required {
+				required[p] = true
+			}
+			params := make([]string, 0, len(action.Params))
+			for _, p := range action.Params {
+				if required[p] {
+					p += "*"
+				}
+				params = append(params, p)
+			}
+
+			fmt.Printf("%s\n", name)
+			fmt.Printf("  %s\n", action.Description)
+			fmt.Printf("  参数: %s\n", strings.Join(params, ", "))
+			if len(action.Outputs) > 0 {
+				fmt.Printf("  输出: %s\n", strings.Join(action.Outputs, ", "))
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+	batchCmd.AddCommand(batchRunCmd)
+	batchCmd.AddCommand(batchActionsCmd)
+
+	batchRunCmd.Flags().StringArray("var", nil, "设置变量 key=value，可重复指定")
+	batchRunCmd.Flags().StringArray("vars", nil, "从 YAML/JSON 文件读取变量，可重复指定")
+	batchRunCmd.Flags().Int("concurrency", 0, "同时执行的步骤数（覆盖计划中的 concurrency）")
+	batchRunCmd.Flags().String("log", "", "将结果日志写入文件（默认输出到 stdout）")
+}
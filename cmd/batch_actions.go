@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/riba2534/feishu-cli/internal/batch"
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+// batchActions batch run 计划中可用的操作
+var batchActions = map[string]batch.Action{
+	"folder.create": {
+		Description: "创建文件夹",
+		Params:      []string{"name", "parent_token"},
+		Required:    []string{"name"},
+		Outputs:     []string{"folder_token", "url"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			name, err := p.RequiredString("name")
+			if err != nil {
+				return nil, err
+			}
+			parent, err := p.String("parent_token")
+			if err != nil {
+				return nil, err
+			}
+			token, url, err := client.CreateFolder(ctx, name, parent)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"folder_token": token, "url": url}, nil
+		},
+	},
+	"doc.create": {
+		Description: "创建空文档",
+		Params:      []string{"title", "folder_token"},
+		Required:    []string{"title"},
+		Outputs:     []string{"document_id", "url"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			title, err := p.RequiredString("title")
+			if err != nil {
+				return nil, err
+			}
+			folder, err := p.String("folder_token")
+			if err != nil {
+				return nil, err
+			}
+			doc, err := client.CreateDocument(ctx, title, folder)
+			if err != nil {
+				return nil, err
+			}
+			if doc.DocumentId == nil {
+				return nil, fmt.Errorf("文档已创建但未返回ID")
+			}
+			return documentOutputs(*doc.DocumentId), nil
+		},
+	},
+	"doc.import": {
+		Description: "从 Markdown 文件创建文档（支持 front matter、图表和表格）",
+		Params:      []string{"file", "title", "folder_token", "wiki_parent", "upload_images"},
+		Required:    []string{"file"},
+		Outputs:     []string{"document_id", "url"},
+		Run:         runBatchDocImport,
+	},
+	"sheet.create": {
+		Description: "创建电子表格",
+		Params:      []string{"title", "folder_token"},
+		Required:    []string{"title"},
+		Outputs:     []string{"spreadsheet_token", "url"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			title, err := p.RequiredString("title")
+			if err != nil {
+				return nil, err
+			}
+			folder, err := p.String("folder_token")
+			if err != nil {
+				return nil, err
+			}
+			info, err := client.CreateSpreadsheet(ctx, title, folder)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"spreadsheet_token": info.SpreadsheetToken, "url": info.URL}, nil
+		},
+	},
+	"sheet.write": {
+		Description: "写入电子表格范围",
+		Params:      []string{"spreadsheet_token", "range", "values"},
+		Required:    []string{"spreadsheet_token", "range", "values"},
+		Outputs:     []string{"range"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			token, err := p.RequiredString("spreadsheet_token")
+			if err != nil {
+				return nil, err
+			}
+			rangeStr, err := p.RequiredString("range")
+			if err != nil {
+				return nil, err
+			}
+			values, err := p.Rows("values")
+			if err != nil {
+				return nil, err
+			}
+			result, err := client.WriteCells(ctx, token, unescapeSheetRange(rangeStr), values)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"range": result.Range}, nil
+		},
+	},
+	"perm.add": {
+		Description: "添加协作者权限",
+		Params:      []string{"token", "doc_type", "member_type", "member_id", "perm", "notify"},
+		Required:    []string{"token", "member_type", "member_id"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			token, err := p.RequiredString("token")
+			if err != nil {
+				return nil, err
+			}
+			docType, err := p.String("doc_type")
+			if err != nil {
+				return nil, err
+			}
+			if docType == "" {
+				docType = "docx"
+			}
+			member := client.PermissionMember{Perm: "view"}
+			if member.MemberType, err = p.RequiredString("member_type"); err != nil {
+				return nil, err
+			}
+			if member.MemberID, err = p.RequiredString("member_id"); err != nil {
+				return nil, err
+			}
+			if perm, err := p.String("perm"); err != nil {
+				return nil, err
+			} else if perm != "" {
+				member.Perm = perm
+			}
+			notify, err := p.Bool("notify", false)
+			if err != nil {
+				return nil, err
+			}
+			if err := client.AddPermission(ctx, token, docType, member, notify); err != nil {
+				return nil, err
+			}
+			return map[string]any{}, nil
+		},
+	},
+	"msg.send": {
+		Description: "发送消息：text 发送文本，或用 msg_type 和 content 发送其他类型",
+		Params:      []string{"receive_id_type", "receive_id", "text", "msg_type", "content"},
+		Required:    []string{"receive_id"},
+		Outputs:     []string{"message_id"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			receiveIDType, err := p.String("receive_id_type")
+			if err != nil {
+				return nil, err
+			}
+			if receiveIDType == "" {
+				receiveIDType = "chat_id"
+			}
+			receiveID, err := p.RequiredString("receive_id")
+			if err != nil {
+				return nil, err
+			}
+			msgType, content, err := batchMessageContent(p)
+			if err != nil {
+				return nil, err
+			}
+			messageID, err := client.SendMessage(ctx, receiveIDType, receiveID, msgType, content)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"message_id": messageID}, nil
+		},
+	},
+	"task.create": {
+		Description: "创建任务",
+		Params:      []string{"summary", "description", "due"},
+		Required:    []string{"summary"},
+		Outputs:     []string{"task_id"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			var opts client.CreateTaskOptions
+			var err error
+			if opts.Summary, err = p.RequiredString("summary"); err != nil {
+				return nil, err
+			}
+			if opts.Description, err = p.String("description"); err != nil {
+				return nil, err
+			}
+			due, err := p.String("due")
+			if err != nil {
+				return nil, err
+			}
+			if due != "" {
+				dueTime, err := parseTime(due)
+				if err != nil {
+					return nil, fmt.Errorf("无效的截止时间: %w", err)
+				}
+				opts.DueTimestamp = dueTime.UnixMilli()
+			}
+			task, err := client.CreateTask(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"task_id": task.Guid}, nil
+		},
+	},
+	"wiki.create": {
+		Description: "在知识空间中创建节点",
+		Params:      []string{"space_id", "title", "parent_node", "obj_type"},
+		Required:    []string{"space_id", "title"},
+		Outputs:     []string{"node_token", "obj_token", "url"},
+		Run: func(ctx context.Context, p batch.Params) (map[string]any, error) {
+			spaceID, err := p.RequiredString("space_id")
+			if err != nil {
+				return nil, err
+			}
+			title, err := p.RequiredString("title")
+			if err != nil {
+				return nil, err
+			}
+			parent, err := p.String("parent_node")
+			if err != nil {
+				return nil, err
+			}
+			objType, err := p.String("obj_type")
+			if err != nil {
+				return nil, err
+			}
+			node, err := client.CreateWikiNode(ctx, spaceID, title, parent, objType)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{
+				"node_token": node.NodeToken,
+				"obj_token":  node.ObjToken,
+				"url":        "https://feishu.cn/wiki/" + node.NodeToken,
+			}, nil
+		},
+	},
+}
+
+// documentOutputs 文档类操作的输出
+func documentOutputs(documentID string) map[string]any {
+	return map[string]any{
+		"document_id": documentID,
+		"url":         "https://feishu.cn/docx/" + documentID,
+	}
+}
+
+// runBatchDocImport 与 doc import 相同：参数优先于 front matter，导入后应用 front matter 中的权限
+func runBatchDocImport(ctx context.Context, p batch.Params) (map[string]any, error) {
+	filePath, err := p.RequiredString("file")
+	if err != nil {
+		return nil, err
+	}
+	title, err := p.String("title")
+	if err != nil {
+		return nil, err
+	}
+	folder, err := p.String("folder_token")
+	if err != nil {
+		return nil, err
+	}
+	wikiParent, err := p.String("wiki_parent")
+	if err != nil {
+		return nil, err
+	}
+	opts := defaultImportPipelineOptions()
+	opts.quiet = true
+	if opts.uploadImages, err = p.Bool("upload_images", opts.uploadImages); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	fm, markdownText, err := converter.ParseFrontMatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+	if fm != nil {
+		if title == "" {
+			title = fm.Title
+		}
+		if folder == "" {
+			folder = fm.Folder
+		}
+		if wikiParent == "" {
+			wikiParent = fm.WikiParent
+		}
+	}
+	if title == "" {
+		title = titleFromFileName(filePath)
+	}
+
+	documentID, err := createTargetDocument(ctx, title, folder, wikiParent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := runImportPipeline(ctx, documentID, markdownText, filepath.Dir(filePath), opts); err != nil {
+		return nil, fmt.Errorf("导入文档 %s 失败: %w", documentID, err)
+	}
+	applyFrontMatterPublishing(ctx, documentID, fm, true)
+	return documentOutputs(documentID), nil
+}
+
+// batchMessageContent 返回消息类型和内容，content 可以是 JSON 字符串或对象
+func batchMessageContent(p batch.Params) (string, string, error) {
+	text, err := p.String("text")
+	if err != nil {
+		return "", "", err
+	}
+	msgType, err := p.String("msg_type")
+	if err != nil {
+		return "", "", err
+	}
+	if text != "" {
+		if msgType != "" && msgType != "text" {
+			return "", "", fmt.Errorf("text 只能用于 msg_type: text")
+		}
+		return "text", client.CreateTextMessageContent(text), nil
+	}
+
+	if msgType == "" {
+		return "", "", fmt.Errorf("需要指定 text，或同时指定 msg_type 和 content")
+	}
+	switch content := p["content"].(type) {
+	case string:
+		if content == "" {
+			return "", "", fmt.Errorf("参数 content 不能为空")
+		}
+		return msgType, content, nil
+	case map[string]any:
+		data, err := json.Marshal(content)
+		if err != nil {
+			return "", "", fmt.Errorf("序列化 content 失败: %w", err)
+		}
+		return msgType, string(data), nil
+	case nil:
+		return "", "", fmt.Errorf("缺少参数 content")
+	default:
+		return "", "", fmt.Errorf("参数 content 应为 JSON 字符串或对象")
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/batch"
+)
+
+func TestBatchActionsDeclareParams(t *testing.T) {
+	for name, action := range batchActions {
+		if action.Run == nil {
+			t.Errorf("操作 %s 没有 Run", name)
+		}
+		params := map[string]bool{}
+		for _, p := range action.Params {
+			params[p] = true
+		}
+		for _, p := range action.Required {
+			if !params[p] {
+				t.Errorf("操作 %s 的必填参数 %s 不在 Params 中", name, p)
+			}
+		}
+	}
+}
+
+func TestBatchMessageContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      batch.Params
+		wantType    string
+		wantContent string
+		wantErr     bool
+	}{
+		{"文本", batch.Params{"text": "hi"}, "text", `{"text":"hi"}`, false},
+		{"对象内容", batch.Params{"msg_type": "post", "content": map[string]any{"zh_cn": map[string]any{"title": "t"}}}, "post", `{"zh_cn":{"title":"t"}}`, false},
+		{"字符串内容", batch.Params{"msg_type": "interactive", "content": `{"a":1}`}, "interactive", `{"a":1}`, false},
+		{"text 与其他类型", batch.Params{"text": "hi", "msg_type": "post"}, "", "", true},
+		{"缺少内容", batch.Params{"msg_type": "post"}, "", "", true},
+		{"都未指定", batch.Params{}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgType, content, err := batchMessageContent(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("batchMessageContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if msgType != tt.wantType || content != tt.wantContent {
+				t.Errorf("batchMessageContent() = %q, %q, 期望 %q, %q", msgType, content, tt.wantType, tt.wantContent)
+			}
+		})
+	}
+}
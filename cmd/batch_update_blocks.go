@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -19,6 +20,8 @@ var batchUpdateBlocksCmd = &cobra.Command{
   <requests>                更新请求列表，JSON 格式（必填）
   --source-type             源类型：file/content，默认 file
   --document-revision-id    文档版本 ID，-1 表示最新
+  --expect-revision         期望的文档版本号，文档已被修改时拒绝写入（退出码 3）
+  --rebase                  版本冲突但请求涉及的块均未被修改时，基于最新版本继续写入
   --client-token            UUIDv4，用于幂等更新
   --user-id-type            用户 ID 类型，默认 open_id
   --output, -o              输出格式 (json)
@@ -41,13 +44,17 @@ var batchUpdateBlocksCmd = &cobra.Command{
   feishu-cli doc batch-update DOC_ID '[{"block_id":"xxx","update_text_elements":{"elements":[{"text_run":{"content":"新内容"}}]}}]' --source-type content
 
   # 使用幂等 token
-  feishu-cli doc batch-update DOC_ID requests.json --client-token abc123`,
+  feishu-cli doc batch-update DOC_ID requests.json --client-token abc123
+
+  # 仅在文档仍为版本 42 时写入
+  feishu-cli doc batch-update DOC_ID requests.json --expect-revision 42`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		source := args[1]
 		sourceType, _ := cmd.Flags().GetString("source-type")
@@ -69,25 +76,48 @@ var batchUpdateBlocksCmd = &cobra.Command{
 			requestsJSON = string(data)
 		}
 
+		// 指定 --expect-revision 时以校验后的版本写入
+		guard := newRevisionGuard(cmd, documentID)
+		if guard.expected > 0 {
+			var targets []struct {
+				BlockID string `json:"block_id"`
+			}
+			if err := json.Unmarshal([]byte(requestsJSON), &targets); err != nil {
+				return fmt.Errorf("解析请求 JSON 失败: %w", err)
+			}
+			var touched []string
+			for _, t := range targets {
+				if t.BlockID != "" {
+					touched = append(touched, t.BlockID)
+				}
+			}
+			revision, err := guard.resolve(ctx, touched...)
+			if err != nil {
+				return err
+			}
+			documentRevisionID = revision
+		}
+
 		opts := client.BatchUpdateBlocksOptions{
 			DocumentRevisionID: documentRevisionID,
 			ClientToken:        clientToken,
 			UserIDType:         userIDType,
 		}
 
-		result, err := client.BatchUpdateBlocks(documentID, requestsJSON, opts)
+		result, err := client.BatchUpdateBlocks(ctx, documentID, requestsJSON, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
 			fmt.Printf("批量更新成功！\n")
 			fmt.Printf("  文档 ID: %s\n", documentID)
 			fmt.Printf("  更新块数: %d\n", len(result.BlockIDs))
+			fmt.Printf("  文档版本: %d\n", result.DocumentRevision)
 			for i, id := range result.BlockIDs {
 				fmt.Printf("  [%d] 块 ID: %s\n", i+1, id)
 			}
@@ -103,5 +133,6 @@ func init() {
 	batchUpdateBlocksCmd.Flags().Int("document-revision-id", -1, "文档版本 ID（-1 表示最新）")
 	batchUpdateBlocksCmd.Flags().String("client-token", "", "操作唯一标识（幂等）")
 	batchUpdateBlocksCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型")
-	batchUpdateBlocksCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(batchUpdateBlocksCmd)
+	addRevisionFlags(batchUpdateBlocksCmd)
 }
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/spf13/cobra"
+)
+
+// mentionDocTypes @文档 的 obj_type 与云文档类型的对应关系
+var mentionDocTypes = map[int]string{
+	1:  "doc",
+	3:  "sheet",
+	8:  "bitable",
+	11: "mindnote",
+	12: "file",
+	15: "slides",
+	16: "wiki",
+	22: "docx",
+}
+
+// feishuURLTypes 飞书链接路径前缀与云文档类型的对应关系
+var feishuURLTypes = map[string]string{
+	"docx":      "docx",
+	"docs":      "doc",
+	"wiki":      "wiki",
+	"sheets":    "sheet",
+	"base":      "bitable",
+	"mindnotes": "mindnote",
+	"file":      "file",
+	"slides":    "slides",
+}
+
+// feishuHosts 飞书云文档使用的域名
+var feishuHosts = []string{"feishu.cn", "feishu.net", "larksuite.com", "larkoffice.com"}
+
+// parseFeishuURL 解析飞书云文档链接，返回文档 token 和类型
+// isFeishu 表示链接属于飞书域名；无法识别的路径（如文件夹）docType 为空
+func parseFeishuURL(raw string) (token, docType string, isFeishu bool) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	if !matchHost(u.Hostname(), feishuHosts) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 2 {
+		if t, ok := feishuURLTypes[parts[0]]; ok && isValidToken(parts[1]) {
+			return parts[1], t, true
+		}
+	}
+	return "", "", true
+}
+
+// matchHost 判断域名是否为列表中的域名或其子域名
+func matchHost(host string, domains []string) bool {
+	host = strings.ToLower(host)
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "*."))
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDocReference 检查云文档是否存在且可访问，未知类型（docType 为空）视为有效
+func checkDocReference(ctx context.Context, token, docType string) error {
+	switch docType {
+	case "":
+		return nil
+	case "docx":
+		_, err := client.GetDocument(ctx, token)
+		return err
+	case "wiki":
+		_, err := client.GetWikiNode(ctx, token)
+		return err
+	default:
+		_, err := client.GetFileMeta(ctx, token, docType)
+		return err
+	}
+}
+
+// linkSource 待检查的文档
+type linkSource struct {
+	DocumentID string `json:"document_id"`
+	Title      string `json:"title"`
+	Path       string `json:"path,omitempty"` // 在知识库或文件夹中的路径
+}
+
+// brokenLink 失效的链接
+type brokenLink struct {
+	SourceDoc   string `json:"source_doc"`
+	SourceTitle string `json:"source_title"`
+	BlockID     string `json:"block_id"`
+	Kind        string `json:"kind"`
+	Text        string `json:"text,omitempty"`
+	Target      string `json:"target"`
+	Reason      string `json:"reason"`
+}
+
+// linkCheckReport 链接检查结果
+type linkCheckReport struct {
+	Documents int          `json:"documents"`
+	Links     int          `json:"links"`
+	Broken    []brokenLink `json:"broken"`
+	Errors    []string     `json:"errors,omitempty"` // 无法读取的文档和暂时无法检查的链接（限流、网络错误等）
+}
+
+// linkChecker 检查文档中的链接，相同目标只检查一次
+type linkChecker struct {
+	external   bool
+	allowHosts []string
+	allowURLs  []string
+	httpClient *http.Client
+	cache      map[string]string // 目标 -> 失效原因（有效时为空）
+}
+
+// addLinkCheckFlags 添加链接检查命令的公共参数
+func addLinkCheckFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("external", false, "通过 HTTP HEAD 请求检查外部链接")
+	cmd.Flags().StringSlice("allow-host", nil, "视为有效、不做检查的外部域名（含子域名，可重复）")
+	cmd.Flags().String("allow-list", "", "本地允许列表文件，每行一个域名或 URL 前缀，# 开头为注释")
+	cmd.Flags().Duration("http-timeout", 10*time.Second, "外部链接检查的超时时间")
+	cmd.Flags().Bool("exit-code", false, "存在失效链接时返回非零退出码")
+	cmd.Flags().BoolP("verbose", "v", false, "显示检查进度")
+	addOutputFlag(cmd)
+}
+
+// newLinkChecker 从命令参数创建链接检查器
+func newLinkChecker(cmd *cobra.Command) (*linkChecker, error) {
+	external, _ := cmd.Flags().GetBool("external")
+	allowHosts, _ := cmd.Flags().GetStringSlice("allow-host")
+	allowList, _ := cmd.Flags().GetString("allow-list")
+	timeout, _ := cmd.Flags().GetDuration("http-timeout")
+
+	c := &linkChecker{
+		external:   external,
+		allowHosts: allowHosts,
+		httpClient: &http.Client{Timeout: timeout},
+		cache:      map[string]string{},
+	}
+	if allowList != "" {
+		if err := c.loadAllowList(allowList); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// loadAllowList 读取允许列表文件，包含 "://" 的行视为 URL 前缀，其余视为域名
+func (c *linkChecker) loadAllowList(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("读取允许列表失败: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, "://") {
+			c.allowURLs = append(c.allowURLs, line)
+		} else {
+			c.allowHosts = append(c.allowHosts, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取允许列表失败: %w", err)
+	}
+	return nil
+}
+
+// run 依次检查所有文档，单个文档读取失败时记录错误并继续，ctx 取消时返回已检查部分的结果
+func (c *linkChecker) run(ctx context.Context, sources []linkSource, verbose bool) *linkCheckReport {
+	report := &linkCheckReport{Broken: []brokenLink{}}
+	for i, src := range sources {
+		if ctx.Err() != nil {
+			break
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "[%d/%d] 检查 %s (%s)\n", i+1, len(sources), src.Title, src.DocumentID)
+		}
+		blocks, err := client.GetAllBlocks(ctx, src.DocumentID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s (%s): %v", src.Title, src.DocumentID, err))
+			continue
+		}
+		report.Documents++
+
+		for _, ref := range converter.CollectLinks(blocks) {
+			if ctx.Err() != nil {
+				break
+			}
+			report.Links++
+			target, reason, err := c.check(ctx, ref)
+			if err != nil {
+				if ctx.Err() == nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("%s (%s) 块 %s 的链接 %s: %v", src.Title, src.DocumentID, ref.BlockID, target, err))
+				}
+				continue
+			}
+			if reason == "" {
+				continue
+			}
+			report.Broken = append(report.Broken, brokenLink{
+				SourceDoc:   src.DocumentID,
+				SourceTitle: src.Title,
+				BlockID:     ref.BlockID,
+				Kind:        ref.Kind,
+				Text:        ref.Text,
+				Target:      target,
+				Reason:      reason,
+			})
+		}
+	}
+	return report
+}
+
+// check 检查单个链接，返回链接目标和失效原因（有效时原因为空）
+// 无法确定链接是否有效时（限流、服务端错误、网络错误、中断）返回 err
+func (c *linkChecker) check(ctx context.Context, ref converter.LinkRef) (target, reason string, err error) {
+	if ref.Kind == converter.LinkKindMention {
+		if ref.Token == "" {
+			return ref.URL, "@文档 缺少 token", nil
+		}
+		reason, err := c.cached(mentionDocTypes[ref.ObjType]+":"+ref.Token, func() (string, error) {
+			return docReferenceReason(checkDocReference(ctx, ref.Token, mentionDocTypes[ref.ObjType]))
+		})
+		return ref.Token, reason, err
+	}
+
+	if token, docType, isFeishu := parseFeishuURL(ref.URL); isFeishu {
+		reason, err := c.cached(docType+":"+token, func() (string, error) {
+			return docReferenceReason(checkDocReference(ctx, token, docType))
+		})
+		return ref.URL, reason, err
+	}
+
+	u, err := url.Parse(ref.URL)
+	if err != nil {
+		return ref.URL, "链接格式无效", nil
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		// mailto:、tel: 等链接无法检查
+		return ref.URL, "", nil
+	}
+	if !c.external || c.allowed(u) {
+		return ref.URL, "", nil
+	}
+	reason, err = c.cached(ref.URL, func() (string, error) {
+		return c.checkExternal(ctx, ref.URL)
+	})
+	return ref.URL, reason, err
+}
+
+// cached 返回缓存的检查结果，未检查过时执行 check；检查出错时不缓存，下次重新检查
+func (c *linkChecker) cached(key string, check func() (string, error)) (string, error) {
+	if reason, ok := c.cache[key]; ok {
+		return reason, nil
+	}
+	reason, err := check()
+	if err != nil {
+		return "", err
+	}
+	c.cache[key] = reason
+	return reason, nil
+}
+
+// allowed 判断外部链接是否在允许列表中
+func (c *linkChecker) allowed(u *url.URL) bool {
+	if matchHost(u.Hostname(), c.allowHosts) {
+		return true
+	}
+	for _, prefix := range c.allowURLs {
+		if strings.HasPrefix(u.String(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExternal 通过 HEAD 请求检查外部链接，服务器不支持 HEAD 时改用 GET
+// 连接失败视为失效，ctx 取消时返回错误
+func (c *linkChecker) checkExternal(ctx context.Context, rawURL string) (string, error) {
+	status, err := c.request(ctx, http.MethodHead, rawURL)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, rawURL)
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if err != nil {
+		return err.Error(), nil
+	}
+	if status >= 400 {
+		return fmt.Sprintf("HTTP %d", status), nil
+	}
+	return "", nil
+}
+
+// request 发送请求并返回状态码
+func (c *linkChecker) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "feishu-cli-link-checker")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// docReferenceReason 将文档检查的错误分类：文档不存在或无权访问时返回失效原因，
+// 限流、服务端错误、网络错误和中断无法说明链接失效，原样返回错误
+func docReferenceReason(err error) (string, error) {
+	switch client.ErrorKindOf(err) {
+	case "":
+		return "", nil
+	case client.ErrorKindNotFound, client.ErrorKindPermission:
+		return err.Error(), nil
+	}
+	return "", err
+}
+
+// printLinkReport 输出链接检查结果，--exit-code 时存在失效链接返回错误
+func printLinkReport(cmd *cobra.Command, report *linkCheckReport) error {
+	output, _ := cmd.Flags().GetString("output")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+
+	if isStructuredOutput(output) {
+		if err := printOutput(report); err != nil {
+			return err
+		}
+	} else {
+		for _, b := range report.Broken {
+			fmt.Printf("%s (%s)\n", b.SourceTitle, b.SourceDoc)
+			fmt.Printf("  块:   %s\n", b.BlockID)
+			if b.Text != "" {
+				fmt.Printf("  链接: [%s] %s\n", b.Text, b.Target)
+			} else {
+				fmt.Printf("  链接: %s\n", b.Target)
+			}
+			fmt.Printf("  原因: %s\n\n", b.Reason)
+		}
+		for _, e := range report.Errors {
+			fmt.Fprintf(os.Stderr, "检查失败: %s\n", e)
+		}
+		fmt.Printf("已检查 %d 个文档、%d 个链接，发现 %d 个失效链接\n", report.Documents, report.Links, len(report.Broken))
+	}
+
+	// 中断时输出已检查部分的结果后返回错误
+	if err := cmd.Context().Err(); err != nil {
+		return err
+	}
+	if exitCode && len(report.Broken) > 0 {
+		return fmt.Errorf("发现 %d 个失效链接", len(report.Broken))
+	}
+	return nil
+}
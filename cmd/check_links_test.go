@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+func TestParseFeishuURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		token    string
+		docType  string
+		isFeishu bool
+	}{
+		{"https://example.feishu.cn/docx/ABCdef12345", "ABCdef12345", "docx", true},
+		{"https://example.larkoffice.com/wiki/Wiki12345?from=x#block", "Wiki12345", "wiki", true},
+		{"https://example.feishu.cn/sheets/Sheet12345", "Sheet12345", "sheet", true},
+		{"https://example.feishu.cn/drive/folder/Fld12345", "", "", true},
+		{"https://feishu.cn.evil.com/docx/ABCdef12345", "", "", false},
+		{"https://github.com/docx/ABCdef12345", "", "", false},
+		{"mailto:someone@example.com", "", "", false},
+	}
+
+	for _, tt := range tests {
+		token, docType, isFeishu := parseFeishuURL(tt.url)
+		if token != tt.token || docType != tt.docType || isFeishu != tt.isFeishu {
+			t.Errorf("parseFeishuURL(%q) = (%q, %q, %v), 期望 (%q, %q, %v)",
+				tt.url, token, docType, isFeishu, tt.token, tt.docType, tt.isFeishu)
+		}
+	}
+}
+
+func TestLinkCheckerExternal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/head-not-allowed":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &linkChecker{
+		external:   true,
+		allowURLs:  []string{server.URL + "/allowed"},
+		httpClient: server.Client(),
+		cache:      map[string]string{},
+	}
+
+	tests := []struct {
+		path   string
+		broken bool
+	}{
+		{"/ok", false},
+		{"/head-not-allowed", false},
+		{"/missing", true},
+		{"/allowed/missing", false},
+	}
+	for _, tt := range tests {
+		_, reason, err := c.check(context.Background(), converter.LinkRef{Kind: converter.LinkKindURL, URL: server.URL + tt.path})
+		if err != nil {
+			t.Errorf("检查 %s 返回错误: %v", tt.path, err)
+		}
+		if (reason != "") != tt.broken {
+			t.Errorf("检查 %s: 原因 = %q, 期望失效 = %v", tt.path, reason, tt.broken)
+		}
+	}
+
+	c.external = false
+	if _, reason, _ := c.check(context.Background(), converter.LinkRef{Kind: converter.LinkKindURL, URL: "https://unreachable.invalid/x"}); reason != "" {
+		t.Errorf("未启用 --external 时不应检查外部链接, 原因 = %q", reason)
+	}
+}
+
+func TestDocReferenceReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantBroken bool
+		wantErr    bool
+	}{
+		{"有效", nil, false, false},
+		{"文档不存在", &client.APIError{Op: "获取文档失败", Code: 1770002, Msg: "not found"}, true, false},
+		{"无权访问", &client.APIError{Op: "获取文档失败", Code: 1770032, Msg: "forbidden"}, true, false},
+		{"元数据不存在", &client.APIError{Op: "获取文档元数据失败", Code: 970005, Msg: "failed_list"}, true, false},
+		{"限流", &client.APIError{Op: "获取文档失败", Code: 99991400, Msg: "request trigger frequency limit"}, false, true},
+		{"服务端错误", &client.APIError{Op: "获取文档失败", Code: 1, Msg: "internal error", HTTPStatus: 500}, false, true},
+		{"中断", fmt.Errorf("获取文档失败: %w", context.Canceled), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := docReferenceReason(tt.err)
+			if (reason != "") != tt.wantBroken || (err != nil) != tt.wantErr {
+				t.Errorf("docReferenceReason() = (%q, %v), 期望失效 = %v, 错误 = %v", reason, err, tt.wantBroken, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinkCheckerCache_SkipsErrors(t *testing.T) {
+	c := &linkChecker{cache: map[string]string{}}
+	calls := 0
+	check := func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", context.DeadlineExceeded
+		}
+		return "", nil
+	}
+	if _, err := c.cached("docx:abc", check); err == nil {
+		t.Fatal("第一次检查应返回错误")
+	}
+	if _, err := c.cached("docx:abc", check); err != nil || calls != 2 {
+		t.Errorf("出错的结果不应缓存: err = %v, 调用次数 = %d", err, calls)
+	}
+	_, _ = c.cached("docx:abc", check)
+	if calls != 2 {
+		t.Errorf("成功的结果应缓存, 调用次数 = %d", calls)
+	}
+}
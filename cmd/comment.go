@@ -7,13 +7,19 @@ import (
 var commentCmd = &cobra.Command{
 	Use:   "comment",
 	Short: "评论操作命令",
-	Long: `文档评论操作命令，包括列出评论、添加评论、获取评论详情、删除评论等。
+	Long: `文档评论操作命令，包括列出评论、添加评论、回复、解决评论等。
 
 子命令:
-  list      列出文档评论
-  add       添加评论
-  get       获取评论详情
-  delete    删除评论
+  list          列出文档评论（含回复链）
+  add           添加全文评论或引用文本的局部评论
+  get           获取评论详情
+  delete        删除评论
+  reply         回复评论
+  update-reply  更新评论回复
+  delete-reply  删除评论回复
+  resolve       将评论标记为已解决
+  unresolve     重新打开已解决的评论
+  import        批量导入审阅意见为局部评论
 
 文件类型（--type）:
   doc       旧版文档
@@ -32,7 +38,11 @@ var commentCmd = &cobra.Command{
   feishu-cli comment get <file_token> <comment_id> --type docx
 
   # 删除评论
-  feishu-cli comment delete <file_token> <comment_id> --type docx`,
+  feishu-cli comment delete <file_token> <comment_id> --type docx
+
+  # 回复并解决评论
+  feishu-cli comment reply <file_token> <comment_id> --type docx --text "已修复"
+  feishu-cli comment resolve <file_token> <comment_id> --type docx`,
 }
 
 func init() {
@@ -29,16 +29,17 @@ var completeTaskCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		taskGuid := args[0]
 
-		task, err := client.CompleteTask(taskGuid)
+		task, err := client.CompleteTask(ctx, taskGuid)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(task); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(task); err != nil {
 				return err
 			}
 		} else {
@@ -56,5 +57,5 @@ var completeTaskCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(completeTaskCmd)
-	completeTaskCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(completeTaskCmd)
 }
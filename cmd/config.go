@@ -10,19 +10,24 @@ var configCmd = &cobra.Command{
 	Long: `配置管理命令，用于初始化和管理 CLI 配置。
 
 子命令:
-  init    初始化配置文件
+  init       初始化配置文件
+  profile    管理配置档案（多个应用或租户）
+  secret     密钥安全存储（系统密钥环或加密文件）
 
 配置文件位置:
   ~/.feishu-cli/config.yaml
 
 配置优先级:
-  环境变量 > 配置文件 > 默认值
+  环境变量 > 配置档案 > 配置文件顶层配置 > 默认值
 
 环境变量:
   FEISHU_APP_ID      应用 ID
   FEISHU_APP_SECRET  应用密钥
   FEISHU_BASE_URL    API 地址（可选）
   FEISHU_DEBUG       调试模式（可选）
+  FEISHU_PROFILE     使用的配置档案（可选，--profile 优先）
+  FEISHU_SECRET_STORE       密钥存储后端（可选: auto/keyring/file/plaintext）
+  FEISHU_SECRET_PASSPHRASE  加密文件存储的口令（可选）
 
 示例:
   # 初始化配置文件
@@ -30,7 +35,10 @@ var configCmd = &cobra.Command{
 
   # 使用环境变量
   export FEISHU_APP_ID="cli_xxx"
-  export FEISHU_APP_SECRET="xxx"`,
+  export FEISHU_APP_SECRET="xxx"
+
+  # 添加并切换到 Lark 国际版档案
+  feishu-cli config profile add lark --app-id cli_xxx --app-secret xxx --lark --use`,
 }
 
 func init() {
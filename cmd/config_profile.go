@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "配置档案管理",
+	Long: `管理配置文件中的命名档案，用于在多个应用或租户（如飞书与 Lark 国际版）之间切换。
+
+每个档案可单独设置 app_id、app_secret、base_url 等配置，未设置的项沿用顶层配置；
+凭证（app_secret、app_secret_cmd、user_access_token）除外，只从档案和环境变量读取。
+每个档案使用独立的用户 Token 文件:
+  默认配置:  ~/.lark_user_token
+  命名档案:  ~/.feishu-cli/tokens/<profile>.json
+
+档案选择优先级:
+  --profile 参数 > FEISHU_PROFILE 环境变量 > 配置文件中的 current_profile > 顶层配置
+
+子命令:
+  list    列出所有档案
+  add     添加或更新档案
+  use     设置默认使用的档案
+
+示例:
+  feishu-cli config profile add lark --app-id cli_xxx --app-secret xxx --lark
+  feishu-cli config profile use lark
+  feishu-cli --profile default doc get <document_id>`,
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出配置档案",
+	Long: `列出配置文件中的所有档案，并标记当前生效的档案。
+
+default 表示配置文件的顶层配置。
+
+示例:
+  feishu-cli config profile list
+  feishu-cli config profile list -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		path, err := config.FilePath(cfgFile)
+		if err != nil {
+			return err
+		}
+		profiles, err := config.ListProfiles(path)
+		if err != nil {
+			return err
+		}
+
+		// --profile 和 FEISHU_PROFILE 优先于配置文件中的 current_profile
+		active := profile
+		if active == "" {
+			active = os.Getenv("FEISHU_PROFILE")
+		}
+		if active != "" {
+			for _, p := range profiles {
+				p.Current = p.Name == active
+			}
+		}
+
+		if isStructuredOutput(output) {
+			return printOutput(profiles)
+		}
+
+		fmt.Printf("配置文件: %s\n\n", path)
+		for _, p := range profiles {
+			mark := " "
+			if p.Current {
+				mark = "*"
+			}
+			appID := p.AppID
+			if appID == "" {
+				appID = "-"
+			}
+			baseURL := p.BaseURL
+			if baseURL == "" {
+				baseURL = "-"
+			}
+			fmt.Printf("%s %-16s app_id: %-24s base_url: %s\n", mark, p.Name, appID, baseURL)
+		}
+		return nil
+	},
+}
+
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "添加或更新配置档案",
+	Long: `在配置文件中添加档案；档案已存在时只更新指定的字段。
+
+参数:
+  name            档案名（小写字母、数字、下划线和连字符，default 为保留名称）
+  --app-id        应用 ID
+  --app-secret    应用密钥
+  --base-url      API 地址，如 https://open.feishu.cn
+  --lark          使用 Lark 国际版地址（https://open.larksuite.com）
+  --use           添加后设为默认档案
+
+配置文件中的其他内容和注释会被保留。
+
+示例:
+  # 添加 Lark 国际版应用
+  feishu-cli config profile add lark --app-id cli_xxx --app-secret xxx --lark
+
+  # 添加另一个飞书租户的应用并切换过去
+  feishu-cli config profile add team-b --app-id cli_yyy --app-secret yyy --use`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		appID, _ := cmd.Flags().GetString("app-id")
+		appSecret, _ := cmd.Flags().GetString("app-secret")
+		baseURL, _ := cmd.Flags().GetString("base-url")
+		lark, _ := cmd.Flags().GetBool("lark")
+		use, _ := cmd.Flags().GetBool("use")
+
+		if lark {
+			if baseURL != "" && baseURL != config.LarkBaseURL {
+				return fmt.Errorf("--lark 与 --base-url 不能同时指定")
+			}
+			baseURL = config.LarkBaseURL
+		}
+
+		path, err := config.FilePath(cfgFile)
+		if err != nil {
+			return err
+		}
+		created, err := config.SaveProfile(path, &config.Profile{
+			Name:      name,
+			AppID:     appID,
+			AppSecret: appSecret,
+			BaseURL:   baseURL,
+		})
+		if err != nil {
+			return err
+		}
+		if created {
+			fmt.Printf("已添加配置档案: %s\n", name)
+		} else {
+			fmt.Printf("已更新配置档案: %s\n", name)
+		}
+
+		if use {
+			if err := config.UseProfile(path, name); err != nil {
+				return err
+			}
+			fmt.Printf("已切换默认档案: %s\n", name)
+		}
+		return nil
+	},
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "设置默认配置档案",
+	Long: `设置配置文件中的 current_profile，之后的命令默认使用该档案。
+
+使用 default 切回顶层配置。--profile 参数和 FEISHU_PROFILE 环境变量仍优先于此设置。
+
+示例:
+  feishu-cli config profile use lark
+  feishu-cli config profile use default`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.FilePath(cfgFile)
+		if err != nil {
+			return err
+		}
+		if err := config.UseProfile(path, args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("已切换默认档案: %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileAddCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+
+	addOutputFlag(configProfileListCmd)
+
+	configProfileAddCmd.Flags().String("app-id", "", "应用 ID")
+	configProfileAddCmd.Flags().String("app-secret", "", "应用密钥")
+	configProfileAddCmd.Flags().String("base-url", "", "API 地址")
+	configProfileAddCmd.Flags().Bool("lark", false, "使用 Lark 国际版地址（https://open.larksuite.com）")
+	configProfileAddCmd.Flags().Bool("use", false, "添加后设为默认档案")
+}
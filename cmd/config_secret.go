@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/secret"
+	"github.com/riba2534/feishu-cli/internal/token"
+	"github.com/spf13/cobra"
+)
+
+var configSecretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "密钥安全存储",
+	Long: `管理应用密钥（app_secret）和用户 Token 的安全存储。
+
+存储后端（配置项 secret_store 或环境变量 FEISHU_SECRET_STORE）:
+  auto        默认。有系统密钥环时使用密钥环；否则设置了口令时使用加密文件；都没有时使用明文文件
+  keyring     系统密钥环（Linux 通过 secret-tool 访问 Secret Service，macOS 使用钥匙串）
+  file        口令加密文件 ~/.feishu-cli/secrets.enc（AES-256-GCM），适用于无桌面环境的 Linux 主机
+              口令来自环境变量 FEISHU_SECRET_PASSPHRASE 或 FEISHU_SECRET_PASSPHRASE_FILE 指向的文件
+  plaintext   明文文件（旧版本行为）
+
+app_secret 的间接配置:
+  app_secret: "keyring:"           从安全存储读取当前档案的 app_secret
+  app_secret: "keyring:<key>"      从安全存储读取指定密钥
+  app_secret_cmd: "pass show feishu"  未设置 app_secret 时执行命令，取输出的第一行
+
+子命令:
+  status            查看当前使用的存储后端
+  set-app-secret    将 app_secret 写入安全存储，并在配置文件中改为 keyring: 引用
+  migrate           将明文文件中的用户 Token 移入安全存储
+
+示例:
+  # 无桌面环境的构建机使用加密文件
+  export FEISHU_SECRET_STORE=file
+  export FEISHU_SECRET_PASSPHRASE_FILE=/run/secrets/feishu-passphrase
+  feishu-cli config secret set-app-secret < app_secret.txt
+  feishu-cli config secret migrate`,
+}
+
+var configSecretStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "查看密钥存储状态",
+	Long: `显示当前配置的存储后端、实际使用的存储位置，以及 app_secret 和用户 Token 的来源。
+
+示例:
+  feishu-cli config secret status
+  feishu-cli config secret status -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		cfg := config.Get()
+
+		result := map[string]any{
+			"secret_store":      cfg.SecretStore,
+			"keyring_available": secret.KeyringAvailable(),
+			"token_location":    token.DisplayPath(),
+		}
+		store, err := secret.Open(cfg.SecretStore)
+		switch {
+		case err == nil:
+			result["app_secret_location"] = store.Describe(secret.Key(cfg.Profile, "app_secret"))
+		case errors.Is(err, secret.ErrNoStore):
+			result["warning"] = "未启用安全存储，用户 Token 以明文保存"
+		default:
+			result["error"] = err.Error()
+		}
+
+		if isStructuredOutput(output) {
+			return printOutput(result)
+		}
+
+		fmt.Printf("存储后端:     %s\n", cfg.SecretStore)
+		fmt.Printf("系统密钥环:   %s\n", map[bool]string{true: "可用", false: "不可用"}[secret.KeyringAvailable()])
+		fmt.Printf("用户 Token:   %s\n", result["token_location"])
+		if loc, ok := result["app_secret_location"]; ok {
+			fmt.Printf("app_secret:   %s\n", loc)
+		}
+		if warning, ok := result["warning"]; ok {
+			fmt.Printf("\n警告: %s\n", warning)
+			fmt.Printf("可设置 FEISHU_SECRET_STORE=file 和 %s 启用加密文件存储\n", secret.PassphraseEnv)
+		}
+		if e, ok := result["error"]; ok {
+			fmt.Printf("\n错误: %s\n", e)
+		}
+		return nil
+	},
+}
+
+var configSecretSetAppSecretCmd = &cobra.Command{
+	Use:   "set-app-secret",
+	Short: "将 app_secret 写入安全存储",
+	Long: `从标准输入读取 app_secret，写入安全存储，并将配置文件中当前档案的 app_secret
+改为 "keyring:" 引用。
+
+在终端中运行时会提示输入且不回显；也可以通过管道传入。
+
+示例:
+  feishu-cli config secret set-app-secret
+  feishu-cli --profile lark config secret set-app-secret < secret.txt`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		store, err := secret.Open(cfg.SecretStore)
+		if errors.Is(err, secret.ErrNoStore) {
+			return fmt.Errorf("没有可用的安全存储，请安装系统密钥环或设置 FEISHU_SECRET_STORE=file 和 %s", secret.PassphraseEnv)
+		}
+		if err != nil {
+			return err
+		}
+
+		value, err := readSecret("请输入 App Secret: ")
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return fmt.Errorf("app_secret 不能为空")
+		}
+
+		key := secret.Key(cfg.Profile, "app_secret")
+		if err := store.Set(key, value); err != nil {
+			return err
+		}
+		fmt.Printf("已保存到: %s\n", store.Describe(key))
+
+		path, err := config.FilePath(cfgFile)
+		if err != nil {
+			return err
+		}
+		if err := config.SetValue(path, cfg.Profile, "app_secret", config.SecretRefPrefix); err != nil {
+			return err
+		}
+		fmt.Printf("已更新配置文件: %s（app_secret: %q）\n", path, config.SecretRefPrefix)
+		return nil
+	},
+}
+
+var configSecretMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "将明文用户 Token 移入安全存储",
+	Long: `将当前档案的明文 token 文件移入安全存储，成功后删除明文文件。
+
+之后 auth login / auth refresh 也会直接写入安全存储。
+
+示例:
+  feishu-cli config secret migrate`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrated, err := token.MigrateToken()
+		if errors.Is(err, secret.ErrNoStore) {
+			return fmt.Errorf("没有可用的安全存储，请安装系统密钥环或设置 FEISHU_SECRET_STORE=file 和 %s", secret.PassphraseEnv)
+		}
+		if err != nil {
+			return err
+		}
+		if !migrated {
+			fmt.Println("没有需要迁移的明文 token 文件")
+			return nil
+		}
+		fmt.Printf("已迁移用户 Token 到: %s\n", token.DisplayPath())
+		return nil
+	},
+}
+
+// readSecret 从标准输入读取一行密钥，标准输入为终端时关闭回显
+func readSecret(prompt string) (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, prompt)
+		if setEcho(false) == nil {
+			defer func() {
+				_ = setEcho(true)
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("读取输入失败: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// setEcho 通过 stty 开关终端回显，不支持的平台返回错误
+func setEcho(on bool) error {
+	arg := "-echo"
+	if on {
+		arg = "echo"
+	}
+	c := exec.Command("stty", arg)
+	c.Stdin = os.Stdin
+	return c.Run()
+}
+
+func init() {
+	configCmd.AddCommand(configSecretCmd)
+	configSecretCmd.AddCommand(configSecretStatusCmd)
+	configSecretCmd.AddCommand(configSecretSetAppSecretCmd)
+	configSecretCmd.AddCommand(configSecretMigrateCmd)
+
+	addOutputFlag(configSecretStatusCmd)
+}
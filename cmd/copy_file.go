@@ -39,19 +39,20 @@ var copyFileCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		targetFolder, _ := cmd.Flags().GetString("target")
 		fileType, _ := cmd.Flags().GetString("type")
 		name, _ := cmd.Flags().GetString("name")
 		output, _ := cmd.Flags().GetString("output")
 
-		newToken, url, err := client.CopyFile(fileToken, targetFolder, name, fileType)
+		newToken, url, err := client.CopyFile(ctx, fileToken, targetFolder, name, fileType)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"token": newToken,
 				"url":   url,
 			}); err != nil {
@@ -75,6 +76,6 @@ func init() {
 	copyFileCmd.Flags().String("target", "", "目标文件夹 Token（必填）")
 	copyFileCmd.Flags().String("type", "", "文件类型（必填）")
 	copyFileCmd.Flags().String("name", "", "新文件名称")
-	copyFileCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(copyFileCmd)
 	mustMarkFlagRequired(copyFileCmd, "target", "type")
 }
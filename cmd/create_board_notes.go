@@ -47,6 +47,7 @@ var createBoardNotesCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		whiteboardID := args[0]
 		source := args[1]
 		sourceType, _ := cmd.Flags().GetString("source-type")
@@ -72,18 +73,18 @@ var createBoardNotesCmd = &cobra.Command{
 			UserIDType:  userIDType,
 		}
 
-		nodeIDs, err := client.CreateBoardNodes(whiteboardID, nodesJSON, opts)
+		nodeIDs, err := client.CreateBoardNodes(ctx, whiteboardID, nodesJSON, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]any{
 				"whiteboard_id": whiteboardID,
 				"node_ids":      nodeIDs,
 				"count":         len(nodeIDs),
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -104,5 +105,5 @@ func init() {
 	createBoardNotesCmd.Flags().String("source-type", "file", "源类型 (file/content)")
 	createBoardNotesCmd.Flags().String("client-token", "", "操作唯一标识（幂等）")
 	createBoardNotesCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型 (open_id/union_id/user_id)")
-	createBoardNotesCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(createBoardNotesCmd)
 }
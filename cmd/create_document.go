@@ -2,22 +2,48 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/riba2534/feishu-cli/internal/client"
 	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
 	"github.com/spf13/cobra"
 )
 
 var createDocumentCmd = &cobra.Command{
 	Use:   "create",
 	Short: "创建新文档",
-	Long: `创建新的飞书云文档。
+	Long: `创建新的飞书云文档，或从 Markdown 模板生成文档。
 
 参数:
-  --title, -t     文档标题（必填）
+  --title, -t     文档标题（不使用模板时必填）
   --folder, -f    目标文件夹 token（可选）
+  --wiki-parent   目标知识库父节点 token（仅模板模式）
   --output, -o    输出格式，可选 json
 
+模板参数:
+  --template      Go template 格式的 Markdown 模板文件
+  --var           模板变量 key=value（可重复）
+  --vars          YAML/JSON 变量文件（可重复，后者覆盖前者）
+  --vars-cmd      key=command，执行命令并将输出（JSON 或文本）作为变量（可重复）
+
+模板说明:
+  模板先整体渲染，再解析 front matter，正文通过 doc import 的流水线写入文档。
+  front matter 支持 title（标题，可使用模板变量）、folder、wiki_parent、owner 和 permissions，
+  命令行参数优先于 front matter。
+
+  ---
+  title: "{{ .team }} 周报 {{ date \"2006-01-02\" }}"
+  wiki_parent: wikcnXXX
+  permissions:
+    - member_type: email
+      member_id: lead@example.com
+      perm: edit
+  ---
+
+  可用函数: now, date, addDays, join, split, upper, lower, trim, default, toJSON,
+            tasks "all|todo|done", events CALENDAR_ID START END
+
 示例:
   # 创建空白文档
   feishu-cli doc create --title "我的文档"
@@ -25,6 +51,13 @@ var createDocumentCmd = &cobra.Command{
   # 在指定文件夹创建
   feishu-cli doc create --title "项目文档" --folder FOLDER_TOKEN
 
+  # 从模板生成周报
+  feishu-cli doc create --template weekly.md.tmpl --var team=infra --vars vars.yaml
+
+  # 使用其他命令的输出作为模板数据
+  feishu-cli doc create --template standup.md.tmpl \
+    --vars-cmd 'tasks=feishu-cli task list --output json'
+
   # JSON 格式输出
   feishu-cli doc create --title "测试" --output json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,10 +65,20 @@ var createDocumentCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		title, _ := cmd.Flags().GetString("title")
 		folder, _ := cmd.Flags().GetString("folder")
+		templatePath, _ := cmd.Flags().GetString("template")
+		output, _ := cmd.Flags().GetString("output")
 
-		doc, err := client.CreateDocument(title, folder)
+		if templatePath != "" {
+			return createDocumentFromTemplate(cmd, templatePath, title, folder, output)
+		}
+		if title == "" {
+			return fmt.Errorf("请通过 --title 指定文档标题")
+		}
+
+		doc, err := client.CreateDocument(ctx, title, folder)
 		if err != nil {
 			return err
 		}
@@ -53,9 +96,8 @@ var createDocumentCmd = &cobra.Command{
 			revisionID = *doc.RevisionId
 		}
 
-		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"document_id": documentID,
 				"title":       docTitle,
 				"revision_id": revisionID,
@@ -74,10 +116,72 @@ var createDocumentCmd = &cobra.Command{
 	},
 }
 
+// createDocumentFromTemplate 渲染模板并通过导入流水线创建文档
+func createDocumentFromTemplate(cmd *cobra.Command, templatePath, title, folder, output string) error {
+	ctx := cmd.Context()
+	varFiles, _ := cmd.Flags().GetStringArray("vars")
+	varCmds, _ := cmd.Flags().GetStringArray("vars-cmd")
+	vars, _ := cmd.Flags().GetStringArray("var")
+	wikiParent, _ := cmd.Flags().GetString("wiki-parent")
+
+	data, err := loadTemplateVars(varFiles, varCmds, vars)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderDocTemplate(templatePath, data)
+	if err != nil {
+		return err
+	}
+
+	fm, body, err := converter.ParseFrontMatter(rendered)
+	if err != nil {
+		return err
+	}
+	if fm == nil {
+		fm = &converter.FrontMatter{}
+	}
+
+	// 命令行参数优先于 front matter
+	if title == "" {
+		title = fm.Title
+	}
+	if title == "" {
+		title = titleFromFileName(templatePath)
+	}
+	if folder == "" {
+		folder = fm.Folder
+	}
+	if wikiParent == "" {
+		wikiParent = fm.WikiParent
+	}
+
+	documentID, err := createTargetDocument(ctx, title, folder, wikiParent)
+	if err != nil {
+		return err
+	}
+
+	stats, err := runImportPipeline(ctx, documentID, body, filepath.Dir(templatePath), defaultImportPipelineOptions())
+	if err != nil {
+		if stats != nil && stats.interrupted {
+			_ = printImportResult(documentID, stats, output)
+		}
+		return err
+	}
+
+	applyFrontMatterPublishing(ctx, documentID, fm, true)
+
+	return printImportResult(documentID, stats, output)
+}
+
 func init() {
 	docCmd.AddCommand(createDocumentCmd)
-	createDocumentCmd.Flags().StringP("title", "t", "", "文档标题（必填）")
+	createDocumentCmd.Flags().StringP("title", "t", "", "文档标题（不使用模板时必填）")
 	createDocumentCmd.Flags().StringP("folder", "f", "", "目标文件夹 token")
-	createDocumentCmd.Flags().StringP("output", "o", "", "输出格式（json）")
-	mustMarkFlagRequired(createDocumentCmd, "title")
+	addOutputFlag(createDocumentCmd)
+	createDocumentCmd.Flags().String("template", "", "Markdown 模板文件（Go template 语法）")
+	createDocumentCmd.Flags().StringArray("var", nil, "模板变量 key=value（可重复）")
+	createDocumentCmd.Flags().StringArray("vars", nil, "YAML/JSON 变量文件（可重复）")
+	createDocumentCmd.Flags().StringArray("vars-cmd", nil, "key=command，将命令输出作为模板变量（可重复）")
+	createDocumentCmd.Flags().String("wiki-parent", "", "在该知识库节点下创建文档（模板模式）")
 }
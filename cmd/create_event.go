@@ -56,6 +56,7 @@ var createEventCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		calendarID, _ := cmd.Flags().GetString("calendar-id")
 		summary, _ := cmd.Flags().GetString("summary")
 		startTime, _ := cmd.Flags().GetString("start")
@@ -73,13 +74,13 @@ var createEventCmd = &cobra.Command{
 			Location:    location,
 		}
 
-		event, err := client.CreateEvent(params)
+		event, err := client.CreateEvent(ctx, params)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(event); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(event); err != nil {
 				return err
 			}
 		} else {
@@ -111,7 +112,7 @@ func init() {
 	createEventCmd.Flags().String("end", "", "结束时间，RFC3339 格式（必填）")
 	createEventCmd.Flags().StringP("description", "d", "", "日程描述")
 	createEventCmd.Flags().StringP("location", "l", "", "地点")
-	createEventCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(createEventCmd)
 
 	mustMarkFlagRequired(createEventCmd, "calendar-id", "summary", "start", "end")
 }
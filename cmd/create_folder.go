@@ -32,17 +32,18 @@ var createFolderCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		name := args[0]
 		parentToken, _ := cmd.Flags().GetString("parent")
 		output, _ := cmd.Flags().GetString("output")
 
-		token, url, err := client.CreateFolder(name, parentToken)
+		token, url, err := client.CreateFolder(ctx, name, parentToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"token": token,
 				"url":   url,
 			}); err != nil {
@@ -64,5 +65,5 @@ var createFolderCmd = &cobra.Command{
 func init() {
 	fileCmd.AddCommand(createFolderCmd)
 	createFolderCmd.Flags().String("parent", "", "父文件夹 Token")
-	createFolderCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(createFolderCmd)
 }
@@ -39,18 +39,19 @@ var createShortcutCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		targetFolder, _ := cmd.Flags().GetString("target")
 		fileType, _ := cmd.Flags().GetString("type")
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.CreateShortcut(targetFolder, fileToken, fileType)
+		info, err := client.CreateShortcut(ctx, targetFolder, fileToken, fileType)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -71,6 +72,6 @@ func init() {
 	fileCmd.AddCommand(createShortcutCmd)
 	createShortcutCmd.Flags().String("target", "", "目标文件夹 Token（必填）")
 	createShortcutCmd.Flags().String("type", "", "文件类型（必填）")
-	createShortcutCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(createShortcutCmd)
 	mustMarkFlagRequired(createShortcutCmd, "target", "type")
 }
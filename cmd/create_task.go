@@ -42,6 +42,7 @@ var createTaskCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		summary, _ := cmd.Flags().GetString("summary")
 		description, _ := cmd.Flags().GetString("description")
 		dueStr, _ := cmd.Flags().GetString("due")
@@ -64,14 +65,14 @@ var createTaskCmd = &cobra.Command{
 			opts.DueTimestamp = dueTime.UnixMilli()
 		}
 
-		task, err := client.CreateTask(opts)
+		task, err := client.CreateTask(ctx, opts)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(task); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(task); err != nil {
 				return err
 			}
 		} else {
@@ -120,6 +121,6 @@ func init() {
 	createTaskCmd.Flags().String("due", "", "截止时间（格式: 2006-01-02 15:04:05）")
 	createTaskCmd.Flags().String("origin-href", "", "任务来源链接")
 	createTaskCmd.Flags().String("origin-platform", "", "任务来源平台名称")
-	createTaskCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(createTaskCmd)
 	mustMarkFlagRequired(createTaskCmd, "summary")
 }
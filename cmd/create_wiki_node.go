@@ -41,19 +41,20 @@ var createWikiNodeCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		spaceID, _ := cmd.Flags().GetString("space-id")
 		title, _ := cmd.Flags().GetString("title")
 		parentNode, _ := cmd.Flags().GetString("parent-node")
 		nodeType, _ := cmd.Flags().GetString("node-type")
 		output, _ := cmd.Flags().GetString("output")
 
-		result, err := client.CreateWikiNode(spaceID, title, parentNode, nodeType)
+		result, err := client.CreateWikiNode(ctx, spaceID, title, parentNode, nodeType)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -74,6 +75,6 @@ func init() {
 	createWikiNodeCmd.Flags().String("title", "", "节点标题（必填）")
 	createWikiNodeCmd.Flags().String("parent-node", "", "父节点 Token（可选）")
 	createWikiNodeCmd.Flags().String("node-type", "docx", "节点类型：docx/doc/sheet（默认 docx）")
-	createWikiNodeCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(createWikiNodeCmd)
 	mustMarkFlagRequired(createWikiNodeCmd, "space-id", "title")
 }
@@ -16,6 +16,11 @@ var deleteBlocksCmd = &cobra.Command{
 删除基于索引范围。可以指定起始和结束索引，
 或使用 --all 删除所有子块。
 
+并发控制:
+  --expect-revision 指定读取文档时的版本号，文档已被修改时拒绝删除并以退出码 3 退出；
+  使用 --all 时会自动记录读取子块时的版本，删除前文档被修改同样视为冲突。
+  同时指定 --rebase 时，若父块的子块在该版本之后未变化，则基于最新版本继续删除。
+
 示例:
   feishu-cli doc delete DOC_ID PARENT_BLOCK_ID --start 0 --end 3
   feishu-cli doc delete DOC_ID PARENT_BLOCK_ID --all`,
@@ -25,16 +30,22 @@ var deleteBlocksCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		blockID := args[1]
 		startIndex, _ := cmd.Flags().GetInt("start")
 		endIndex, _ := cmd.Flags().GetInt("end")
 		deleteAll, _ := cmd.Flags().GetBool("all")
 		force, _ := cmd.Flags().GetBool("force")
+		guard := newRevisionGuard(cmd, documentID)
 
 		if deleteAll {
+			// 记录读取子块时的版本，避免删除期间新增的内容被误删
+			if err := guard.capture(ctx); err != nil {
+				return err
+			}
 			// Get block children count first
-			children, err := client.GetBlockChildren(documentID, blockID)
+			children, err := client.GetBlockChildren(ctx, documentID, blockID)
 			if err != nil {
 				return fmt.Errorf("获取子块失败: %w", err)
 			}
@@ -66,11 +77,29 @@ var deleteBlocksCmd = &cobra.Command{
 			}
 		}
 
-		if err := client.DeleteBlocks(documentID, blockID, startIndex, endIndex); err != nil {
+		revision, err := guard.resolve(ctx, blockID)
+		if err != nil {
 			return err
 		}
 
+		newRevision, err := client.DeleteBlocksAtRevision(ctx, documentID, blockID, startIndex, endIndex, revision)
+		if err != nil {
+			return err
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"document_id": documentID,
+				"block_id":    blockID,
+				"start":       startIndex,
+				"end":         endIndex,
+				"revision":    newRevision,
+			})
+		}
+
 		fmt.Printf("成功删除索引 %d 到 %d 的块！\n", startIndex, endIndex)
+		fmt.Printf("  文档版本: %d\n", newRevision)
 		return nil
 	},
 }
@@ -81,4 +110,6 @@ func init() {
 	deleteBlocksCmd.Flags().Int("end", 0, "结束索引 (不包含)")
 	deleteBlocksCmd.Flags().Bool("all", false, "删除所有子块")
 	deleteBlocksCmd.Flags().BoolP("force", "f", false, "跳过确认直接删除")
+	addRevisionFlags(deleteBlocksCmd)
+	addOutputFlag(deleteBlocksCmd)
 }
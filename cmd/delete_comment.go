@@ -41,6 +41,15 @@ var deleteCommentCmd = &cobra.Command{
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"success":    true,
+				"file_token": fileToken,
+				"comment_id": commentID,
+			})
+		}
+
 		fmt.Printf("评论删除成功！\n")
 		fmt.Printf("  文档 Token: %s\n", fileToken)
 		fmt.Printf("  评论 ID:    %s\n", commentID)
@@ -51,5 +60,6 @@ var deleteCommentCmd = &cobra.Command{
 func init() {
 	commentCmd.AddCommand(deleteCommentCmd)
 	deleteCommentCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
+	addOutputFlag(deleteCommentCmd)
 	mustMarkFlagRequired(deleteCommentCmd, "type")
 }
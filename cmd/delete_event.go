@@ -30,13 +30,23 @@ var deleteEventCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		calendarID := args[0]
 		eventID := args[1]
 
-		if err := client.DeleteEvent(calendarID, eventID); err != nil {
+		if err := client.DeleteEvent(ctx, calendarID, eventID); err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"success":     true,
+				"calendar_id": calendarID,
+				"event_id":    eventID,
+			})
+		}
+
 		fmt.Printf("日程删除成功！（日程 ID: %s）\n", eventID)
 		return nil
 	},
@@ -44,4 +54,5 @@ var deleteEventCmd = &cobra.Command{
 
 func init() {
 	calendarCmd.AddCommand(deleteEventCmd)
+	addOutputFlag(deleteEventCmd)
 }
@@ -40,6 +40,7 @@ var deleteFileCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		fileType, _ := cmd.Flags().GetString("type")
 		force, _ := cmd.Flags().GetBool("force")
@@ -52,11 +53,20 @@ var deleteFileCmd = &cobra.Command{
 			}
 		}
 
-		taskID, err := client.DeleteFile(fileToken, fileType)
+		taskID, err := client.DeleteFile(ctx, fileToken, fileType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"file_token": fileToken,
+				"type":       fileType,
+				"task_id":    taskID,
+			})
+		}
+
 		fmt.Printf("删除操作已提交！\n")
 		fmt.Printf("  文件 Token: %s\n", fileToken)
 		fmt.Printf("  文件类型:   %s\n", fileType)
@@ -72,5 +82,6 @@ func init() {
 	fileCmd.AddCommand(deleteFileCmd)
 	deleteFileCmd.Flags().String("type", "", "文件类型（必填）")
 	deleteFileCmd.Flags().BoolP("force", "f", false, "跳过确认直接删除")
+	addOutputFlag(deleteFileCmd)
 	mustMarkFlagRequired(deleteFileCmd, "type")
 }
@@ -33,16 +33,17 @@ var deleteMessageCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		messageID := args[0]
 
-		err := client.DeleteMessage(messageID)
+		err := client.DeleteMessage(ctx, messageID)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"success":    true,
 				"message_id": messageID,
 			}); err != nil {
@@ -59,5 +60,5 @@ var deleteMessageCmd = &cobra.Command{
 
 func init() {
 	msgCmd.AddCommand(deleteMessageCmd)
-	deleteMessageCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(deleteMessageCmd)
 }
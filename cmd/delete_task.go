@@ -29,16 +29,17 @@ var deleteTaskCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		taskGuid := args[0]
 
-		err := client.DeleteTask(taskGuid)
+		err := client.DeleteTask(ctx, taskGuid)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"success": true,
 				"task_id": taskGuid,
 				"message": "任务删除成功",
@@ -56,5 +57,5 @@ var deleteTaskCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(deleteTaskCmd)
-	deleteTaskCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(deleteTaskCmd)
 }
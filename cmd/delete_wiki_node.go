@@ -32,6 +32,7 @@ var deleteWikiNodeCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		nodeToken, err := extractWikiToken(args[0])
 		if err != nil {
 			return err
@@ -39,7 +40,7 @@ var deleteWikiNodeCmd = &cobra.Command{
 		force, _ := cmd.Flags().GetBool("force")
 
 		// 先获取节点信息以获取 obj_token 和 obj_type
-		node, err := client.GetWikiNode(nodeToken)
+		node, err := client.GetWikiNode(ctx, nodeToken)
 		if err != nil {
 			return fmt.Errorf("获取节点信息失败: %w", err)
 		}
@@ -53,11 +54,22 @@ var deleteWikiNodeCmd = &cobra.Command{
 		}
 
 		// 通过 Drive API 删除对应的文档
-		taskID, err := client.DeleteFile(node.ObjToken, node.ObjType)
+		taskID, err := client.DeleteFile(ctx, node.ObjToken, node.ObjType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"node_token": nodeToken,
+				"obj_token":  node.ObjToken,
+				"obj_type":   node.ObjType,
+				"title":      node.Title,
+				"task_id":    taskID,
+			})
+		}
+
 		fmt.Printf("知识库节点删除操作已提交！\n")
 		fmt.Printf("  节点 Token: %s\n", nodeToken)
 		fmt.Printf("  文档 Token: %s\n", node.ObjToken)
@@ -74,4 +86,5 @@ var deleteWikiNodeCmd = &cobra.Command{
 func init() {
 	wikiCmd.AddCommand(deleteWikiNodeCmd)
 	deleteWikiNodeCmd.Flags().BoolP("force", "f", false, "跳过确认直接删除")
+	addOutputFlag(deleteWikiNodeCmd)
 }
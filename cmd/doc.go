@@ -18,6 +18,12 @@ var docCmd = &cobra.Command{
   delete    删除块
   export    导出文档为 Markdown
   import    从 Markdown 导入文档
+  diff      比较文档与本地 Markdown 或两个文档的差异
+  outline   查看文档标题大纲，插入目录
+  stats     统计文档内容和结构问题
+  push      按 .feishu.yaml 清单推送本地 Markdown
+  pull      按 .feishu.yaml 清单拉取远端文档
+  status    查看清单中文件的同步状态
 
 示例:
   # 创建文档
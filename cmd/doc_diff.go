@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/riba2534/feishu-cli/internal/textdiff"
+	"github.com/spf13/cobra"
+)
+
+var docDiffCmd = &cobra.Command{
+	Use:   "diff <document_id|url|file.md> <document_id|url|file.md>",
+	Short: "比较文档与本地 Markdown 或两个文档的差异",
+	Long: `比较远端文档与本地 Markdown 文件，或比较两个远端文档。
+
+两侧内容都会先转换为文档块，再经过与 doc export 相同的 Markdown 转换进行规范化，
+因此只会显示实际内容的差异，不受本地 Markdown 书写风格影响。
+参数为已存在的本地文件时按 Markdown 文件处理，否则按文档 ID 或 URL 处理。
+
+输出模式:
+  默认        行级统一格式 (unified diff)
+  --word      按顶层块列出变化，块内显示词级差异（[-删除-]{+新增+}）
+  -o json     列出变化的块及其块 ID，便于脚本处理
+
+注意:
+  本地 Markdown 中的 Mermaid/PlantUML 图表导入后为画板，与远端比较时总会显示为差异。
+
+示例:
+  # 查看远端文档相对本地文件的改动
+  feishu-cli doc diff doc.md ABC123def456
+
+  # 比较两个文档
+  feishu-cli doc diff ABC123def456 XYZ789ghi012
+
+  # 词级差异
+  feishu-cli doc diff doc.md ABC123def456 --word
+
+  # 有差异时返回非零退出码
+  feishu-cli doc diff doc.md ABC123def456 --exit-code`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		word, _ := cmd.Flags().GetBool("word")
+		context, _ := cmd.Flags().GetInt("context")
+		exitCode, _ := cmd.Flags().GetBool("exit-code")
+		output, _ := cmd.Flags().GetString("output")
+
+		from, err := loadDiffSide(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		to, err := loadDiffSide(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		changes := diffBlocks(from.sections, to.sections)
+
+		switch {
+		case isStructuredOutput(output):
+			if err := printOutput(map[string]any{
+				"from":      from.name,
+				"to":        to.name,
+				"identical": len(changes) == 0,
+				"changes":   changes,
+			}); err != nil {
+				return err
+			}
+		case word:
+			printWordDiff(from, to, changes)
+		default:
+			fmt.Print(textdiff.Unified(from.name, to.name, from.markdown, to.markdown, context))
+		}
+
+		if exitCode && len(changes) > 0 {
+			return fmt.Errorf("文档存在差异: %d 个块发生变化", len(changes))
+		}
+		return nil
+	},
+}
+
+// diffSide 表示比较的一侧（远端文档或本地 Markdown 文件）
+type diffSide struct {
+	name     string
+	sections []converter.BlockMarkdown
+	markdown string
+}
+
+// blockChange 表示一个顶层块的变化
+type blockChange struct {
+	Type         string `json:"type"` // added、removed 或 modified
+	FromBlockID  string `json:"from_block_id,omitempty"`
+	ToBlockID    string `json:"to_block_id,omitempty"`
+	BlockType    int    `json:"block_type"`
+	FromMarkdown string `json:"from_markdown,omitempty"`
+	ToMarkdown   string `json:"to_markdown,omitempty"`
+}
+
+// loadDiffSide 加载并规范化比较的一侧
+// 本地文件的块 ID 是临时生成的，不对外输出
+func loadDiffSide(ctx context.Context, arg string) (*diffSide, error) {
+	if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+		content, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		_, body, err := converter.ParseFrontMatter(string(content))
+		if err != nil {
+			return nil, err
+		}
+		result, err := converter.NewMarkdownToBlock([]byte(body), converter.ConvertOptions{}, filepath.Dir(arg)).ConvertWithTableData()
+		if err != nil {
+			return nil, fmt.Errorf("转换 Markdown 失败: %w", err)
+		}
+
+		side, err := normalizeDiffSide(arg, converter.BuildDocumentBlocks(result), converter.ConvertOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range side.sections {
+			side.sections[i].BlockID = ""
+		}
+		return side, nil
+	}
+
+	documentID, err := extractDocToken(arg)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := client.GetAllBlocks(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("获取块失败: %w", err)
+	}
+	return normalizeDiffSide(documentID, blocks, converter.ConvertOptions{DocumentID: documentID})
+}
+
+// normalizeDiffSide 将块列表转换为完整 Markdown 和按顶层块拆分的 Markdown
+func normalizeDiffSide(name string, blocks []*larkdocx.Block, options converter.ConvertOptions) (*diffSide, error) {
+	// 标题编号等转换状态保存在转换器中，两种输出分别使用独立的转换器
+	markdown, err := converter.NewBlockToMarkdown(blocks, options).Convert()
+	if err != nil {
+		return nil, fmt.Errorf("转换为 Markdown 失败: %w", err)
+	}
+	sections, err := converter.NewBlockToMarkdown(blocks, options).ConvertTopLevel()
+	if err != nil {
+		return nil, fmt.Errorf("转换为 Markdown 失败: %w", err)
+	}
+	return &diffSide{name: name, sections: sections, markdown: markdown}, nil
+}
+
+// diffBlocks 按顶层块比较两侧内容，相邻的删除和新增配对为修改
+func diffBlocks(a, b []converter.BlockMarkdown) []blockChange {
+	aKeys := make([]string, len(a))
+	for i, s := range a {
+		aKeys[i] = strings.TrimRight(s.Markdown, "\n")
+	}
+	bKeys := make([]string, len(b))
+	for i, s := range b {
+		bKeys[i] = strings.TrimRight(s.Markdown, "\n")
+	}
+
+	edits := textdiff.Diff(aKeys, bKeys)
+	changes := []blockChange{}
+
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == textdiff.Equal {
+			i++
+			continue
+		}
+
+		var removed, added []int
+		for ; i < len(edits) && edits[i].Kind != textdiff.Equal; i++ {
+			if edits[i].Kind == textdiff.Delete {
+				removed = append(removed, edits[i].A)
+			} else {
+				added = append(added, edits[i].B)
+			}
+		}
+
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				from, to := a[removed[k]], b[added[k]]
+				changes = append(changes, blockChange{
+					Type:         "modified",
+					FromBlockID:  from.BlockID,
+					ToBlockID:    to.BlockID,
+					BlockType:    int(to.BlockType),
+					FromMarkdown: aKeys[removed[k]],
+					ToMarkdown:   bKeys[added[k]],
+				})
+			case k < len(removed):
+				from := a[removed[k]]
+				changes = append(changes, blockChange{
+					Type:         "removed",
+					FromBlockID:  from.BlockID,
+					BlockType:    int(from.BlockType),
+					FromMarkdown: aKeys[removed[k]],
+				})
+			default:
+				to := b[added[k]]
+				changes = append(changes, blockChange{
+					Type:       "added",
+					ToBlockID:  to.BlockID,
+					BlockType:  int(to.BlockType),
+					ToMarkdown: bKeys[added[k]],
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// printWordDiff 逐块输出词级差异
+func printWordDiff(from, to *diffSide, changes []blockChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Printf("--- %s\n+++ %s\n", from.name, to.name)
+
+	for _, c := range changes {
+		var ids []string
+		if c.FromBlockID != "" {
+			ids = append(ids, c.FromBlockID)
+		}
+		if c.ToBlockID != "" {
+			ids = append(ids, c.ToBlockID)
+		}
+		label := map[string]string{"modified": "修改", "removed": "删除", "added": "新增"}[c.Type]
+		if len(ids) > 0 {
+			fmt.Printf("@@ %s %s @@\n", label, strings.Join(ids, " → "))
+		} else {
+			fmt.Printf("@@ %s @@\n", label)
+		}
+		fmt.Println(textdiff.Words(c.FromMarkdown, c.ToMarkdown))
+	}
+}
+
+func init() {
+	docCmd.AddCommand(docDiffCmd)
+	docDiffCmd.Flags().Bool("word", false, "按块显示词级差异")
+	docDiffCmd.Flags().IntP("context", "U", 3, "统一格式差异的上下文行数")
+	docDiffCmd.Flags().Bool("exit-code", false, "存在差异时返回非零退出码")
+	addOutputFlag(docDiffCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+func TestDiffBlocks(t *testing.T) {
+	a := []converter.BlockMarkdown{
+		{BlockID: "a1", BlockType: converter.BlockTypeHeading1, Markdown: "# 标题\n"},
+		{BlockID: "a2", BlockType: converter.BlockTypeText, Markdown: "旧段落\n"},
+		{BlockID: "a3", BlockType: converter.BlockTypeText, Markdown: "将被删除\n"},
+		{BlockID: "a4", BlockType: converter.BlockTypeText, Markdown: "结尾\n"},
+	}
+	b := []converter.BlockMarkdown{
+		{BlockID: "b1", BlockType: converter.BlockTypeHeading1, Markdown: "# 标题\n"},
+		{BlockID: "b2", BlockType: converter.BlockTypeText, Markdown: "新段落\n"},
+		{BlockID: "b3", BlockType: converter.BlockTypeText, Markdown: "结尾\n"},
+		{BlockID: "b4", BlockType: converter.BlockTypeText, Markdown: "追加\n"},
+	}
+
+	changes := diffBlocks(a, b)
+	want := []struct {
+		typ, from, to string
+	}{
+		{"modified", "a2", "b2"},
+		{"removed", "a3", ""},
+		{"added", "", "b4"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("变化数 = %d, 期望 %d: %+v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Type != w.typ || c.FromBlockID != w.from || c.ToBlockID != w.to {
+			t.Errorf("第 %d 个变化 = {%s %s %s}, 期望 {%s %s %s}", i+1, c.Type, c.FromBlockID, c.ToBlockID, w.typ, w.from, w.to)
+		}
+	}
+
+	if got := diffBlocks(a, a); len(got) != 0 {
+		t.Errorf("相同内容不应有变化: %+v", got)
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/spf13/cobra"
+)
+
+var docOutlineCmd = &cobra.Command{
+	Use:   "outline <document_id|url>",
+	Short: "查看文档标题大纲",
+	Long: `输出文档的标题树（H1-H9），包含标题编号、块 ID、跳转链接和章节字数。
+
+章节字数统计从该标题到下一个同级或更高级标题之间的所有文本（含子章节），
+中日韩文字按字计数，英文按单词计数。
+
+输出格式:
+  默认          缩进的标题树
+  -o json       标题列表（含 block_id、level、seq、title、words、link）
+  -o markdown   带链接的 Markdown 目录
+
+插入目录:
+  --insert-toc 在文档标题下方（正文最前面）插入带链接的目录列表，
+  可配合 --max-level 控制目录深度。
+
+示例:
+  feishu-cli doc outline ABC123def456
+  feishu-cli doc outline ABC123def456 --max-level 2 -o markdown
+  feishu-cli doc outline ABC123def456 --insert-toc --max-level 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		documentID, err := extractDocToken(args[0])
+		if err != nil {
+			return err
+		}
+		maxLevel, _ := cmd.Flags().GetInt("max-level")
+		insertTOC, _ := cmd.Flags().GetBool("insert-toc")
+		output, _ := cmd.Flags().GetString("output")
+
+		guard := newRevisionGuard(cmd, documentID)
+		if insertTOC {
+			if err := guard.capture(ctx); err != nil {
+				return err
+			}
+		}
+
+		blocks, err := client.GetAllBlocks(ctx, documentID)
+		if err != nil {
+			return fmt.Errorf("获取块失败: %w", err)
+		}
+
+		var entries []converter.OutlineEntry
+		for _, e := range converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{}).Outline() {
+			if e.Level <= maxLevel {
+				entries = append(entries, e)
+			}
+		}
+
+		if insertTOC {
+			if len(entries) == 0 {
+				return fmt.Errorf("文档中没有标题，无法生成目录")
+			}
+			revision, err := guard.resolve(ctx)
+			if err != nil {
+				return err
+			}
+			count, err := insertOutlineTOC(ctx, documentID, entries, revision)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("已在文档开头插入目录 (%d 个条目, %d 个块)\n", len(entries), count)
+			return nil
+		}
+
+		switch {
+		case isStructuredOutput(output):
+			type jsonEntry struct {
+				converter.OutlineEntry
+				Link string `json:"link"`
+			}
+			result := make([]jsonEntry, len(entries))
+			for i, e := range entries {
+				result[i] = jsonEntry{OutlineEntry: e, Link: outlineLink(documentID, e.BlockID)}
+			}
+			return printOutput(result)
+		case output == "markdown":
+			fmt.Print(outlineMarkdown(documentID, entries))
+		default:
+			if len(entries) == 0 {
+				fmt.Println("文档中没有标题")
+				return nil
+			}
+			minLevel := outlineMinLevel(entries)
+			for _, e := range entries {
+				indent := strings.Repeat("  ", e.Level-minLevel)
+				fmt.Printf("%s%s  (%d 字)  %s\n", indent, outlineTitle(e), e.Words, e.BlockID)
+			}
+			fmt.Printf("\n共 %d 个标题\n", len(entries))
+		}
+
+		return nil
+	},
+}
+
+// outlineLink 返回跳转到指定块的文档链接
+func outlineLink(documentID, blockID string) string {
+	return fmt.Sprintf("https://feishu.cn/docx/%s#%s", documentID, blockID)
+}
+
+// outlineTitle 返回带编号的标题文本
+func outlineTitle(e converter.OutlineEntry) string {
+	if e.Seq != "" {
+		return e.Seq + ". " + e.Title
+	}
+	return e.Title
+}
+
+// outlineMinLevel 返回大纲中最浅的标题级别，用于计算缩进
+func outlineMinLevel(entries []converter.OutlineEntry) int {
+	minLevel := 9
+	for _, e := range entries {
+		minLevel = min(minLevel, e.Level)
+	}
+	return minLevel
+}
+
+// outlineMarkdown 生成带链接的 Markdown 目录
+func outlineMarkdown(documentID string, entries []converter.OutlineEntry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	minLevel := outlineMinLevel(entries)
+	for _, e := range entries {
+		title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(outlineTitle(e))
+		fmt.Fprintf(&sb, "%s- [%s](%s)\n", strings.Repeat("  ", e.Level-minLevel), title, outlineLink(documentID, e.BlockID))
+	}
+	return sb.String()
+}
+
+// insertOutlineTOC 在文档正文开头插入目录列表，返回创建的块数
+func insertOutlineTOC(ctx context.Context, documentID string, entries []converter.OutlineEntry, revision int) (int, error) {
+	toc := outlineMarkdown(documentID, entries)
+	result, err := converter.NewMarkdownToBlock([]byte(toc), converter.ConvertOptions{}, "").ConvertWithTableData()
+	if err != nil {
+		return 0, fmt.Errorf("生成目录失败: %w", err)
+	}
+
+	topLevel := make([]*larkdocx.Block, len(result.BlockNodes))
+	for i, node := range result.BlockNodes {
+		topLevel[i] = node.Block
+	}
+
+	// 飞书 API 限制每次最多创建 50 个块，分批依次插入到开头位置之后
+	const batchSize = 50
+	var created []*larkdocx.Block
+	for i := 0; i < len(topLevel); i += batchSize {
+		end := min(i+batchSize, len(topLevel))
+		batch, newRevision, err := client.CreateBlockAtRevision(ctx, documentID, documentID, topLevel[i:end], i, revision)
+		if err != nil {
+			return len(created), fmt.Errorf("插入目录失败: %w", err)
+		}
+		created = append(created, batch...)
+		if newRevision > 0 {
+			revision = newRevision
+		}
+	}
+	total := len(created)
+
+	for i, node := range result.BlockNodes {
+		if i >= len(created) || created[i].BlockId == nil || len(node.Children) == 0 {
+			continue
+		}
+		count, err := createNestedChildren(ctx, documentID, *created[i].BlockId, node.Children)
+		total += count
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func init() {
+	docCmd.AddCommand(docOutlineCmd)
+	docOutlineCmd.Flags().Int("max-level", 9, "最大标题级别 (1-9)")
+	docOutlineCmd.Flags().Bool("insert-toc", false, "在文档开头插入带链接的目录")
+	addOutputFlag(docOutlineCmd, "markdown")
+	addRevisionFlags(docOutlineCmd)
+}
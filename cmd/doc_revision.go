@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// exitCodeRevisionConflict 文档版本冲突时的退出码，便于自动化脚本区分冲突和其他错误
+const exitCodeRevisionConflict = 3
+
+// addRevisionFlags 为修改文档的命令添加乐观并发控制参数
+func addRevisionFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("expect-revision", 0, "期望的文档版本号，文档已被修改时拒绝写入 (0 表示不检查)")
+	cmd.Flags().Bool("rebase", false, "版本冲突但目标块未被修改时，基于最新版本自动重试")
+}
+
+// revisionGuard 基于文档版本号的乐观并发控制
+type revisionGuard struct {
+	documentID string
+	expected   int
+	rebase     bool
+}
+
+// newRevisionGuard 从命令参数创建版本校验
+func newRevisionGuard(cmd *cobra.Command, documentID string) *revisionGuard {
+	expected, _ := cmd.Flags().GetInt("expect-revision")
+	rebase, _ := cmd.Flags().GetBool("rebase")
+	return &revisionGuard{documentID: documentID, expected: expected, rebase: rebase}
+}
+
+// capture 在读取文档前记录当前版本，未指定 --expect-revision 时使"先读后写"的操作也能检测并发修改
+func (g *revisionGuard) capture(ctx context.Context) error {
+	if g.expected > 0 {
+		return nil
+	}
+	revision, err := client.GetDocumentRevision(ctx, g.documentID)
+	if err != nil {
+		return err
+	}
+	g.expected = revision
+	return nil
+}
+
+// resolve 校验文档版本，返回写入时使用的 document_revision_id（未启用校验时为 -1）
+// touched 为本次操作涉及的块（被更新的块，或插入/删除子块的父块），
+// 启用 --rebase 时若这些块在期望版本之后未被修改（或未涉及任何块），则视为不冲突并基于最新版本写入
+func (g *revisionGuard) resolve(ctx context.Context, touched ...string) (int, error) {
+	if g.expected <= 0 {
+		return -1, nil
+	}
+
+	current, err := client.GetDocumentRevision(ctx, g.documentID)
+	if err != nil {
+		return 0, err
+	}
+	if current == g.expected {
+		return g.expected, nil
+	}
+
+	conflict := &client.RevisionConflictError{
+		DocumentID: g.documentID,
+		Expected:   g.expected,
+		Actual:     current,
+	}
+	if !g.rebase {
+		return 0, conflict
+	}
+
+	unchanged, err := client.BlocksUnchangedSince(ctx, g.documentID, touched, g.expected)
+	if err != nil {
+		return 0, fmt.Errorf("检查块是否被修改失败: %w", err)
+	}
+	if !unchanged {
+		conflict.Reason = "目标块已被修改，无法自动合并"
+		return 0, conflict
+	}
+
+	fmt.Fprintf(os.Stderr, "文档已从版本 %d 更新到 %d，目标块未被修改，基于最新版本重试\n", g.expected, current)
+	g.expected = current
+	return current, nil
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/spf13/cobra"
+)
+
+// markdownImagePattern 匹配 Markdown 图片语法，捕获 alt 文本和路径
+var markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(?:<([^>]+)>|([^)\s]+))(?:\s+"[^"]*")?\s*\)`)
+
+var docStatsCmd = &cobra.Command{
+	Use:   "stats <document_id|url|file.md>",
+	Short: "统计文档内容和结构问题",
+	Long: `统计飞书文档或本地 Markdown 文件的内容，并检查常见的结构问题。
+
+统计内容:
+  - 各类型块的数量
+  - 字数（中日韩文字按字计数，英文按单词计数）和非空白字符数
+  - 预计阅读时间（中文约 300 字/分钟，英文约 200 词/分钟）
+  - 最大嵌套深度、图片数量、@文档 引用数量
+
+检查的问题:
+  image_missing_alt  图片缺少替代文本
+  empty_heading      标题内容为空
+  broken_mention     @文档 引用失效（文档不存在或无权访问）
+  deep_nesting       嵌套深度超过 --max-depth
+  oversized_table    表格单元格数超过 --max-table-cells
+
+参数为已存在的本地文件时按 Markdown 统计（不检查 @文档 引用），否则按飞书文档处理。
+限流、网络错误等导致暂时无法校验的 @文档 引用不报告为 broken_mention，
+而是列在结果的 errors 中（文本输出时打印到 stderr）。
+
+示例:
+  feishu-cli doc stats ABC123def456
+  feishu-cli doc stats README.md
+  feishu-cli doc stats ABC123def456 --max-depth 3 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		maxTableCells, _ := cmd.Flags().GetInt("max-table-cells")
+		output, _ := cmd.Flags().GetString("output")
+		opts := converter.StatsOptions{MaxDepth: maxDepth, MaxTableCells: maxTableCells}
+
+		var stats *converter.DocStats
+		if info, err := os.Stat(args[0]); err == nil && !info.IsDir() {
+			stats, err = localDocStats(args[0], opts)
+			if err != nil {
+				return err
+			}
+		} else {
+			if err := config.Validate(); err != nil {
+				return err
+			}
+			documentID, err := extractDocToken(args[0])
+			if err != nil {
+				return err
+			}
+			blocks, err := client.GetAllBlocks(ctx, documentID)
+			if err != nil {
+				return fmt.Errorf("获取块失败: %w", err)
+			}
+			stats = converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{DocumentID: documentID}).Stats(opts)
+			issues, errs := checkMentions(ctx, stats.Mentions)
+			stats.Issues = append(stats.Issues, issues...)
+			stats.Errors = append(stats.Errors, errs...)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		if isStructuredOutput(output) {
+			return printOutput(stats)
+		}
+		printDocStats(stats)
+		return nil
+	},
+}
+
+// localDocStats 统计本地 Markdown 文件
+// 未上传的图片在转换后是文本占位符，因此图片数量和 alt 文本直接从 Markdown 源码中统计
+func localDocStats(path string, opts converter.StatsOptions) (*converter.DocStats, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	_, body, err := converter.ParseFrontMatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+	// 去掉图片语法，避免图片占位符文本计入字数
+	text := markdownImagePattern.ReplaceAllString(body, "")
+	result, err := converter.NewMarkdownToBlock([]byte(text), converter.ConvertOptions{}, filepath.Dir(path)).ConvertWithTableData()
+	if err != nil {
+		return nil, fmt.Errorf("转换 Markdown 失败: %w", err)
+	}
+
+	blocks := converter.BuildDocumentBlocks(result)
+	stats := converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{}).Stats(opts)
+
+	// 本地块 ID 没有实际意义，不在结果中输出
+	for i := range stats.Issues {
+		stats.Issues[i].BlockID = ""
+	}
+	for _, m := range markdownImagePattern.FindAllStringSubmatch(body, -1) {
+		stats.Images++
+		if strings.TrimSpace(m[1]) == "" {
+			stats.Issues = append(stats.Issues, converter.StatsIssue{
+				Type:   converter.IssueImageMissingAlt,
+				Detail: fmt.Sprintf("图片 %s 缺少替代文本", m[2]+m[3]),
+			})
+		}
+	}
+	return stats, nil
+}
+
+// checkMentions 校验 @文档 引用的目标是否存在，相同 token 只查询一次
+// 只有文档不存在或无权访问时报告 broken_mention，其他错误（限流、网络错误等）作为检查失败返回且不缓存
+func checkMentions(ctx context.Context, mentions []converter.MentionRef) ([]converter.StatsIssue, []string) {
+	var issues []converter.StatsIssue
+	var errs []string
+	checked := map[string]string{}
+	for _, ref := range mentions {
+		if ctx.Err() != nil {
+			break
+		}
+		if ref.Token == "" {
+			continue
+		}
+		reason, ok := checked[ref.Token]
+		if !ok {
+			var err error
+			reason, err = docReferenceReason(checkDocReference(ctx, ref.Token, mentionDocTypes[ref.ObjType]))
+			if err != nil {
+				if ctx.Err() == nil {
+					errs = append(errs, fmt.Sprintf("块 %s 的 @文档 %q (%s): %v", ref.BlockID, ref.Title, ref.Token, err))
+				}
+				continue
+			}
+			checked[ref.Token] = reason
+		}
+		if reason != "" {
+			issues = append(issues, converter.StatsIssue{
+				Type:    converter.IssueBrokenMention,
+				BlockID: ref.BlockID,
+				Detail:  fmt.Sprintf("@文档 %q (%s) 无法访问: %s", ref.Title, ref.Token, reason),
+			})
+		}
+	}
+	return issues, errs
+}
+
+// printDocStats 以文本形式输出统计结果
+func printDocStats(stats *converter.DocStats) {
+	fmt.Printf("块数量:     %d\n", stats.Blocks)
+	fmt.Printf("字数:       %d (中日韩 %d, 英文单词 %d)\n", stats.Words.Total(), stats.Words.CJK, stats.Words.Latin)
+	fmt.Printf("字符数:     %d (不含空白)\n", stats.Characters)
+	fmt.Printf("阅读时间:   约 %d 分钟\n", stats.ReadingMinutes)
+	fmt.Printf("嵌套深度:   %d\n", stats.MaxDepth)
+	fmt.Printf("图片:       %d\n", stats.Images)
+	fmt.Printf("@文档 引用: %d\n", len(stats.Mentions))
+
+	if len(stats.BlockTypes) > 0 {
+		names := make([]string, 0, len(stats.BlockTypes))
+		for name := range stats.BlockTypes {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if stats.BlockTypes[names[i]] != stats.BlockTypes[names[j]] {
+				return stats.BlockTypes[names[i]] > stats.BlockTypes[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		fmt.Println("\n块类型:")
+		for _, name := range names {
+			fmt.Printf("  %-16s %d\n", name, stats.BlockTypes[name])
+		}
+	}
+
+	for _, e := range stats.Errors {
+		fmt.Fprintf(os.Stderr, "检查失败: %s\n", e)
+	}
+	if len(stats.Issues) == 0 {
+		fmt.Println("\n未发现问题")
+		return
+	}
+	fmt.Printf("\n发现 %d 个问题:\n", len(stats.Issues))
+	for _, issue := range stats.Issues {
+		if issue.BlockID != "" {
+			fmt.Printf("  [%s] %s  %s\n", issue.Type, issue.BlockID, issue.Detail)
+		} else {
+			fmt.Printf("  [%s] %s\n", issue.Type, issue.Detail)
+		}
+	}
+}
+
+func init() {
+	docCmd.AddCommand(docStatsCmd)
+	docStatsCmd.Flags().Int("max-depth", 4, "嵌套深度上限，超过时报告问题 (0 表示不检查)")
+	docStatsCmd.Flags().Int("max-table-cells", 200, "表格单元格数上限，超过时报告问题 (0 表示不检查)")
+	addOutputFlag(docStatsCmd)
+}
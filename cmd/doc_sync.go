@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/riba2534/feishu-cli/internal/docsync"
+	"github.com/spf13/cobra"
+)
+
+// syncResult 单个文件的同步结果
+type syncResult struct {
+	Path       string `json:"path"`
+	DocumentID string `json:"document_id,omitempty"`
+	Status     string `json:"status"`
+	Action     string `json:"action,omitempty"`
+	Revision   int    `json:"revision_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+var docPushCmd = &cobra.Command{
+	Use:   "push [file.md...]",
+	Short: "按清单将本地 Markdown 推送到飞书文档",
+	Long: `按项目清单（默认 .feishu.yaml）将本地 Markdown 文件推送到对应的飞书文档。
+
+清单格式:
+  folder: fldcnXXX              # 新建文档的默认文件夹（可选）
+  documents:
+    - path: docs/design.md
+      document_id: doxcnXXX     # 已有文档
+    - path: docs/guide.md
+      wiki_node: wikcnXXX       # 知识库节点
+    - path: docs/new.md         # 未指定时 push 新建文档并回写 document_id
+      title: 新文档
+
+同步状态（内容哈希、远端 revision_id）保存在清单同目录的 .feishu-state.json。
+push 只处理自上次同步后有修改的文件，会清空远端文档后重新导入。
+如果远端文档在上次同步后被修改（revision_id 变化），push 拒绝覆盖，除非指定 --force。
+
+示例:
+  feishu-cli doc push
+  feishu-cli doc push docs/design.md
+  feishu-cli doc push --manifest path/to/.feishu.yaml --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		force, _ := cmd.Flags().GetBool("force")
+		output, _ := cmd.Flags().GetString("output")
+
+		manifest, state, entries, err := loadSyncContext(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		var results []*syncResult
+		manifestChanged := false
+		for _, e := range entries {
+			// 中断时保留已完成文件的状态，跳过剩余文件
+			if ctx.Err() != nil {
+				break
+			}
+			r, created := pushEntry(ctx, manifest, state, e, force)
+			results = append(results, r)
+			if created {
+				manifestChanged = true
+			}
+			// 每个文件完成后立即保存，避免中途失败丢失已同步的状态
+			if err := state.Save(); err != nil {
+				return err
+			}
+		}
+
+		if manifestChanged {
+			if err := manifest.Save(); err != nil {
+				return err
+			}
+		}
+
+		if err := reportSyncResults(results, output); err != nil {
+			return err
+		}
+		return ctx.Err()
+	},
+}
+
+var docPullCmd = &cobra.Command{
+	Use:   "pull [file.md...]",
+	Short: "按清单将飞书文档拉取为本地 Markdown",
+	Long: `按项目清单（默认 .feishu.yaml）重新导出飞书文档并覆盖本地文件。
+
+通过 revision_id 判断远端是否有修改，未修改的文件会跳过。
+如果本地文件在上次同步后被修改，pull 拒绝覆盖，除非指定 --force。
+
+示例:
+  feishu-cli doc pull
+  feishu-cli doc pull docs/design.md --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		force, _ := cmd.Flags().GetBool("force")
+		output, _ := cmd.Flags().GetString("output")
+
+		manifest, state, entries, err := loadSyncContext(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		var results []*syncResult
+		for _, e := range entries {
+			if ctx.Err() != nil {
+				break
+			}
+			results = append(results, pullEntry(ctx, manifest, state, e, force))
+			if err := state.Save(); err != nil {
+				return err
+			}
+		}
+
+		if err := reportSyncResults(results, output); err != nil {
+			return err
+		}
+		return ctx.Err()
+	},
+}
+
+var docStatusCmd = &cobra.Command{
+	Use:   "status [file.md...]",
+	Short: "查看清单中文件的同步状态",
+	Long: `对比本地文件哈希和远端 revision_id，显示清单中每个文件的同步状态。
+
+状态:
+  clean            本地和远端均未修改
+  local-modified   本地有修改，可 push
+  remote-modified  远端有修改，可 pull
+  conflict         两端都有修改，需要 --force 选择一端覆盖
+  untracked        尚未同步过
+  missing          本地文件不存在
+
+示例:
+  feishu-cli doc status
+  feishu-cli doc status --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		output, _ := cmd.Flags().GetString("output")
+
+		manifest, state, entries, err := loadSyncContext(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		var results []*syncResult
+		for _, e := range entries {
+			r := &syncResult{Path: e.Path}
+			results = append(results, r)
+
+			localHash, err := docsync.HashFile(manifest.LocalPath(e))
+			if err != nil {
+				r.Status, r.Error = "error", err.Error()
+				continue
+			}
+
+			prev := state.Files[e.Path]
+			documentID, err := resolveSyncDocumentID(ctx, e, prev)
+			if err != nil {
+				r.Status, r.Error = "error", err.Error()
+				continue
+			}
+			r.DocumentID = documentID
+
+			if documentID != "" {
+				doc, err := client.GetDocument(ctx, documentID)
+				if err != nil {
+					r.Status, r.Error = "error", err.Error()
+					continue
+				}
+				r.Revision = client.IntVal(doc.RevisionId)
+			}
+
+			r.Status = string(docsync.Compare(prev, localHash, r.Revision))
+		}
+
+		if isStructuredOutput(output) {
+			return printOutput(results)
+		}
+		for _, r := range results {
+			line := fmt.Sprintf("  %-16s %s", r.Status, r.Path)
+			if r.DocumentID != "" {
+				line += fmt.Sprintf(" → %s", r.DocumentID)
+			}
+			if r.Error != "" {
+				line += fmt.Sprintf(" (%s)", r.Error)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+// loadSyncContext 读取清单、同步状态，并按参数筛选清单项
+func loadSyncContext(cmd *cobra.Command, args []string) (*docsync.Manifest, *docsync.State, []*docsync.Entry, error) {
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+
+	manifest, err := docsync.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	state, err := docsync.LoadState(manifest)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	entries, err := manifest.Filter(args)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return manifest, state, entries, nil
+}
+
+// resolveSyncDocumentID 解析清单项对应的文档 ID
+// 优先级: document_id > wiki_node > 上次同步记录
+func resolveSyncDocumentID(ctx context.Context, e *docsync.Entry, prev *docsync.FileState) (string, error) {
+	if e.DocumentID != "" {
+		return e.DocumentID, nil
+	}
+	if e.WikiNode != "" {
+		node, err := client.GetWikiNode(ctx, e.WikiNode)
+		if err != nil {
+			return "", err
+		}
+		if node.ObjType != "docx" {
+			return "", fmt.Errorf("知识库节点 %s 类型为 %s，仅支持 docx", e.WikiNode, node.ObjType)
+		}
+		return node.ObjToken, nil
+	}
+	if prev != nil {
+		return prev.DocumentID, nil
+	}
+	return "", nil
+}
+
+// pushEntry 推送单个文件，返回结果和是否新建了文档
+func pushEntry(ctx context.Context, manifest *docsync.Manifest, state *docsync.State, e *docsync.Entry, force bool) (*syncResult, bool) {
+	r := &syncResult{Path: e.Path}
+	fail := func(err error) (*syncResult, bool) {
+		r.Status, r.Error = "error", err.Error()
+		fmt.Printf("  ✗ %s: %v\n", e.Path, err)
+		return r, false
+	}
+
+	localPath := manifest.LocalPath(e)
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return fail(fmt.Errorf("读取文件失败: %w", err))
+	}
+	localHash := docsync.HashContent(content)
+	prev := state.Files[e.Path]
+
+	fm, body, err := converter.ParseFrontMatter(string(content))
+	if err != nil {
+		return fail(err)
+	}
+
+	documentID, err := resolveSyncDocumentID(ctx, e, prev)
+	if err != nil {
+		return fail(err)
+	}
+	r.DocumentID = documentID
+
+	created := false
+	if documentID == "" {
+		title := e.Title
+		if title == "" && fm != nil {
+			title = fm.Title
+		}
+		if title == "" {
+			title = titleFromFileName(localPath)
+		}
+		folder := e.Folder
+		if folder == "" {
+			folder = manifest.Folder
+		}
+		documentID, err = createTargetDocument(ctx, title, folder, "")
+		if err != nil {
+			return fail(err)
+		}
+		e.DocumentID = documentID
+		r.DocumentID = documentID
+		created = true
+	} else {
+		if prev != nil && prev.LocalHash == localHash && !force {
+			r.Status, r.Action = string(docsync.StatusClean), "skip"
+			return r, false
+		}
+
+		doc, err := client.GetDocument(ctx, documentID)
+		if err != nil {
+			return fail(err)
+		}
+		revision := client.IntVal(doc.RevisionId)
+		if !force {
+			if prev == nil {
+				r.Status, r.Revision = string(docsync.StatusConflict), revision
+				r.Error = "远端文档尚未同步过，push 将覆盖其内容"
+				fmt.Printf("  ! %s: %s，使用 --force 覆盖\n", e.Path, r.Error)
+				return r, false
+			}
+			if prev.RemoteRevision != revision {
+				r.Status, r.Revision = string(docsync.StatusConflict), revision
+				r.Error = fmt.Sprintf("远端已修改 (revision %d → %d)", prev.RemoteRevision, revision)
+				fmt.Printf("  ! %s: %s，使用 doc pull 同步或 --force 覆盖\n", e.Path, r.Error)
+				return r, false
+			}
+		}
+
+		if err := clearDocumentContent(ctx, documentID); err != nil {
+			return fail(err)
+		}
+	}
+
+	fmt.Printf("--- 推送 %s → %s ---\n", e.Path, documentID)
+	if _, err := runImportPipeline(ctx, documentID, body, filepath.Dir(localPath), defaultImportPipelineOptions()); err != nil {
+		return fail(err)
+	}
+
+	doc, err := client.GetDocument(ctx, documentID)
+	if err != nil {
+		return fail(err)
+	}
+	r.Revision = client.IntVal(doc.RevisionId)
+	state.Record(e.Path, documentID, localHash, r.Revision)
+
+	r.Status, r.Action = string(docsync.StatusClean), "pushed"
+	if created {
+		r.Action = "created"
+	}
+	return r, created
+}
+
+// pullEntry 拉取单个文件
+func pullEntry(ctx context.Context, manifest *docsync.Manifest, state *docsync.State, e *docsync.Entry, force bool) *syncResult {
+	r := &syncResult{Path: e.Path}
+	fail := func(err error) *syncResult {
+		r.Status, r.Error = "error", err.Error()
+		fmt.Printf("  ✗ %s: %v\n", e.Path, err)
+		return r
+	}
+
+	prev := state.Files[e.Path]
+	documentID, err := resolveSyncDocumentID(ctx, e, prev)
+	if err != nil {
+		return fail(err)
+	}
+	if documentID == "" {
+		r.Status, r.Action = string(docsync.StatusUntracked), "skip"
+		return r
+	}
+	r.DocumentID = documentID
+
+	doc, err := client.GetDocument(ctx, documentID)
+	if err != nil {
+		return fail(err)
+	}
+	r.Revision = client.IntVal(doc.RevisionId)
+
+	localPath := manifest.LocalPath(e)
+	localHash, err := docsync.HashFile(localPath)
+	if err != nil {
+		return fail(err)
+	}
+
+	if !force {
+		// 远端未修改，无需拉取（本地修改留待 push）
+		if prev != nil && prev.RemoteRevision == r.Revision {
+			r.Status, r.Action = string(docsync.Compare(prev, localHash, r.Revision)), "skip"
+			return r
+		}
+		localChanged := localHash != "" && (prev == nil || prev.LocalHash != localHash)
+		if localChanged {
+			r.Status = string(docsync.StatusConflict)
+			r.Error = "本地文件有未推送的修改"
+			fmt.Printf("  ! %s: %s，使用 doc push 同步或 --force 覆盖\n", e.Path, r.Error)
+			return r
+		}
+	}
+
+	blocks, err := client.GetAllBlocks(ctx, documentID)
+	if err != nil {
+		return fail(fmt.Errorf("获取块失败: %w", err))
+	}
+	markdown, err := converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{DocumentID: documentID}).Convert()
+	if err != nil {
+		return fail(fmt.Errorf("转换为 Markdown 失败: %w", err))
+	}
+
+	if dir := filepath.Dir(localPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fail(fmt.Errorf("创建目录失败: %w", err))
+		}
+	}
+	if err := os.WriteFile(localPath, []byte(markdown), 0644); err != nil {
+		return fail(fmt.Errorf("写入文件失败: %w", err))
+	}
+
+	state.Record(e.Path, documentID, docsync.HashContent([]byte(markdown)), r.Revision)
+	r.Status, r.Action = string(docsync.StatusClean), "pulled"
+	fmt.Printf("  ✓ %s ← %s (revision %d)\n", e.Path, documentID, r.Revision)
+	return r
+}
+
+// clearDocumentContent 删除文档根节点下的全部子块
+func clearDocumentContent(ctx context.Context, documentID string) error {
+	children, err := client.GetAllBlockChildren(ctx, documentID, documentID)
+	if err != nil {
+		return fmt.Errorf("获取文档子块失败: %w", err)
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	return client.DeleteBlocks(ctx, documentID, documentID, 0, len(children))
+}
+
+// reportSyncResults 输出同步汇总，存在冲突或错误时返回错误以便脚本感知
+func reportSyncResults(results []*syncResult, output string) error {
+	var conflicts, failures int
+	for _, r := range results {
+		switch r.Status {
+		case string(docsync.StatusConflict):
+			conflicts++
+		case "error":
+			failures++
+		}
+	}
+
+	if isStructuredOutput(output) {
+		if err := printOutput(results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		fmt.Println("同步结果:")
+		for _, r := range results {
+			action := r.Action
+			if action == "" {
+				action = r.Status
+			}
+			fmt.Printf("  %-10s %s", action, r.Path)
+			if r.DocumentID != "" {
+				fmt.Printf(" (%s)", r.DocumentID)
+			}
+			fmt.Println()
+		}
+	}
+
+	if conflicts > 0 || failures > 0 {
+		return fmt.Errorf("同步未完成: %d 个冲突, %d 个失败", conflicts, failures)
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{docPushCmd, docPullCmd, docStatusCmd} {
+		docCmd.AddCommand(c)
+		c.Flags().String("manifest", docsync.DefaultManifestName, "清单文件路径")
+		addOutputFlag(c)
+	}
+	docPushCmd.Flags().Bool("force", false, "忽略冲突，强制覆盖远端文档")
+	docPullCmd.Flags().Bool("force", false, "忽略冲突，强制覆盖本地文件")
+}
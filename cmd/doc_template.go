@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+// loadTemplateVars 合并模板变量
+// 优先级: --var > --vars-cmd > --vars（多个文件按顺序覆盖）
+func loadTemplateVars(varFiles, varCmds, vars []string) (map[string]any, error) {
+	data := map[string]any{}
+
+	for _, file := range varFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取变量文件失败: %w", err)
+		}
+		// YAML 是 JSON 的超集，.json 文件同样可以解析
+		fileVars := map[string]any{}
+		if err := yaml.Unmarshal(content, &fileVars); err != nil {
+			return nil, fmt.Errorf("解析变量文件 %s 失败: %w", file, err)
+		}
+		for k, v := range fileVars {
+			data[k] = v
+		}
+	}
+
+	for _, kv := range varCmds {
+		key, command, ok := strings.Cut(kv, "=")
+		if !ok || key == "" || command == "" {
+			return nil, fmt.Errorf("无效的 --vars-cmd 参数 %q，格式应为 key=command", kv)
+		}
+		out, err := exec.Command("sh", "-c", command).Output()
+		if err != nil {
+			return nil, fmt.Errorf("执行变量命令 %q 失败: %w", command, err)
+		}
+		var value any
+		if err := json.Unmarshal(out, &value); err != nil {
+			// 非 JSON 输出按纯文本处理
+			value = strings.TrimRight(string(out), "\n")
+		}
+		data[key] = value
+	}
+
+	for _, kv := range vars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("无效的 --var 参数 %q，格式应为 key=value", kv)
+		}
+		data[key] = value
+	}
+
+	return data, nil
+}
+
+// renderDocTemplate 使用 Go text/template 渲染 Markdown 模板
+// 引用未定义的变量会直接报错，避免生成包含 "<no value>" 的文档
+func renderDocTemplate(path string, data map[string]any) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取模板失败: %w", err)
+	}
+
+	tmpl, err := template.New(path).
+		Option("missingkey=error").
+		Funcs(docTemplateFuncs()).
+		Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("解析模板失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("渲染模板失败: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// docTemplateFuncs 返回模板可用的函数
+// tasks/events 直接调用飞书 API，让模板可以引用任务列表和日程数据
+func docTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"now": time.Now,
+		"date": func(layout string) string {
+			return time.Now().Format(layout)
+		},
+		"addDays": func(days int, t time.Time) time.Time {
+			return t.AddDate(0, 0, days)
+		},
+		"join":  strings.Join,
+		"split": strings.Split,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"default": func(def any, value any) any {
+			if value == nil || value == "" {
+				return def
+			}
+			return value
+		},
+		"toJSON": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+		"tasks":  templateTasks,
+		"events": templateEvents,
+	}
+}
+
+// templateTasks 获取任务列表，status 可选 all/todo/done
+func templateTasks(ctx context.Context, status string) ([]*client.TaskInfo, error) {
+	var completed *bool
+	switch status {
+	case "", "all":
+	case "todo":
+		v := false
+		completed = &v
+	case "done":
+		v := true
+		completed = &v
+	default:
+		return nil, fmt.Errorf("tasks 不支持的状态: %s（可选 all/todo/done）", status)
+	}
+
+	var all []*client.TaskInfo
+	pageToken := ""
+	for {
+		result, err := client.ListTasks(ctx, 100, pageToken, completed)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Tasks...)
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return all, nil
+}
+
+// templateEvents 获取日历在指定时间范围内的日程（RFC3339 格式）
+func templateEvents(ctx context.Context, calendarID, startTime, endTime string) ([]*client.CalendarEvent, error) {
+	var all []*client.CalendarEvent
+	pageToken := ""
+	for {
+		events, nextToken, hasMore, err := client.ListEvents(ctx, &client.ListEventsParams{
+			CalendarID: calendarID,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			PageSize:   500,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events...)
+		if !hasMore || nextToken == "" {
+			break
+		}
+		pageToken = nextToken
+	}
+	return all, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTemplateVars_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	varsFile := filepath.Join(dir, "vars.yaml")
+	os.WriteFile(varsFile, []byte("team: from-file\nmembers:\n  - alice\n  - bob\n"), 0600)
+
+	data, err := loadTemplateVars([]string{varsFile}, []string{"week=echo 42"}, []string{"team=infra"})
+	if err != nil {
+		t.Fatalf("loadTemplateVars() 返回错误: %v", err)
+	}
+
+	if data["team"] != "infra" {
+		t.Errorf("team = %v, 期望 --var 覆盖文件变量", data["team"])
+	}
+	if members, ok := data["members"].([]any); !ok || len(members) != 2 {
+		t.Errorf("members = %v, 期望包含 2 个元素的列表", data["members"])
+	}
+	// 命令输出 "42" 是合法 JSON，按数字解析
+	if data["week"] != float64(42) {
+		t.Errorf("week = %v (%T), 期望 42", data["week"], data["week"])
+	}
+}
+
+func TestLoadTemplateVars_Invalid(t *testing.T) {
+	if _, err := loadTemplateVars(nil, nil, []string{"novalue"}); err == nil {
+		t.Error("缺少 = 的 --var 应返回错误")
+	}
+	if _, err := loadTemplateVars(nil, []string{"=cmd"}, nil); err == nil {
+		t.Error("缺少 key 的 --vars-cmd 应返回错误")
+	}
+}
+
+func TestRenderDocTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tmplFile := filepath.Join(dir, "weekly.md.tmpl")
+	os.WriteFile(tmplFile, []byte("---\ntitle: \"{{ .team }} 周报\"\n---\n{{ range .items }}- {{ upper . }}\n{{ end }}"), 0600)
+
+	out, err := renderDocTemplate(tmplFile, map[string]any{
+		"team":  "infra",
+		"items": []any{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("renderDocTemplate() 返回错误: %v", err)
+	}
+	expected := "---\ntitle: \"infra 周报\"\n---\n- A\n- B\n"
+	if out != expected {
+		t.Errorf("渲染结果 = %q, 期望 %q", out, expected)
+	}
+
+	if _, err := renderDocTemplate(tmplFile, map[string]any{"items": []any{}}); err == nil {
+		t.Error("引用未定义变量应返回错误")
+	}
+}
+
+func TestTitleFromFileName(t *testing.T) {
+	tests := map[string]string{
+		"docs/weekly.md.tmpl": "weekly",
+		"report.md":           "report",
+		"notes":               "notes",
+		".md":                 ".md",
+	}
+	for input, expected := range tests {
+		if got := titleFromFileName(input); got != expected {
+			t.Errorf("titleFromFileName(%q) = %q, 期望 %q", input, got, expected)
+		}
+	}
+}
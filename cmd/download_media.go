@@ -28,6 +28,7 @@ var downloadMediaCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		output, _ := cmd.Flags().GetString("output")
 
@@ -41,16 +42,16 @@ var downloadMediaCmd = &cobra.Command{
 		}
 
 		// Try to get temp URL first
-		url, err := client.GetMediaTempURL(fileToken)
+		url, err := client.GetMediaTempURL(ctx, fileToken)
 		if err == nil {
-			if err := client.DownloadFromURL(url, output); err == nil {
+			if err := client.DownloadFromURL(ctx, url, output); err == nil {
 				fmt.Printf("已下载到 %s\n", output)
 				return nil
 			}
 		}
 
 		// Fallback to direct download
-		if err := client.DownloadMedia(fileToken, output); err != nil {
+		if err := client.DownloadMedia(ctx, fileToken, output); err != nil {
 			return err
 		}
 
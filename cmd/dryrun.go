@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/output"
+)
+
+// finishDryRun 输出 dry-run 时拦截的写请求。命令因写请求被拦截而停止时视为成功
+func finishDryRun(err error) error {
+	stopped := errors.Is(err, client.ErrDryRun)
+	if stopped {
+		err = nil
+	}
+
+	requests := client.DryRunRequests()
+	if len(requests) == 0 {
+		return err
+	}
+	if printErr := printDryRunRequests(requests); printErr != nil && err == nil {
+		err = printErr
+	}
+	if stopped {
+		fmt.Fprintf(os.Stderr, "dry-run: 以上请求未发送，命令在写请求处停止，后续步骤未执行\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "dry-run: 以上 %d 个请求未发送\n", len(requests))
+	}
+	return err
+}
+
+// printDryRunRequests 结构化格式按 -o 输出请求列表，否则逐个输出方法、地址和请求体
+func printDryRunRequests(requests []client.DryRunRequest) error {
+	if isStructuredOutput(outputOpts.Format) {
+		return output.Write(os.Stdout, requests, outputOpts)
+	}
+
+	var buf bytes.Buffer
+	for _, req := range requests {
+		fmt.Fprintf(&buf, "[dry-run] %s %s\n", req.Method, req.URL)
+		switch body := req.Body.(type) {
+		case nil:
+		case json.RawMessage:
+			// 记录请求时已确认是有效的 JSON
+			_ = json.Indent(&buf, body, "", "  ")
+			buf.WriteByte('\n')
+		default:
+			fmt.Fprintf(&buf, "%v\n", body)
+		}
+	}
+	_, err := os.Stdout.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// 退出码，自动化脚本可据此区分错误类别而无需解析错误信息
+//
+//	0    成功
+//	1    其他错误
+//	2    命令行参数错误或接口参数校验失败
+//	3    文档版本冲突（exitCodeRevisionConflict）
+//	4    认证失败：应用凭证无效、未登录或 token 过期
+//	5    权限不足：缺少 scope 或无权访问资源
+//	6    资源不存在或已删除
+//	7    触发频率限制（已按 --max-retries 重试）
+//	8    网络错误
+//	9    服务端内部错误
+//	124  超过 --timeout
+//	130  被 Ctrl+C 或 SIGTERM 中断
+const (
+	exitCodeError       = 1
+	exitCodeUsage       = 2
+	exitCodeAuth        = 4
+	exitCodePermission  = 5
+	exitCodeNotFound    = 6
+	exitCodeRateLimit   = 7
+	exitCodeNetwork     = 8
+	exitCodeServer      = 9
+	exitCodeTimeout     = 124 // 超过 --timeout，与 timeout(1) 一致
+	exitCodeInterrupted = 130 // 收到 Ctrl+C 或 SIGTERM（128 + SIGINT）
+)
+
+// 中断和超时不属于接口错误，单独作为错误类别
+const (
+	errorKindTimeout     client.ErrorKind = "timeout"
+	errorKindInterrupted client.ErrorKind = "interrupted"
+)
+
+var exitCodes = map[client.ErrorKind]int{
+	client.ErrorKindValidation: exitCodeUsage,
+	client.ErrorKindConflict:   exitCodeRevisionConflict,
+	client.ErrorKindAuth:       exitCodeAuth,
+	client.ErrorKindPermission: exitCodePermission,
+	client.ErrorKindNotFound:   exitCodeNotFound,
+	client.ErrorKindRateLimit:  exitCodeRateLimit,
+	client.ErrorKindNetwork:    exitCodeNetwork,
+	client.ErrorKindServer:     exitCodeServer,
+	errorKindTimeout:           exitCodeTimeout,
+	errorKindInterrupted:       exitCodeInterrupted,
+}
+
+// usageError 命令行参数错误
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// newUsageError 将参数错误标记为 usageError，err 为 nil 时返回 nil
+func newUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &usageError{err: err}
+}
+
+// markUsageErrors 将命令树中参数个数校验的错误标记为 usageError
+func markUsageErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			return newUsageError(args(c, a))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// errorKind 返回命令错误的类别
+func errorKind(cmd *cobra.Command, err error) client.ErrorKind {
+	if cmd != nil && cmd.Context() != nil {
+		cause := context.Cause(cmd.Context())
+		switch {
+		case errors.Is(cause, errCommandInterrupted):
+			return errorKindInterrupted
+		case errors.Is(cause, errCommandTimeout):
+			return errorKindTimeout
+		}
+	}
+	var usage *usageError
+	if errors.As(err, &usage) {
+		return client.ErrorKindValidation
+	}
+	return client.ErrorKindOf(err)
+}
+
+// exitCodeFor 根据错误类别返回退出码
+func exitCodeFor(cmd *cobra.Command, err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[errorKind(cmd, err)]; ok {
+		return code
+	}
+	return exitCodeError
+}
+
+// errorEnvelope 结构化输出时写入 stderr 的错误信息
+type errorEnvelope struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message    string `json:"message"`
+	Kind       string `json:"kind"`
+	ExitCode   int    `json:"exit_code"`
+	Code       int    `json:"code,omitempty"`
+	Msg        string `json:"msg,omitempty"`
+	LogID      string `json:"log_id,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Retryable  bool   `json:"retryable"`
+	Hint       string `json:"hint,omitempty"`
+}
+
+// printError 输出命令错误：结构化输出时为 JSON，否则为文本和排查提示
+func printError(cmd *cobra.Command, err error, code int) {
+	hint := permissionHint(cmd, err)
+
+	if isStructuredOutput(errorOutputFormat(cmd)) {
+		kind := errorKind(cmd, err)
+		detail := errorDetail{
+			Message:  err.Error(),
+			Kind:     string(kind),
+			ExitCode: code,
+			Hint:     hint,
+			// 限流、服务端错误和网络错误稍后重试可能成功
+			Retryable: kind == client.ErrorKindRateLimit || kind == client.ErrorKindServer || kind == client.ErrorKindNetwork,
+		}
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			detail.Code = apiErr.Code
+			detail.Msg = apiErr.Msg
+			detail.LogID = apiErr.LogID
+			detail.HTTPStatus = apiErr.HTTPStatus
+		}
+		data, _ := json.Marshal(errorEnvelope{Error: detail})
+		fmt.Fprintln(os.Stderr, string(data))
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	var usage *usageError
+	if errors.As(err, &usage) && cmd != nil {
+		fmt.Fprintln(os.Stderr, cmd.UsageString())
+		return
+	}
+	if code == exitCodeInterrupted || code == exitCodeTimeout {
+		if cause := context.Cause(cmd.Context()); !errors.Is(err, cause) {
+			fmt.Fprintln(os.Stderr, cause)
+		}
+	}
+	// 权限不足时提示需要开通的 scope
+	if hint != "" {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, hint)
+	}
+}
+
+// errorOutputFormat 返回命令的输出格式。参数校验失败时 PersistentPreRunE 尚未执行，直接读取 -o 参数
+func errorOutputFormat(cmd *cobra.Command) string {
+	if outputOpts.Format != "" {
+		return outputOpts.Format
+	}
+	if cmd == nil {
+		return ""
+	}
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil || flag.Annotations[outputFormatAnnotation] == nil {
+		return ""
+	}
+	format, _ := output.ParseFormat(flag.Value.String())
+	return format
+}
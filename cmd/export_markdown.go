@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
 	"github.com/riba2534/feishu-cli/internal/client"
 	"github.com/riba2534/feishu-cli/internal/config"
 	"github.com/riba2534/feishu-cli/internal/converter"
@@ -21,16 +28,33 @@ var exportMarkdownCmd = &cobra.Command{
   feishu-cli doc export https://xxx.feishu.cn/docx/ABC123def456
   feishu-cli doc export https://xxx.larkoffice.com/docx/ABC123def456
 
+分块导出 (--format chunks.jsonl):
+  沿标题层级将文档切分为语义连贯的片段，每行输出一个 JSON 对象，包含 Markdown 文本、
+  标题路径、来源块 ID、文档链接和更新时间，可直接用于 RAG 索引并引用回原始块。
+  表格和代码块不会被拆开（包括高亮块、引用和列表中的），超长的高亮块、引用和列表
+  在子块边界处拆分，--max-tokens 控制每个片段的估算 token 上限。
+
+导出评论 (--with-comments):
+  获取文档的全部评论，按局部评论的引用文本定位到所在的块，--comment-style 控制输出方式:
+    footnote  在块末尾添加脚注引用，文末输出脚注（默认）
+    html      在块之后输出 <!-- comment --> 注释块
+    sidecar   Markdown 保持不变，评论写入同名的 .comments.json 文件（需指定 --output），
+              包含引用文本、块 ID、作者、解决状态和全部回复
+  全文评论和无法定位的评论放在文末。
+
 示例:
   feishu-cli doc export ABC123def456
   feishu-cli doc export ABC123def456 --output doc.md
-  feishu-cli doc export ABC123def456 --download-images --assets-dir ./images`,
+  feishu-cli doc export ABC123def456 --download-images --assets-dir ./images
+  feishu-cli doc export ABC123def456 --format chunks.jsonl --max-tokens 800 --output doc.jsonl
+  feishu-cli doc export ABC123def456 --with-comments --comment-style sidecar --output doc.md`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID, err := extractDocToken(args[0])
 		if err != nil {
 			return err
@@ -40,16 +64,18 @@ var exportMarkdownCmd = &cobra.Command{
 		assetsDir, _ := cmd.Flags().GetString("assets-dir")
 
 		// Get all blocks
-		blocks, err := client.GetAllBlocks(documentID)
+		blocks, err := client.GetAllBlocks(ctx, documentID)
 		if err != nil {
 			return fmt.Errorf("获取块失败: %w", err)
 		}
 
 		frontMatter, _ := cmd.Flags().GetBool("front-matter")
 		highlight, _ := cmd.Flags().GetBool("highlight")
+		format, _ := cmd.Flags().GetString("format")
 
 		// Convert to Markdown
 		options := converter.ConvertOptions{
+			Context:        ctx,
 			DownloadImages: downloadImages,
 			AssetsDir:      assetsDir,
 			DocumentID:     documentID,
@@ -57,20 +83,55 @@ var exportMarkdownCmd = &cobra.Command{
 			Highlight:      highlight,
 		}
 
+		switch format {
+		case "", "markdown":
+		case "chunks.jsonl":
+			maxTokens, _ := cmd.Flags().GetInt("max-tokens")
+			return exportChunks(ctx, documentID, blocks, options, maxTokens, output)
+		default:
+			return fmt.Errorf("不支持的导出格式: %s（可选 markdown/chunks.jsonl）", format)
+		}
+
+		withComments, _ := cmd.Flags().GetBool("with-comments")
+		commentStyle, _ := cmd.Flags().GetString("comment-style")
+		if withComments && commentStyle == "sidecar" && output == "" {
+			return fmt.Errorf("--comment-style sidecar 需要指定 --output")
+		}
+
 		conv := converter.NewBlockToMarkdown(blocks, options)
-		markdown, err := conv.Convert()
+		var markdown string
+		var comments []converter.AnchoredComment
+		if withComments {
+			all, err := listAllComments(ctx, documentID, "docx")
+			if err != nil {
+				return err
+			}
+			comments = conv.AnchorComments(all)
+		}
+		if withComments && commentStyle != "sidecar" {
+			markdown, err = conv.ConvertWithComments(comments, commentStyle)
+		} else {
+			markdown, err = conv.Convert()
+		}
 		if err != nil {
 			return fmt.Errorf("转换为 Markdown 失败: %w", err)
 		}
+		// 中断时图片下载不完整，不写入文件
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		// 添加 Front Matter
 		if frontMatter {
 			docTitle := ""
-			doc, docErr := client.GetDocument(documentID)
+			doc, docErr := client.GetDocument(ctx, documentID)
 			if docErr == nil && doc != nil && doc.Title != nil {
 				docTitle = *doc.Title
 			}
-			fm := fmt.Sprintf("---\ntitle: %q\ndocument_id: %s\n---\n\n", docTitle, documentID)
+			fm, err := buildExportFrontMatter(ctx, documentID, docTitle, "").Render()
+			if err != nil {
+				return err
+			}
 			markdown = fm + markdown
 		}
 
@@ -84,10 +145,137 @@ var exportMarkdownCmd = &cobra.Command{
 			fmt.Print(markdown)
 		}
 
+		if withComments && commentStyle == "sidecar" {
+			path := commentsSidecarPath(output)
+			data, err := json.MarshalIndent(comments, "", "  ")
+			if err != nil {
+				return fmt.Errorf("序列化评论失败: %w", err)
+			}
+			if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("写入评论文件失败: %w", err)
+			}
+			fmt.Printf("已导出 %d 条评论到 %s\n", len(comments), path)
+		}
+
 		return nil
 	},
 }
 
+// listAllComments 分页获取文档的全部评论
+func listAllComments(ctx context.Context, fileToken, fileType string) ([]*client.Comment, error) {
+	var all []*client.Comment
+	pageToken := ""
+	for {
+		comments, nextPageToken, hasMore, err := client.ListComments(ctx, fileToken, fileType, 100, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if !hasMore || nextPageToken == "" {
+			return all, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// commentsSidecarPath 返回评论文件路径：doc.md -> doc.comments.json
+func commentsSidecarPath(output string) string {
+	return strings.TrimSuffix(output, filepath.Ext(output)) + ".comments.json"
+}
+
+// exportChunk 分块导出的一行记录
+type exportChunk struct {
+	DocumentID  string `json:"document_id"`
+	Title       string `json:"title,omitempty"`
+	DocURL      string `json:"doc_url"`
+	URL         string `json:"url"` // 跳转到片段第一个块的链接
+	UpdatedTime string `json:"updated_time,omitempty"`
+	converter.Chunk
+}
+
+// exportChunks 将文档按标题层级切分后以 JSON Lines 格式输出
+func exportChunks(ctx context.Context, documentID string, blocks []*larkdocx.Block, options converter.ConvertOptions, maxTokens int, output string) error {
+	if maxTokens <= 0 {
+		return fmt.Errorf("--max-tokens 必须大于 0")
+	}
+
+	chunks, err := converter.BuildChunks(blocks, options, maxTokens)
+	if err != nil {
+		return fmt.Errorf("切分文档失败: %w", err)
+	}
+
+	// 标题、链接和更新时间为尽力获取
+	base := exportChunk{
+		DocumentID: documentID,
+		DocURL:     fmt.Sprintf("https://feishu.cn/docx/%s", documentID),
+	}
+	if meta, err := client.GetFileMeta(ctx, documentID, "docx"); err == nil {
+		base.Title = meta.Title
+		if meta.URL != "" {
+			base.DocURL = meta.URL
+		}
+		if sec, err := strconv.ParseInt(meta.LatestModifyTime, 10, 64); err == nil {
+			base.UpdatedTime = time.Unix(sec, 0).Format(time.RFC3339)
+		}
+	}
+
+	var sb strings.Builder
+	for _, c := range chunks {
+		record := base
+		record.Chunk = c
+		record.URL = base.DocURL
+		if len(c.BlockIDs) > 0 {
+			record.URL = base.DocURL + "#" + c.BlockIDs[0]
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("序列化片段失败: %w", err)
+		}
+		sb.Write(line)
+		sb.WriteString("\n")
+	}
+
+	if output != "" {
+		if err := os.WriteFile(output, []byte(sb.String()), 0644); err != nil {
+			return fmt.Errorf("写入输出文件失败: %w", err)
+		}
+		fmt.Printf("已导出 %d 个片段到 %s\n", len(chunks), output)
+		return nil
+	}
+	fmt.Print(sb.String())
+	return nil
+}
+
+// buildExportFrontMatter 构建导出用的 front matter，字段与 doc import 读取的一致
+// 所有者和协作者为尽力获取，失败时省略对应字段
+func buildExportFrontMatter(ctx context.Context, documentID, title, wikiParent string) *converter.FrontMatter {
+	fm := &converter.FrontMatter{
+		Title:      title,
+		DocumentID: documentID,
+		WikiParent: wikiParent,
+	}
+
+	if meta, err := client.GetFileMeta(ctx, documentID, "docx"); err == nil && meta.OwnerID != "" {
+		fm.Owner = &converter.FrontMatterOwner{MemberType: "openid", MemberID: meta.OwnerID}
+	}
+
+	if members, err := client.ListPermission(ctx, documentID, "docx"); err == nil {
+		for _, m := range members {
+			memberType, memberID, perm := client.StringVal(m.MemberType), client.StringVal(m.MemberId), client.StringVal(m.Perm)
+			if memberType == "" || memberID == "" || perm == "" {
+				continue
+			}
+			fm.Permissions = append(fm.Permissions, converter.FrontMatterPermission{
+				MemberType: memberType,
+				MemberID:   memberID,
+				Perm:       perm,
+			})
+		}
+	}
+
+	return fm
+}
+
 // extractDocToken 从 URL 或直接的 token 中提取 document_id
 func extractDocToken(input string) (string, error) {
 	// 尝试匹配 docx URL
@@ -111,6 +299,10 @@ func init() {
 	exportMarkdownCmd.Flags().StringP("output", "o", "", "输出文件路径")
 	exportMarkdownCmd.Flags().Bool("download-images", false, "下载图片到本地目录")
 	exportMarkdownCmd.Flags().String("assets-dir", "./assets", "下载资源的保存目录")
-	exportMarkdownCmd.Flags().Bool("front-matter", false, "添加 YAML front matter (标题和文档 ID)")
+	exportMarkdownCmd.Flags().Bool("front-matter", false, "添加 YAML front matter (标题、文档 ID、所有者和协作者，doc import 读取后替换该文档内容)")
 	exportMarkdownCmd.Flags().Bool("highlight", false, "保留文本颜色和背景色 (输出为 HTML span)")
+	exportMarkdownCmd.Flags().String("format", "markdown", "导出格式 (markdown/chunks.jsonl)")
+	exportMarkdownCmd.Flags().Int("max-tokens", 800, "分块导出时每个片段的最大估算 token 数")
+	exportMarkdownCmd.Flags().Bool("with-comments", false, "同时导出文档评论")
+	exportMarkdownCmd.Flags().String("comment-style", "footnote", "评论输出方式 (footnote/html/sidecar)")
 }
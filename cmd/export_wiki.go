@@ -31,6 +31,7 @@ var exportWikiCmd = &cobra.Command{
   url               知识库文档 URL
   --output, -o      输出文件路径
   --download-images 下载文档中的图片
+  --front-matter    添加 YAML front matter（可被 doc import 读取）
 
 示例:
   # 导出到默认路径
@@ -50,6 +51,7 @@ var exportWikiCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		// 解析 node_token
 		nodeToken, err := extractWikiToken(args[0])
 		if err != nil {
@@ -58,7 +60,7 @@ var exportWikiCmd = &cobra.Command{
 
 		// 1. 获取节点信息
 		fmt.Printf("正在获取节点信息: %s\n", nodeToken)
-		node, err := client.GetWikiNode(nodeToken)
+		node, err := client.GetWikiNode(ctx, nodeToken)
 		if err != nil {
 			return err
 		}
@@ -74,7 +76,7 @@ var exportWikiCmd = &cobra.Command{
 
 		// 3. 获取文档块
 		fmt.Println("正在获取文档内容...")
-		blocks, err := client.GetAllBlocks(node.ObjToken)
+		blocks, err := client.GetAllBlocks(ctx, node.ObjToken)
 		if err != nil {
 			return fmt.Errorf("获取块失败: %w", err)
 		}
@@ -84,6 +86,7 @@ var exportWikiCmd = &cobra.Command{
 		assetsDir, _ := cmd.Flags().GetString("assets-dir")
 
 		options := converter.ConvertOptions{
+			Context:        ctx,
 			DocumentID:     node.ObjToken,
 			DownloadImages: downloadImages,
 			AssetsDir:      assetsDir,
@@ -94,6 +97,19 @@ var exportWikiCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("转换为 Markdown 失败: %w", err)
 		}
+		// 中断时图片下载不完整，不写入文件
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// 添加 Front Matter（wiki_parent 使用节点的父节点，便于重新导入到同一位置）
+		if frontMatter, _ := cmd.Flags().GetBool("front-matter"); frontMatter {
+			fm, err := buildExportFrontMatter(ctx, node.ObjToken, node.Title, node.ParentNodeToken).Render()
+			if err != nil {
+				return err
+			}
+			markdown = fm + markdown
+		}
 
 		// 5. 保存文件
 		outputPath, _ := cmd.Flags().GetString("output")
@@ -134,4 +150,5 @@ func init() {
 	exportWikiCmd.Flags().StringP("output", "o", "", "输出文件路径")
 	exportWikiCmd.Flags().Bool("download-images", false, "下载图片到本地目录")
 	exportWikiCmd.Flags().String("assets-dir", "./assets", "下载资源的保存目录")
+	exportWikiCmd.Flags().Bool("front-matter", false, "添加 YAML front matter (标题、文档 ID、知识库父节点、所有者和协作者)")
 }
@@ -17,6 +17,7 @@ var fileCmd = &cobra.Command{
   delete    删除文件或文件夹
   shortcut  创建文件快捷方式
   quota     查询云空间容量
+  check-links 检查文件夹中的失效链接
 
 文件类型（type）:
   doc       旧版文档
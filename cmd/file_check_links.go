@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var fileCheckLinksCmd = &cobra.Command{
+	Use:   "check-links <folder_token>",
+	Short: "检查文件夹中的失效链接",
+	Long: `递归遍历文件夹中的所有新版文档（docx），检查其中的超链接和 @文档 引用。
+
+检查规则和参数与 wiki check-links 相同:
+  @文档、飞书文档链接   通过 API 查询目标文档，已删除或无权访问时报告失效；
+                        限流、网络错误等无法确定时列入 errors，不报告为失效
+  外部链接              指定 --external 后发送 HTTP HEAD 请求检查
+
+示例:
+  feishu-cli file check-links fldcnABC123
+  feishu-cli file check-links fldcnABC123 --external --allow-host example.com
+  feishu-cli file check-links fldcnABC123 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		checker, err := newLinkChecker(cmd)
+		if err != nil {
+			return err
+		}
+
+		var sources []linkSource
+		if err := collectFolderSources(ctx, args[0], "", &sources); err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			fmt.Println("文件夹中没有新版文档")
+			return nil
+		}
+
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return printLinkReport(cmd, checker.run(ctx, sources, verbose))
+	},
+}
+
+// collectFolderSources 递归收集文件夹中的所有 docx 文档
+func collectFolderSources(ctx context.Context, folderToken, path string, sources *[]linkSource) error {
+	pageToken := ""
+	for {
+		files, nextPageToken, hasMore, err := client.ListFiles(ctx, folderToken, 200, pageToken)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			filePath := path + "/" + f.Name
+			switch f.Type {
+			case "docx":
+				*sources = append(*sources, linkSource{DocumentID: f.Token, Title: f.Name, Path: filePath})
+			case "folder":
+				if err := collectFolderSources(ctx, f.Token, filePath, sources); err != nil {
+					return err
+				}
+			}
+		}
+		if !hasMore || nextPageToken == "" {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func init() {
+	fileCmd.AddCommand(fileCheckLinksCmd)
+	addLinkCheckFlags(fileCheckLinksCmd)
+}
@@ -41,18 +41,19 @@ var forwardMessageCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		messageID := args[0]
 		receiveID, _ := cmd.Flags().GetString("receive-id")
 		receiveIDType, _ := cmd.Flags().GetString("receive-id-type")
 
-		newMessageID, err := client.ForwardMessage(messageID, receiveID, receiveIDType)
+		newMessageID, err := client.ForwardMessage(ctx, messageID, receiveID, receiveIDType)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"original_message_id": messageID,
 				"new_message_id":      newMessageID,
 			}); err != nil {
@@ -72,6 +73,6 @@ func init() {
 	msgCmd.AddCommand(forwardMessageCmd)
 	forwardMessageCmd.Flags().String("receive-id", "", "接收者 ID")
 	forwardMessageCmd.Flags().String("receive-id-type", "", "接收者 ID 类型（email/open_id/user_id/union_id/chat_id）")
-	forwardMessageCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(forwardMessageCmd)
 	mustMarkFlagRequired(forwardMessageCmd, "receive-id", "receive-id-type")
 }
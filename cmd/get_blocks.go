@@ -35,6 +35,7 @@ var getBlocksCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		raw, _ := cmd.Flags().GetBool("raw")
 		all, _ := cmd.Flags().GetBool("all")
@@ -43,7 +44,7 @@ var getBlocksCmd = &cobra.Command{
 		output, _ := cmd.Flags().GetString("output")
 
 		if raw {
-			content, err := client.GetRawContent(documentID)
+			content, err := client.GetRawContent(ctx, documentID)
 			if err != nil {
 				return err
 			}
@@ -57,14 +58,14 @@ var getBlocksCmd = &cobra.Command{
 
 		if all {
 			// Get all blocks with automatic pagination
-			allBlocks, err := client.GetAllBlocks(documentID)
+			allBlocks, err := client.GetAllBlocks(ctx, documentID)
 			if err != nil {
 				return err
 			}
 			blocks = allBlocks
 
-			if output == "json" {
-				if err := printJSON(blocks); err != nil {
+			if isStructuredOutput(output) {
+				if err := printOutput(blocks); err != nil {
 					return err
 				}
 			} else {
@@ -83,20 +84,20 @@ var getBlocksCmd = &cobra.Command{
 			}
 		} else {
 			// Get blocks with pagination
-			blockList, nextToken, err := client.ListBlocks(documentID, pageToken, pageSize)
+			blockList, nextToken, err := client.ListBlocks(ctx, documentID, pageToken, pageSize)
 			if err != nil {
 				return err
 			}
 			blocks = blockList
 			nextPageToken = nextToken
 
-			if output == "json" {
+			if isStructuredOutput(output) {
 				result := map[string]any{
 					"items":      blockList,
 					"page_token": nextPageToken,
 					"has_more":   nextPageToken != "",
 				}
-				if err := printJSON(result); err != nil {
+				if err := printOutput(result); err != nil {
 					return err
 				}
 			} else {
@@ -130,5 +131,5 @@ func init() {
 	getBlocksCmd.Flags().String("page-token", "", "分页标记")
 	getBlocksCmd.Flags().Int("document-revision-id", -1, "文档版本 ID（-1 表示最新）")
 	getBlocksCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型")
-	getBlocksCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(getBlocksCmd)
 }
@@ -36,22 +36,23 @@ var getBoardImageCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		whiteboardID := args[0]
 		outputPath := args[1]
 		output, _ := cmd.Flags().GetString("output")
 
-		err := client.GetBoardImage(whiteboardID, outputPath)
+		err := client.GetBoardImage(ctx, whiteboardID, outputPath)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]string{
 				"whiteboard_id": whiteboardID,
 				"output_path":   outputPath,
 				"status":        "success",
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -66,5 +67,5 @@ var getBoardImageCmd = &cobra.Command{
 
 func init() {
 	boardCmd.AddCommand(getBoardImageCmd)
-	getBoardImageCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(getBoardImageCmd)
 }
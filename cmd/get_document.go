@@ -22,8 +22,9 @@ var getDocumentCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		docID := args[0]
-		doc, err := client.GetDocument(docID)
+		doc, err := client.GetDocument(ctx, docID)
 		if err != nil {
 			return err
 		}
@@ -42,8 +43,8 @@ var getDocumentCmd = &cobra.Command{
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(doc); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(doc); err != nil {
 				return err
 			}
 		} else {
@@ -60,5 +61,5 @@ var getDocumentCmd = &cobra.Command{
 
 func init() {
 	docCmd.AddCommand(getDocumentCmd)
-	getDocumentCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(getDocumentCmd)
 }
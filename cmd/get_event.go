@@ -39,17 +39,18 @@ var getEventCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		calendarID := args[0]
 		eventID := args[1]
 		output, _ := cmd.Flags().GetString("output")
 
-		event, err := client.GetEvent(calendarID, eventID)
+		event, err := client.GetEvent(ctx, calendarID, eventID)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(event); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(event); err != nil {
 				return err
 			}
 		} else {
@@ -96,5 +97,5 @@ var getEventCmd = &cobra.Command{
 
 func init() {
 	calendarCmd.AddCommand(getEventCmd)
-	getEventCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(getEventCmd)
 }
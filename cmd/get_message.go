@@ -29,17 +29,18 @@ var getMessageCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		messageID := args[0]
 
-		result, err := client.GetMessage(messageID)
+		result, err := client.GetMessage(ctx, messageID)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
 		msg := result.Message
-		if output == "json" {
-			if err := printJSON(msg); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(msg); err != nil {
 				return err
 			}
 		} else {
@@ -94,5 +95,5 @@ var getMessageCmd = &cobra.Command{
 
 func init() {
 	msgCmd.AddCommand(getMessageCmd)
-	getMessageCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(getMessageCmd)
 }
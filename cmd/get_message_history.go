@@ -21,6 +21,8 @@ var getMessageHistoryCmd = &cobra.Command{
   --sort-type           排序方式 (ByCreateTimeAsc/ByCreateTimeDesc)，默认 ByCreateTimeDesc
   --page-size           分页大小 (1-50)，默认 50
   --page-token          分页标记
+  --all                 自动翻页获取全部消息，逐行输出 JSON
+  --limit               自动翻页，最多获取的消息数
   --output, -o          输出格式 (json)
 
 排序方式:
@@ -41,12 +43,17 @@ var getMessageHistoryCmd = &cobra.Command{
 
   # 分页获取
   feishu-cli msg history --container-id oc_xxx --container-id-type chat \
-    --page-size 20 --page-token xxx`,
+    --page-size 20 --page-token xxx
+
+  # 导出一年的聊天记录（自动翻页，逐行输出 JSON）
+  feishu-cli msg history --container-id oc_xxx --container-id-type chat \
+    --start-time 1704067200 --end-time 1735689600 --all > history.jsonl`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		containerIDType, _ := cmd.Flags().GetString("container-id-type")
 		containerID, _ := cmd.Flags().GetString("container-id")
 		startTime, _ := cmd.Flags().GetString("start-time")
@@ -69,13 +76,17 @@ var getMessageHistoryCmd = &cobra.Command{
 			PageToken:       pageToken,
 		}
 
-		result, err := client.ListMessages(containerID, opts)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.ListMessagesPages(containerID, opts))
+		}
+
+		result, err := client.ListMessages(ctx, containerID, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -122,6 +133,7 @@ func init() {
 	getMessageHistoryCmd.Flags().String("sort-type", "ByCreateTimeDesc", "排序方式 (ByCreateTimeAsc/ByCreateTimeDesc)")
 	getMessageHistoryCmd.Flags().Int("page-size", 50, "分页大小 (1-50)")
 	getMessageHistoryCmd.Flags().String("page-token", "", "分页标记")
-	getMessageHistoryCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addPaginationFlags(getMessageHistoryCmd)
+	addOutputFlag(getMessageHistoryCmd)
 	mustMarkFlagRequired(getMessageHistoryCmd, "container-id-type", "container-id")
 }
@@ -38,8 +38,8 @@ var getQuotaCmd = &cobra.Command{
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(quota); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(quota); err != nil {
 				return err
 			}
 		} else {
@@ -82,5 +82,5 @@ func formatBytes(bytes int64) string {
 
 func init() {
 	fileCmd.AddCommand(getQuotaCmd)
-	getQuotaCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(getQuotaCmd)
 }
@@ -29,16 +29,17 @@ var getTaskCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		taskGuid := args[0]
 
-		task, err := client.GetTask(taskGuid)
+		task, err := client.GetTask(ctx, taskGuid)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(task); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(task); err != nil {
 				return err
 			}
 		} else {
@@ -70,5 +71,5 @@ var getTaskCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(getTaskCmd)
-	getTaskCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(getTaskCmd)
 }
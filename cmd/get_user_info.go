@@ -39,6 +39,7 @@ var getUserInfoCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		userID := args[0]
 		userIDType, _ := cmd.Flags().GetString("user-id-type")
 		departmentIDType, _ := cmd.Flags().GetString("department-id-type")
@@ -49,13 +50,13 @@ var getUserInfoCmd = &cobra.Command{
 			DepartmentIDType: departmentIDType,
 		}
 
-		info, err := client.GetUserInfo(userID, opts)
+		info, err := client.GetUserInfo(ctx, userID, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -119,5 +120,5 @@ func init() {
 	userCmd.AddCommand(getUserInfoCmd)
 	getUserInfoCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型 (open_id/union_id/user_id)")
 	getUserInfoCmd.Flags().String("department-id-type", "", "部门 ID 类型 (department_id/open_department_id)")
-	getUserInfoCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(getUserInfoCmd)
 }
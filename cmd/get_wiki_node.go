@@ -45,20 +45,21 @@ URL 格式示例:
 			return err
 		}
 
+		ctx := cmd.Context()
 		// 解析 node_token（支持 URL 或直接 token）
 		nodeToken, err := extractWikiToken(args[0])
 		if err != nil {
 			return err
 		}
 
-		node, err := client.GetWikiNode(nodeToken)
+		node, err := client.GetWikiNode(ctx, nodeToken)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(node); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(node); err != nil {
 				return err
 			}
 		} else {
@@ -102,5 +103,5 @@ func extractWikiToken(input string) (string, error) {
 
 func init() {
 	wikiCmd.AddCommand(getWikiNodeCmd)
-	getWikiNodeCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(getWikiNodeCmd)
 }
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/converter"
+	"github.com/spf13/cobra"
+)
+
+var importCommentsCmd = &cobra.Command{
+	Use:   "import <findings.jsonl>",
+	Short: "批量导入审阅意见为局部评论",
+	Long: `从 JSON Lines 文件批量导入审阅意见，定位到文档中的对应文本并创建局部评论。
+
+每行一个 JSON 对象:
+  quote         引用的文本（可选，为空时引用标题本身）
+  heading_path  标题路径（可选），数组或 "一级标题 > 二级标题" 形式的字符串，
+                可以只给出末尾几级标题；指定后只在该章节内查找引用文本
+  message       评论内容（必填）
+
+处理规则:
+  - 引用文本按块查找，取范围内第一个包含该文本的块
+  - 局部评论只记录引用文本而不记录位置，引用文本在整个文档中出现多次时无法确定评论位置，
+    该意见列为 unmatched（即使指定的 heading_path 内只有一处），请改用更长的唯一片段
+  - 文档中已存在引用文本和内容都相同的评论时跳过，重复执行不会产生重复评论
+  - 无法定位的意见会在结果中列出，不影响其他意见的导入
+
+文件参数为 - 时从标准输入读取。
+
+示例:
+  feishu-cli comment import findings.jsonl --doc ABC123def456
+
+  # findings.jsonl
+  {"quote": "每秒处理 1000 次请求", "message": "请补充压测数据"}
+  {"heading_path": "设计 > 存储", "quote": "使用 Redis", "message": "需要说明持久化策略"}
+  {"heading_path": ["附录"], "message": "附录内容过时"}`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		docArg, _ := cmd.Flags().GetString("doc")
+		output, _ := cmd.Flags().GetString("output")
+		documentID, err := extractDocToken(docArg)
+		if err != nil {
+			return err
+		}
+
+		findings, err := readReviewFindings(args[0])
+		if err != nil {
+			return err
+		}
+
+		blocks, err := client.GetAllBlocks(ctx, documentID)
+		if err != nil {
+			return fmt.Errorf("获取块失败: %w", err)
+		}
+		existing, err := listAllComments(ctx, documentID, "docx")
+		if err != nil {
+			return err
+		}
+
+		results := importReviewFindings(ctx, documentID, converter.NewBlockToMarkdown(blocks, converter.ConvertOptions{}), findings, existing)
+
+		if isStructuredOutput(output) {
+			if err := printOutput(results); err != nil {
+				return err
+			}
+		} else {
+			printFindingResults(results)
+		}
+		return ctx.Err()
+	},
+}
+
+// headingPath 标题路径，JSON 中可以是字符串数组或以 ">" 分隔的字符串
+type headingPath []string
+
+func (p *headingPath) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*p = list
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("heading_path 必须是字符串或字符串数组")
+	}
+	*p = nil
+	for _, part := range strings.Split(s, ">") {
+		if part = strings.TrimSpace(part); part != "" {
+			*p = append(*p, part)
+		}
+	}
+	return nil
+}
+
+// reviewFinding 一条审阅意见
+type reviewFinding struct {
+	Line        int         `json:"-"`
+	Quote       string      `json:"quote"`
+	HeadingPath headingPath `json:"heading_path"`
+	Message     string      `json:"message"`
+}
+
+// findingResult 审阅意见的导入结果
+type findingResult struct {
+	Line      int    `json:"line"`
+	Status    string `json:"status"` // created、duplicate、unmatched 或 failed
+	CommentID string `json:"comment_id,omitempty"`
+	BlockID   string `json:"block_id,omitempty"`
+	Quote     string `json:"quote,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// readReviewFindings 读取 JSON Lines 格式的审阅意见，path 为 - 时从标准输入读取
+func readReviewFindings(path string) ([]reviewFinding, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件失败: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return parseReviewFindings(r)
+}
+
+// parseReviewFindings 解析 JSON Lines 格式的审阅意见，跳过空行
+func parseReviewFindings(r io.Reader) ([]reviewFinding, error) {
+	var findings []reviewFinding
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var f reviewFinding
+		if err := json.Unmarshal([]byte(text), &f); err != nil {
+			return nil, fmt.Errorf("第 %d 行解析失败: %w", line, err)
+		}
+		if strings.TrimSpace(f.Message) == "" {
+			return nil, fmt.Errorf("第 %d 行缺少 message", line)
+		}
+		f.Line = line
+		findings = append(findings, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	return findings, nil
+}
+
+// commentKey 用于识别重复评论：引用文本 + 评论正文（第一条回复）
+func commentKey(quote, message string) string {
+	return strings.TrimSpace(quote) + "\x00" + strings.TrimSpace(message)
+}
+
+// importReviewFindings 定位并创建评论，已存在相同评论时跳过
+func importReviewFindings(ctx context.Context, documentID string, conv *converter.BlockToMarkdown, findings []reviewFinding, existing []*client.Comment) []findingResult {
+	seen := map[string]bool{}
+	for _, c := range existing {
+		if len(c.Replies) > 0 {
+			seen[commentKey(c.Quote, c.Replies[0].Text)] = true
+		}
+	}
+
+	results := make([]findingResult, 0, len(findings))
+	for _, f := range findings {
+		if ctx.Err() != nil {
+			break
+		}
+		result := findingResult{Line: f.Line}
+		loc, err := conv.Locate(f.HeadingPath, f.Quote)
+		if err != nil {
+			result.Status, result.Reason = "unmatched", err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.BlockID, result.Quote = loc.BlockID, loc.Quote
+
+		key := commentKey(loc.Quote, f.Message)
+		if seen[key] {
+			result.Status, result.Reason = "duplicate", "已存在相同的评论"
+			results = append(results, result)
+			continue
+		}
+
+		commentID, err := client.CreatePartialComment(ctx, documentID, "docx", loc.Quote, f.Message)
+		if err != nil {
+			result.Status, result.Reason = "failed", err.Error()
+			results = append(results, result)
+			continue
+		}
+		seen[key] = true
+		result.Status, result.CommentID = "created", commentID
+		results = append(results, result)
+	}
+	return results
+}
+
+// printFindingResults 输出导入结果和汇总
+func printFindingResults(results []findingResult) {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		switch r.Status {
+		case "created":
+			fmt.Printf("[第 %d 行] 已创建评论 %s (块 %s)\n", r.Line, r.CommentID, r.BlockID)
+		case "duplicate":
+			fmt.Printf("[第 %d 行] 跳过: %s (块 %s)\n", r.Line, r.Reason, r.BlockID)
+		case "unmatched":
+			fmt.Printf("[第 %d 行] 未匹配: %s\n", r.Line, r.Reason)
+		case "failed":
+			fmt.Printf("[第 %d 行] 创建失败: %s\n", r.Line, r.Reason)
+		}
+	}
+	fmt.Printf("\n共 %d 条意见: 创建 %d, 跳过重复 %d, 未匹配 %d, 失败 %d\n",
+		len(results), counts["created"], counts["duplicate"], counts["unmatched"], counts["failed"])
+}
+
+func init() {
+	commentCmd.AddCommand(importCommentsCmd)
+	importCommentsCmd.Flags().String("doc", "", "文档 ID 或 URL（必填）")
+	addOutputFlag(importCommentsCmd)
+	mustMarkFlagRequired(importCommentsCmd, "doc")
+}
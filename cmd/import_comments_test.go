@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReviewFindings(t *testing.T) {
+	input := `{"quote": "使用 Redis", "message": "说明持久化策略"}
+
+{"heading_path": "设计 > 存储", "message": "补充容量估算"}
+{"heading_path": ["附录"], "quote": "旧接口", "message": "已废弃"}
+`
+	findings, err := parseReviewFindings(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	want := []reviewFinding{
+		{Line: 1, Quote: "使用 Redis", Message: "说明持久化策略"},
+		{Line: 3, HeadingPath: headingPath{"设计", "存储"}, Message: "补充容量估算"},
+		{Line: 4, HeadingPath: headingPath{"附录"}, Quote: "旧接口", Message: "已废弃"},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("解析结果 = %+v, 期望 %+v", findings, want)
+	}
+
+	if _, err := parseReviewFindings(strings.NewReader(`{"quote": "x"}`)); err == nil {
+		t.Error("缺少 message 时应返回错误")
+	}
+}
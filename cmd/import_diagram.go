@@ -48,6 +48,7 @@ var importDiagramCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		whiteboardID := args[0]
 		source := args[1]
 		sourceType, _ := cmd.Flags().GetString("source-type")
@@ -63,13 +64,13 @@ var importDiagramCmd = &cobra.Command{
 			Style:       style,
 		}
 
-		result, err := client.ImportDiagram(whiteboardID, source, opts)
+		result, err := client.ImportDiagram(ctx, whiteboardID, source, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"whiteboard_id": whiteboardID,
 				"ticket_id":     result.TicketID,
 				"syntax":        syntax,
@@ -97,5 +98,5 @@ func init() {
 	importDiagramCmd.Flags().String("syntax", "plantuml", "图表语法 (plantuml/mermaid)")
 	importDiagramCmd.Flags().String("diagram-type", "auto", "图表类型 (auto/mindmap/sequence/activity/class/er/flowchart/usecase/component)")
 	importDiagramCmd.Flags().String("style", "board", "样式类型 (board/classic)")
-	importDiagramCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(importDiagramCmd)
 }
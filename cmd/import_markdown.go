@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -163,43 +164,6 @@ func diagramSyntaxLabel(syntax string) string {
 	return "Mermaid"
 }
 
-// countDiagramBlocks 统计图表代码块数量（Mermaid + PlantUML）
-// 使用与 parseMarkdownSegments 相同的嵌套围栏逻辑，避免将示例代码块中的图表标记误计
-func countDiagramBlocks(markdown string) (mermaidCount, plantumlCount int) {
-	lines := strings.Split(markdown, "\n")
-	inFence := false
-	fenceBackticks := 0
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		backticks := countLeadingBackticks(line)
-
-		if inFence {
-			if backticks >= fenceBackticks && strings.TrimSpace(strings.TrimLeft(trimmed, "`")) == "" {
-				inFence = false
-				fenceBackticks = 0
-			}
-			continue
-		}
-
-		if backticks == 3 {
-			if strings.HasPrefix(trimmed, "```mermaid") {
-				mermaidCount++
-			} else if strings.HasPrefix(trimmed, "```plantuml") || strings.HasPrefix(trimmed, "```puml") {
-				plantumlCount++
-			} else if trimmed != "```" {
-				// 非图表的 3 反引号代码块
-				inFence = true
-				fenceBackticks = 3
-			}
-		} else if backticks >= 4 {
-			inFence = true
-			fenceBackticks = backticks
-		}
-	}
-	return
-}
-
 // --- 三阶段流水线数据结构 ---
 
 // diagramTask 表示一个待导入的图表任务（Mermaid 或 PlantUML）
@@ -216,7 +180,6 @@ type diagramResult struct {
 	task    diagramTask
 	success bool
 	err     error
-	retries int
 }
 
 // tableTask 表示一个待填充的表格任务
@@ -251,6 +214,7 @@ type importStats struct {
 	phase1Duration  time.Duration
 	phase2Duration  time.Duration
 	phase3Duration  time.Duration
+	interrupted     bool // 因 Ctrl+C 或 --timeout 中断，统计只包含已完成的部分
 }
 
 var importMarkdownCmd = &cobra.Command{
@@ -260,21 +224,41 @@ var importMarkdownCmd = &cobra.Command{
 
 特性:
   - 三阶段流水线: 顺序创建 → 并发处理 → 降级容错
-  - Mermaid/PlantUML 图表自动转换为飞书画板 (重试+失败降级为代码块)
+  - Mermaid/PlantUML 图表自动转换为飞书画板 (失败降级为代码块)
+  - 限流和临时错误由全局限流重试层处理（--max-retries、--qps）
   - 表格并发填充，大表格自动拆分
   - 详细进度和耗时统计
 
+Front Matter:
+  文件开头的 YAML front matter 会被解析并从正文中去除，支持以下字段
+  （命令行参数优先）:
+    title         新文档标题
+    document_id   已有文档 ID（更新该文档：先清空原有内容再导入）
+    folder        新文档所在文件夹 Token
+    wiki_parent   在该知识库节点下创建新文档
+    owner         新文档所有者（邮箱/open_id，或 {member_type, member_id}）
+    permissions   协作者列表 [{member_type, member_id, perm}]
+
+监听模式 (--watch):
+  持续监听 Markdown 文件及其引用的本地图片，保存后经过防抖自动重新发布。
+  首次同步会清空目标文档并全量导入；之后只删除并重建发生变化的顶层块，
+  文档被他人修改导致结构不一致或图片变化时自动退化为全量重建。
+  每次同步输出一行状态，按 Ctrl+C 退出。
+
 示例:
   feishu-cli doc import doc.md --title "我的文档"
   feishu-cli doc import doc.md --document-id ABC123def456
+  feishu-cli doc import doc.md --document-id ABC123def456 --replace
   feishu-cli doc import doc.md --title "我的文档" --verbose
-  feishu-cli doc import doc.md --title "测试" --diagram-workers 5 --table-workers 8`,
+  feishu-cli doc import doc.md --title "测试" --diagram-workers 5 --table-workers 8
+  feishu-cli doc import doc.md --document-id ABC123def456 --watch`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		filePath := args[0]
 		title, _ := cmd.Flags().GetString("title")
 		documentID, _ := cmd.Flags().GetString("document-id")
@@ -283,15 +267,11 @@ var importMarkdownCmd = &cobra.Command{
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		diagramWorkers, _ := cmd.Flags().GetInt("diagram-workers")
 		tableWorkers, _ := cmd.Flags().GetInt("table-workers")
-		diagramRetries, _ := cmd.Flags().GetInt("diagram-retries")
 
-		// 向后兼容: 如果用户使用了旧的 --mermaid-workers/--mermaid-retries，覆盖新值
+		// 向后兼容: 如果用户使用了旧的 --mermaid-workers，覆盖新值
 		if cmd.Flags().Changed("mermaid-workers") {
 			diagramWorkers, _ = cmd.Flags().GetInt("mermaid-workers")
 		}
-		if cmd.Flags().Changed("mermaid-retries") {
-			diagramRetries, _ = cmd.Flags().GetInt("mermaid-retries")
-		}
 
 		// 检查文件大小限制（100MB）
 		const maxFileSize = 100 * 1024 * 1024
@@ -310,176 +290,382 @@ var importMarkdownCmd = &cobra.Command{
 		}
 
 		basePath := filepath.Dir(filePath)
-		markdownText := string(content)
 
-		// 统计图表数量
-		mermaidCount, plantumlCount := countDiagramBlocks(markdownText)
-		diagramCount := mermaidCount + plantumlCount
-		if verbose && diagramCount > 0 {
-			var parts []string
-			if mermaidCount > 0 {
-				parts = append(parts, fmt.Sprintf("%d 个 Mermaid", mermaidCount))
+		// 解析 front matter，命令行参数优先于 front matter 中的同名字段
+		fm, markdownText, err := converter.ParseFrontMatter(string(content))
+		if err != nil {
+			return err
+		}
+		replace, _ := cmd.Flags().GetBool("replace")
+		documentID, replace = resolveImportTarget(documentID, replace, fm)
+		wikiParent := ""
+		if fm != nil {
+			if title == "" {
+				title = fm.Title
 			}
-			if plantumlCount > 0 {
-				parts = append(parts, fmt.Sprintf("%d 个 PlantUML", plantumlCount))
+			if folder == "" {
+				folder = fm.Folder
 			}
-			fmt.Printf("[信息] 检测到 %s 图表\n", strings.Join(parts, ", "))
+			wikiParent = fm.WikiParent
 		}
 
 		// If no document ID, create new document
+		created := false
 		if documentID == "" {
 			if title == "" {
-				// Use filename as title
-				title = filepath.Base(filePath)
-				ext := filepath.Ext(title)
-				if len(ext) < len(title) {
-					title = title[:len(title)-len(ext)]
-				}
-				if title == "" {
-					title = "无标题文档"
-				}
+				title = titleFromFileName(filePath)
 			}
 
-			doc, err := client.CreateDocument(title, folder)
+			documentID, err = createTargetDocument(ctx, title, folder, wikiParent)
 			if err != nil {
-				return fmt.Errorf("创建文档失败: %w", err)
-			}
-			if doc.DocumentId == nil {
-				return fmt.Errorf("文档已创建但未返回ID")
+				return err
 			}
-			documentID = *doc.DocumentId
-			fmt.Printf("已创建文档: %s\n", documentID)
-			fmt.Printf("链接: https://feishu.cn/docx/%s\n\n", documentID)
+			created = true
+		} else if _, err := newRevisionGuard(cmd, documentID).resolve(ctx); err != nil {
+			// 写入已有文档前校验版本，导入会改动整篇文档，因此不支持 --rebase
+			return err
 		}
 
-		// 解析 Markdown 为片段
-		segments := parseMarkdownSegments(markdownText)
+		opts := importPipelineOptions{
+			uploadImages:   uploadImages,
+			verbose:        verbose,
+			diagramWorkers: diagramWorkers,
+			tableWorkers:   tableWorkers,
+		}
 
-		stats := &importStats{
-			diagramTotal:  diagramCount,
-			mermaidCount:  mermaidCount,
-			plantumlCount: plantumlCount,
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			debounce, _ := cmd.Flags().GetDuration("debounce")
+			if debounce <= 0 {
+				return fmt.Errorf("--debounce 必须大于 0")
+			}
+			applyFrontMatterPublishing(ctx, documentID, fm, created)
+			opts.quiet = !verbose
+			// 监听模式首次同步总是清空目标文档
+			return runImportWatch(ctx, documentID, filePath, opts, debounce)
 		}
 
-		// === 阶段 1/3: 顺序创建文档块 ===
-		fmt.Println("=== 阶段 1/3: 创建文档块 ===")
-		phase1Start := time.Now()
+		if replace && !created {
+			if err := clearDocumentContent(ctx, documentID); err != nil {
+				return fmt.Errorf("清空文档原有内容失败: %w", err)
+			}
+			fmt.Printf("已清空文档原有内容: %s\n", documentID)
+		}
 
-		dTasks, tTasks, err := phase1CreateBlocks(documentID, segments, uploadImages, basePath, stats, verbose)
+		output, _ := cmd.Flags().GetString("output")
+		stats, err := runImportPipeline(ctx, documentID, markdownText, basePath, opts)
 		if err != nil {
+			if stats != nil && stats.interrupted {
+				_ = printImportResult(documentID, stats, output)
+			}
 			return err
 		}
 
+		applyFrontMatterPublishing(ctx, documentID, fm, created)
+
+		return printImportResult(documentID, stats, output)
+	},
+}
+
+// importPipelineOptions 三阶段导入流水线的参数
+type importPipelineOptions struct {
+	uploadImages   bool
+	verbose        bool
+	diagramWorkers int
+	tableWorkers   int
+	quiet          bool // 不输出各阶段的进度信息（watch 模式只输出每次同步的状态行）
+}
+
+// defaultImportPipelineOptions 返回与 doc import 默认 flag 一致的流水线参数
+func defaultImportPipelineOptions() importPipelineOptions {
+	return importPipelineOptions{
+		uploadImages:   true,
+		diagramWorkers: 5,
+		tableWorkers:   3,
+	}
+}
+
+// resolveImportTarget 确定导入的目标文档，返回文档 ID 和是否先清空原有内容
+//
+// --document-id 默认追加到文档末尾，--replace 时替换全部内容；front matter 中的 document_id
+// 表示更新该文档（如 doc export --front-matter 导出的文件），总是替换，避免再次导入时内容重复
+func resolveImportTarget(flagDocumentID string, replace bool, fm *converter.FrontMatter) (string, bool) {
+	if flagDocumentID != "" {
+		return flagDocumentID, replace
+	}
+	if fm != nil && fm.DocumentID != "" {
+		return fm.DocumentID, true
+	}
+	return "", false
+}
+
+// titleFromFileName 使用文件名（去除扩展名）作为文档标题
+func titleFromFileName(filePath string) string {
+	title := filepath.Base(filePath)
+	ext := filepath.Ext(title)
+	if len(ext) < len(title) {
+		title = title[:len(title)-len(ext)]
+	}
+	// 模板文件常见的双扩展名（如 weekly.md.tmpl）
+	if ext := filepath.Ext(title); ext == ".md" && len(ext) < len(title) {
+		title = title[:len(title)-len(ext)]
+	}
+	if title == "" {
+		title = "无标题文档"
+	}
+	return title
+}
+
+// createTargetDocument 创建导入目标文档
+// 指定 wikiParent 时在该知识库节点下创建 docx 节点，否则在 folder 中创建云文档
+func createTargetDocument(ctx context.Context, title, folder, wikiParent string) (string, error) {
+	if wikiParent != "" {
+		parent, err := client.GetWikiNode(ctx, wikiParent)
+		if err != nil {
+			return "", fmt.Errorf("获取知识库父节点失败: %w", err)
+		}
+		node, err := client.CreateWikiNode(ctx, parent.SpaceID, title, wikiParent, "docx")
+		if err != nil {
+			return "", err
+		}
+		if node.ObjToken == "" {
+			return "", fmt.Errorf("知识库节点已创建但未返回文档 ID")
+		}
+		fmt.Printf("已创建知识库文档: %s (节点: %s)\n", node.ObjToken, node.NodeToken)
+		fmt.Printf("链接: https://feishu.cn/wiki/%s\n\n", node.NodeToken)
+		return node.ObjToken, nil
+	}
+
+	doc, err := client.CreateDocument(ctx, title, folder)
+	if err != nil {
+		return "", fmt.Errorf("创建文档失败: %w", err)
+	}
+	if doc.DocumentId == nil {
+		return "", fmt.Errorf("文档已创建但未返回ID")
+	}
+	documentID := *doc.DocumentId
+	fmt.Printf("已创建文档: %s\n", documentID)
+	fmt.Printf("链接: https://feishu.cn/docx/%s\n\n", documentID)
+	return documentID, nil
+}
+
+// applyFrontMatterPublishing 应用 front matter 中的发布信息（协作者权限、所有者）
+// 所有者仅在本次新建文档时转移；单项失败只打印警告，不中断导入
+func applyFrontMatterPublishing(ctx context.Context, documentID string, fm *converter.FrontMatter, created bool) {
+	if fm == nil {
+		return
+	}
+
+	added := 0
+	for _, p := range fm.Permissions {
+		member := client.PermissionMember{
+			MemberType: p.MemberType,
+			MemberID:   p.MemberID,
+			Perm:       p.Perm,
+		}
+		if err := client.AddPermission(ctx, documentID, "docx", member, false); err != nil {
+			fmt.Printf("  ⚠ 添加权限失败 (%s %s): %v\n", p.MemberType, p.MemberID, err)
+			continue
+		}
+		added++
+	}
+	if len(fm.Permissions) > 0 {
+		fmt.Printf("已添加权限: %d/%d\n", added, len(fm.Permissions))
+	}
+
+	if fm.Owner != nil && created {
+		if err := client.TransferOwner(ctx, documentID, "docx", fm.Owner.MemberType, fm.Owner.MemberID, false); err != nil {
+			fmt.Printf("  ⚠ 转移所有者失败 (%s %s): %v\n", fm.Owner.MemberType, fm.Owner.MemberID, err)
+		} else {
+			fmt.Printf("已转移所有者: %s\n", fm.Owner.MemberID)
+		}
+	}
+
+	if len(fm.Permissions) > 0 || (fm.Owner != nil && created) {
+		fmt.Println()
+	}
+}
+
+// runImportPipeline 将 Markdown 内容通过三阶段流水线写入已存在的文档
+func runImportPipeline(ctx context.Context, documentID, markdownText, basePath string, opts importPipelineOptions) (*importStats, error) {
+	units, imageSkipped, err := buildImportUnits(documentID, parseMarkdownSegments(markdownText), opts.uploadImages, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &importStats{imageSkipped: imageSkipped}
+	if err := importUnits(ctx, documentID, units, -1, stats, opts); err != nil {
+		// 中断时返回已完成部分的统计
+		return stats, err
+	}
+	return stats, nil
+}
+
+// importUnits 将导入单元从文档根节点的 index 位置开始写入（-1 表示追加到末尾）
+// 依次执行三个阶段: 创建块、并发填充表格和导入图表、失败图表降级为代码块；
+// ctx 取消时停止后续阶段并标记 stats.interrupted
+func importUnits(ctx context.Context, documentID string, units []importUnit, index int, stats *importStats, opts importPipelineOptions) error {
+	verbose := opts.verbose
+
+	// 统计图表数量
+	for _, u := range units {
+		switch u.kind {
+		case "mermaid":
+			stats.mermaidCount++
+		case "plantuml":
+			stats.plantumlCount++
+		}
+	}
+	stats.diagramTotal = stats.mermaidCount + stats.plantumlCount
+	if verbose && stats.diagramTotal > 0 {
+		var parts []string
+		if stats.mermaidCount > 0 {
+			parts = append(parts, fmt.Sprintf("%d 个 Mermaid", stats.mermaidCount))
+		}
+		if stats.plantumlCount > 0 {
+			parts = append(parts, fmt.Sprintf("%d 个 PlantUML", stats.plantumlCount))
+		}
+		fmt.Printf("[信息] 检测到 %s 图表\n", strings.Join(parts, ", "))
+	}
+
+	// === 阶段 1/3: 顺序创建文档块 ===
+	if !opts.quiet {
+		fmt.Println("=== 阶段 1/3: 创建文档块 ===")
+	}
+	phase1Start := time.Now()
+
+	dTasks, tTasks, err := phase1CreateBlocks(ctx, documentID, units, index, stats, verbose)
+	if err != nil {
 		stats.phase1Duration = time.Since(phase1Start)
-		stats.tableTotal = len(tTasks)
+		stats.interrupted = ctx.Err() != nil
+		return err
+	}
+
+	stats.phase1Duration = time.Since(phase1Start)
+	stats.tableTotal = len(tTasks)
+	if !opts.quiet {
 		fmt.Printf("[阶段1] 完成 (%.1fs), 块: %d, 待填表格: %d, 待导入图表: %d\n\n",
 			stats.phase1Duration.Seconds(), stats.totalBlocks, len(tTasks), len(dTasks))
+	}
 
-		// === 阶段 2/3: 并发处理 ===
-		if len(dTasks) > 0 || len(tTasks) > 0 {
-			// 阶段 1 大量 API 调用后等待配额恢复，避免阶段 2 立即触发频率限制
-			if stats.totalBlocks > 30 {
-				cooldown := 5 * time.Second
-				if verbose {
-					fmt.Printf("等待 API 配额恢复 (%.0fs)...\n", cooldown.Seconds())
-				}
-				time.Sleep(cooldown)
-			}
-			fmt.Printf("=== 阶段 2/3: 并发处理 (图表×%d, 表格×%d) ===\n", diagramWorkers, tableWorkers)
-			phase2Start := time.Now()
+	// === 阶段 2/3: 并发处理 ===
+	if len(dTasks) > 0 || len(tTasks) > 0 {
+		if !opts.quiet {
+			fmt.Printf("=== 阶段 2/3: 并发处理 (图表×%d, 表格×%d) ===\n", opts.diagramWorkers, opts.tableWorkers)
+		}
+		phase2Start := time.Now()
 
-			failedDiagrams := phase2ConcurrentProcess(documentID, dTasks, tTasks, diagramWorkers, tableWorkers, diagramRetries, stats, verbose)
+		failedDiagrams := phase2ConcurrentProcess(ctx, documentID, dTasks, tTasks, opts.diagramWorkers, opts.tableWorkers, stats, verbose)
 
-			stats.phase2Duration = time.Since(phase2Start)
+		stats.phase2Duration = time.Since(phase2Start)
+		if !opts.quiet {
 			fmt.Printf("[阶段2] 完成 (%.1fs), 图表: %d/%d, 表格: %d/%d\n\n",
 				stats.phase2Duration.Seconds(),
 				stats.diagramSuccess, stats.diagramTotal,
 				stats.tableSuccess, stats.tableTotal)
+		}
 
-			// === 阶段 3/3: 降级处理 ===
-			if len(failedDiagrams) > 0 {
+		// 中断后不再降级，失败的图表保留为空画板
+		if ctx.Err() != nil {
+			stats.interrupted = true
+			return ctx.Err()
+		}
+
+		// === 阶段 3/3: 降级处理 ===
+		if len(failedDiagrams) > 0 {
+			if !opts.quiet {
 				fmt.Printf("=== 阶段 3/3: 降级处理 (%d 个) ===\n", len(failedDiagrams))
-				phase3Start := time.Now()
+			}
+			phase3Start := time.Now()
 
-				phase3HandleFallbacks(documentID, failedDiagrams, stats, verbose)
+			phase3HandleFallbacks(ctx, documentID, failedDiagrams, stats, verbose)
 
-				stats.phase3Duration = time.Since(phase3Start)
+			stats.phase3Duration = time.Since(phase3Start)
+			if !opts.quiet {
 				fmt.Printf("[阶段3] 完成 (%.1fs), 降级成功: %d/%d\n\n",
 					stats.phase3Duration.Seconds(),
 					stats.fallbackSuccess, stats.fallbackSuccess+stats.fallbackFailed)
 			}
 		}
+	}
 
-		// === 输出结果 ===
-		totalDuration := stats.phase1Duration + stats.phase2Duration + stats.phase3Duration
+	return nil
+}
 
-		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
-				"document_id":      documentID,
-				"blocks":           stats.totalBlocks,
-				"diagram_total":    stats.diagramTotal,
-				"diagram_success":  stats.diagramSuccess,
-				"diagram_failed":   stats.diagramFailed,
-				"mermaid_count":    stats.mermaidCount,
-				"plantuml_count":   stats.plantumlCount,
-				"diagram_fallback": stats.fallbackSuccess,
-				"table_total":      stats.tableTotal,
-				"table_success":    stats.tableSuccess,
-				"table_failed":     stats.tableFailed,
-				"image_skipped":    stats.imageSkipped,
-				"duration_seconds": totalDuration.Seconds(),
-				"phase1_seconds":   stats.phase1Duration.Seconds(),
-				"phase2_seconds":   stats.phase2Duration.Seconds(),
-				"phase3_seconds":   stats.phase3Duration.Seconds(),
-			}); err != nil {
-				return err
-			}
+// printImportResult 输出导入结果（文本或 JSON）
+func printImportResult(documentID string, stats *importStats, output string) error {
+	totalDuration := stats.phase1Duration + stats.phase2Duration + stats.phase3Duration
+
+	if isStructuredOutput(output) {
+		return printOutput(map[string]any{
+			"document_id":      documentID,
+			"blocks":           stats.totalBlocks,
+			"diagram_total":    stats.diagramTotal,
+			"diagram_success":  stats.diagramSuccess,
+			"diagram_failed":   stats.diagramFailed,
+			"mermaid_count":    stats.mermaidCount,
+			"plantuml_count":   stats.plantumlCount,
+			"diagram_fallback": stats.fallbackSuccess,
+			"table_total":      stats.tableTotal,
+			"table_success":    stats.tableSuccess,
+			"table_failed":     stats.tableFailed,
+			"image_skipped":    stats.imageSkipped,
+			"duration_seconds": totalDuration.Seconds(),
+			"phase1_seconds":   stats.phase1Duration.Seconds(),
+			"phase2_seconds":   stats.phase2Duration.Seconds(),
+			"phase3_seconds":   stats.phase3Duration.Seconds(),
+			"interrupted":      stats.interrupted,
+		})
+	}
+
+	if stats.interrupted {
+		fmt.Println("导入已中断，已完成的部分:")
+	} else {
+		fmt.Println("导入完成!")
+	}
+	fmt.Printf("  文档ID: %s\n", documentID)
+	fmt.Printf("  添加块数: %d\n", stats.totalBlocks)
+	if stats.imageSkipped > 0 {
+		fmt.Printf("  图片: %d 张 (已创建空占位块，飞书 API 暂不支持通过 Open API 插入图片)\n", stats.imageSkipped)
+	}
+	if stats.tableTotal > 0 {
+		fmt.Printf("  表格: %d/%d 成功\n", stats.tableSuccess, stats.tableTotal)
+	}
+	if stats.diagramTotal > 0 {
+		var diagramDetail string
+		if stats.mermaidCount > 0 && stats.plantumlCount > 0 {
+			diagramDetail = fmt.Sprintf(" (Mermaid: %d, PlantUML: %d)", stats.mermaidCount, stats.plantumlCount)
+		}
+		if stats.fallbackSuccess > 0 {
+			fmt.Printf("  图表: %d/%d 成功%s (%d 降级为代码块)\n",
+				stats.diagramSuccess, stats.diagramTotal, diagramDetail, stats.fallbackSuccess)
 		} else {
-			fmt.Println("导入完成!")
-			fmt.Printf("  文档ID: %s\n", documentID)
-			fmt.Printf("  添加块数: %d\n", stats.totalBlocks)
-			if stats.imageSkipped > 0 {
-				fmt.Printf("  图片: %d 张 (已创建空占位块，飞书 API 暂不支持通过 Open API 插入图片)\n", stats.imageSkipped)
-			}
-			if stats.tableTotal > 0 {
-				fmt.Printf("  表格: %d/%d 成功\n", stats.tableSuccess, stats.tableTotal)
-			}
-			if stats.diagramTotal > 0 {
-				var diagramDetail string
-				if stats.mermaidCount > 0 && stats.plantumlCount > 0 {
-					diagramDetail = fmt.Sprintf(" (Mermaid: %d, PlantUML: %d)", stats.mermaidCount, stats.plantumlCount)
-				}
-				if stats.fallbackSuccess > 0 {
-					fmt.Printf("  图表: %d/%d 成功%s (%d 降级为代码块)\n",
-						stats.diagramSuccess, stats.diagramTotal, diagramDetail, stats.fallbackSuccess)
-				} else {
-					fmt.Printf("  图表: %d/%d 成功%s\n", stats.diagramSuccess, stats.diagramTotal, diagramDetail)
-				}
-			}
-			fmt.Printf("  总耗时: %.1fs\n", totalDuration.Seconds())
-			fmt.Printf("  链接: https://feishu.cn/docx/%s\n", documentID)
+			fmt.Printf("  图表: %d/%d 成功%s\n", stats.diagramSuccess, stats.diagramTotal, diagramDetail)
 		}
+	}
+	fmt.Printf("  总耗时: %.1fs\n", totalDuration.Seconds())
+	fmt.Printf("  链接: https://feishu.cn/docx/%s\n", documentID)
 
-		return nil
-	},
+	return nil
 }
 
-// phase1CreateBlocks 顺序创建所有文档块，收集待处理的图表和表格任务
-func phase1CreateBlocks(
-	documentID string,
-	segments []segment,
-	uploadImages bool,
-	basePath string,
-	stats *importStats,
-	verbose bool,
-) ([]diagramTask, []tableTask, error) {
-	var dTasks []diagramTask
-	var tTasks []tableTask
-	diagramIdx := 0
+// importUnit 表示文档根节点下的一个顶层块
+// 每个 Markdown 顶层块、块级公式和图表各对应一个单元，与文档根节点的子块一一对应
+type importUnit struct {
+	kind      string               // "block"、"equation"、"mermaid" 或 "plantuml"
+	segIndex  int                  // 所属片段序号 (1-based)
+	node      *converter.BlockNode // kind 为 block 时的块及嵌套子块
+	tableData *converter.TableData // 表格块的单元格数据
+	content   string               // 公式或图表源码
+}
+
+// buildImportUnits 将 Markdown 片段转换为导入单元，返回单元列表和跳过的图片数
+func buildImportUnits(documentID string, segments []segment, uploadImages bool, basePath string) ([]importUnit, int, error) {
+	var units []importUnit
+	imageSkipped := 0
 
 	for segIdx, seg := range segments {
-		if seg.kind == "markdown" {
+		switch seg.kind {
+		case "markdown":
 			if strings.TrimSpace(seg.content) == "" {
 				continue
 			}
@@ -492,96 +678,115 @@ func phase1CreateBlocks(
 			conv := converter.NewMarkdownToBlock([]byte(seg.content), options, basePath)
 			result, err := conv.ConvertWithTableData()
 			if err != nil {
-				return nil, nil, fmt.Errorf("转换 Markdown 失败 (段落 %d): %w", segIdx+1, err)
+				return nil, 0, fmt.Errorf("转换 Markdown 失败 (段落 %d): %w", segIdx+1, err)
 			}
 
 			// 累加图片跳过统计（飞书 API 不支持通过 Open API 插入图片，仅创建空占位块）
-			stats.imageSkipped += result.ImageStats.Skipped
+			imageSkipped += result.ImageStats.Skipped
 
-			if len(result.BlockNodes) == 0 {
-				continue
+			// 表格数据按出现顺序与表格块对应
+			tableDataIdx := 0
+			for _, node := range result.BlockNodes {
+				unit := importUnit{kind: "block", segIndex: segIdx + 1, node: node}
+				if node.Block.BlockType != nil && *node.Block.BlockType == 31 && tableDataIdx < len(result.TableDatas) { // BlockTypeTable
+					unit.tableData = result.TableDatas[tableDataIdx]
+					tableDataIdx++
+				}
+				units = append(units, unit)
 			}
 
-			// 提取顶层块，记录带有嵌套子块的节点
-			var topLevelBlocks []*larkdocx.Block
-			nodeChildrenMap := map[int][]*converter.BlockNode{} // 顶层索引 → 嵌套子节点
+		case "equation", "mermaid", "plantuml":
+			units = append(units, importUnit{kind: seg.kind, segIndex: segIdx + 1, content: seg.content})
+		}
+	}
 
-			for i, node := range result.BlockNodes {
-				topLevelBlocks = append(topLevelBlocks, node.Block)
-				if len(node.Children) > 0 {
-					nodeChildrenMap[i] = node.Children
-				}
+	return units, imageSkipped, nil
+}
+
+// phase1CreateBlocks 顺序创建所有导入单元对应的文档块，收集待处理的图表和表格任务
+// index 为在文档根节点下的插入位置，-1 表示追加到末尾
+func phase1CreateBlocks(
+	ctx context.Context,
+	documentID string,
+	units []importUnit,
+	index int,
+	stats *importStats,
+	verbose bool,
+) ([]diagramTask, []tableTask, error) {
+	var dTasks []diagramTask
+	var tTasks []tableTask
+	diagramIdx := 0
+
+	// advance 在指定位置插入后移动插入点，保证后续块紧随其后
+	advance := func(n int) {
+		if index >= 0 {
+			index += n
+		}
+	}
+
+	for i := 0; i < len(units); {
+		unit := units[i]
+
+		switch unit.kind {
+		case "block":
+			// 合并连续的普通块批量添加（飞书 API 限制每次最多 50 个块）
+			const batchSize = 50
+			end := i
+			for end < len(units) && units[end].kind == "block" && end-i < batchSize {
+				end++
 			}
+			batch := units[i:end]
+			i = end
 
-			// 记录表格块的索引
-			var tableIndices []int
-			for i, block := range topLevelBlocks {
-				if block.BlockType != nil && *block.BlockType == 31 { // BlockTypeTable
-					tableIndices = append(tableIndices, i)
-				}
+			blocks := make([]*larkdocx.Block, len(batch))
+			for k, u := range batch {
+				blocks[k] = u.node.Block
 			}
 
-			// 批量添加顶层块（飞书 API 限制每次最多 50 个块）
-			const batchSize = 50
-			var createdBlockIDs []string
-			for i := 0; i < len(topLevelBlocks); i += batchSize {
-				end := i + batchSize
-				if end > len(topLevelBlocks) {
-					end = len(topLevelBlocks)
-				}
-				batch := topLevelBlocks[i:end]
+			createdBlocks, err := client.CreateBlock(ctx, documentID, documentID, blocks, index)
+			if err != nil {
+				return nil, nil, fmt.Errorf("添加内容失败 (段落 %d): %w", batch[0].segIndex, err)
+			}
+			stats.totalBlocks += len(createdBlocks)
+			advance(len(createdBlocks))
 
-				createdBlocks, err := client.CreateBlock(documentID, documentID, batch, -1)
-				if err != nil {
-					return nil, nil, fmt.Errorf("添加内容失败 (段落 %d): %w", segIdx+1, err)
+			tableCount := 0
+			for k, block := range createdBlocks {
+				if k >= len(batch) || block.BlockId == nil {
+					continue
 				}
-				stats.totalBlocks += len(createdBlocks)
+				u := batch[k]
 
-				for _, block := range createdBlocks {
-					if block.BlockId != nil {
-						createdBlockIDs = append(createdBlockIDs, *block.BlockId)
+				// 递归创建嵌套子块（如嵌套列表）
+				if len(u.node.Children) > 0 {
+					nestedCount, nestedErr := createNestedChildren(ctx, documentID, *block.BlockId, u.node.Children)
+					if nestedErr != nil && verbose {
+						syncPrintf("  ⚠ 段落 %d 嵌套子块创建失败: %v\n", u.segIndex, nestedErr)
 					}
+					stats.totalBlocks += nestedCount
 				}
-			}
 
-			// 递归创建嵌套子块（如嵌套列表）
-			for idx, children := range nodeChildrenMap {
-				if idx < len(createdBlockIDs) {
-					parentID := createdBlockIDs[idx]
-					nestedCount, nestedErr := createNestedChildren(documentID, parentID, children)
-					if nestedErr != nil {
-						if verbose {
-							syncPrintf("  ⚠ 段落 %d 嵌套子块创建失败: %v\n", segIdx+1, nestedErr)
-						}
-					}
-					stats.totalBlocks += nestedCount
+				// 收集表格任务（不立即填充）
+				if u.tableData != nil {
+					tTasks = append(tTasks, tableTask{
+						index:        len(tTasks) + 1,
+						tableBlockID: *block.BlockId,
+						tableData:    u.tableData,
+					})
+					tableCount++
 				}
 			}
 
 			if verbose {
-				fmt.Printf("  [段落 %d] 创建 %d 个块, %d 个表格\n", segIdx+1, len(createdBlockIDs), len(tableIndices))
-			}
-
-			// 收集表格任务（不立即填充）
-			tableDataIdx := 0
-			for _, tableIdx := range tableIndices {
-				if tableIdx >= len(createdBlockIDs) || tableDataIdx >= len(result.TableDatas) {
-					continue
-				}
-
-				tTasks = append(tTasks, tableTask{
-					index:        len(tTasks) + 1,
-					tableBlockID: createdBlockIDs[tableIdx],
-					tableData:    result.TableDatas[tableDataIdx],
-				})
-				tableDataIdx++
+				fmt.Printf("  [段落 %d] 创建 %d 个块, %d 个表格\n", batch[0].segIndex, len(createdBlocks), tableCount)
 			}
 
-		} else if seg.kind == "equation" {
+		case "equation":
+			i++
 			// 块级公式：飞书 API 不支持创建 Equation 块（type=16），
 			// 降级为包含行内 Equation 元素的 Text 块，保留公式语义
 			textBlockType := 2 // BlockTypeText
-			equationContent := seg.content
+			equationContent := unit.content
 			equationBlocks := []*larkdocx.Block{
 				{
 					BlockType: &textBlockType,
@@ -597,33 +802,36 @@ func phase1CreateBlocks(
 				},
 			}
 
-			createdBlocks, err := client.CreateBlock(documentID, documentID, equationBlocks, -1)
+			createdBlocks, err := client.CreateBlock(ctx, documentID, documentID, equationBlocks, index)
 			if err != nil {
 				if verbose {
 					fmt.Printf("  ⚠ 公式块创建失败: %v\n", err)
 				}
 			} else {
 				stats.totalBlocks += len(createdBlocks)
+				advance(len(createdBlocks))
 				if verbose {
 					fmt.Printf("  [公式] 创建 %d 个块（行内公式）\n", len(createdBlocks))
 				}
 			}
 
-		} else if seg.kind == "mermaid" || seg.kind == "plantuml" {
+		case "mermaid", "plantuml":
+			i++
 			diagramIdx++
-			syntaxLabel := diagramSyntaxLabel(seg.kind)
+			syntaxLabel := diagramSyntaxLabel(unit.kind)
 
 			if verbose {
 				fmt.Printf("  [%s %d] 创建画板占位块...\n", syntaxLabel, diagramIdx)
 			}
 
 			// 只创建画板占位块，不导入图表
-			boardResult, err := client.AddBoard(documentID, "", -1)
+			boardResult, err := client.AddBoard(ctx, documentID, "", index)
 			if err != nil {
 				fmt.Printf("  ✗ %s %d 创建画板失败: %v\n", syntaxLabel, diagramIdx, err)
 				stats.diagramFailed++
 				continue
 			}
+			advance(1)
 
 			if boardResult.WhiteboardID == "" {
 				fmt.Printf("  ✗ %s %d 未返回画板 ID\n", syntaxLabel, diagramIdx)
@@ -635,8 +843,8 @@ func phase1CreateBlocks(
 
 			dTasks = append(dTasks, diagramTask{
 				index:        diagramIdx,
-				content:      seg.content,
-				syntax:       seg.kind,
+				content:      unit.content,
+				syntax:       unit.kind,
 				boardBlockID: boardResult.BlockID,
 				whiteboardID: boardResult.WhiteboardID,
 			})
@@ -644,6 +852,9 @@ func phase1CreateBlocks(
 			if verbose {
 				fmt.Printf("  [%s %d] 画板已创建: %s\n", syntaxLabel, diagramIdx, boardResult.WhiteboardID)
 			}
+
+		default:
+			i++
 		}
 	}
 
@@ -652,12 +863,12 @@ func phase1CreateBlocks(
 
 // phase2ConcurrentProcess 并发处理图表导入和表格填充
 func phase2ConcurrentProcess(
+	ctx context.Context,
 	documentID string,
 	dTasks []diagramTask,
 	tTasks []tableTask,
 	diagramWorkers int,
 	tableWorkers int,
-	maxRetries int,
 	stats *importStats,
 	verbose bool,
 ) []diagramResult {
@@ -676,8 +887,12 @@ func phase2ConcurrentProcess(
 			defer wg.Done()
 			diagramSem <- struct{}{}
 			defer func() { <-diagramSem }()
+			if ctx.Err() != nil {
+				diagramResults[idx] = diagramResult{task: t, err: ctx.Err()}
+				return
+			}
 
-			result := processDiagramTask(t, maxRetries, verbose)
+			result := processDiagramTask(ctx, t, verbose)
 			diagramResults[idx] = result
 
 			stats.mu.Lock()
@@ -697,8 +912,11 @@ func phase2ConcurrentProcess(
 			defer wg.Done()
 			tableSem <- struct{}{}
 			defer func() { <-tableSem }()
+			if ctx.Err() != nil {
+				return
+			}
 
-			result := processTableTask(documentID, t, verbose)
+			result := processTableTask(ctx, documentID, t, verbose)
 
 			stats.mu.Lock()
 			if result.success {
@@ -723,8 +941,9 @@ func phase2ConcurrentProcess(
 	return failedDiagrams
 }
 
-// processDiagramTask 处理单个图表导入任务（Mermaid/PlantUML），带重试
-func processDiagramTask(task diagramTask, maxRetries int, verbose bool) diagramResult {
+// processDiagramTask 处理单个图表导入任务（Mermaid/PlantUML）
+// 限流和临时错误的重试由客户端的传输层统一处理
+func processDiagramTask(ctx context.Context, task diagramTask, verbose bool) diagramResult {
 	syntaxLabel := diagramSyntaxLabel(task.syntax)
 
 	opts := client.ImportDiagramOptions{
@@ -732,109 +951,58 @@ func processDiagramTask(task diagramTask, maxRetries int, verbose bool) diagramR
 		Syntax:     task.syntax,
 	}
 
-	var lastErr error
-	var lastRetry int
-	for retry := 0; retry <= maxRetries; retry++ {
-		lastRetry = retry
-		_, err := client.ImportDiagram(task.whiteboardID, task.content, opts)
-		if err == nil {
-			if verbose {
-				if retry > 0 {
-					syncPrintf("  ✓ %s %d 成功 (重试 %d 次)\n", syntaxLabel, task.index, retry)
-				} else {
-					syncPrintf("  ✓ %s %d 成功\n", syntaxLabel, task.index)
-				}
-			}
-			return diagramResult{task: task, success: true, retries: retry}
-		}
-
-		lastErr = err
-
-		// 判断是否值得重试
-		// 语法解析错误不可重试
+	if _, err := client.ImportDiagram(ctx, task.whiteboardID, task.content, opts); err != nil {
 		if client.IsPermanentError(err) {
-			syncPrintf("  ✗ %s %d 语法错误 (不重试): %v\n", syntaxLabel, task.index, err)
-			return diagramResult{task: task, success: false, err: err, retries: retry}
-		}
-
-		isRetryable := client.IsRetryableError(err)
-
-		if !isRetryable {
-			break
-		}
-
-		if retry < maxRetries {
-			if verbose {
-				syncPrintf("  ⚠ %s %d 重试 %d/%d (等待 1s): %v\n",
-					syntaxLabel, task.index, retry+1, maxRetries, err)
-			}
-			time.Sleep(1 * time.Second)
+			syncPrintf("  ✗ %s %d 语法错误: %v\n", syntaxLabel, task.index, err)
+		} else {
+			syncPrintf("  ✗ %s %d 失败: %v\n", syntaxLabel, task.index, err)
 		}
+		return diagramResult{task: task, success: false, err: err}
 	}
 
-	syncPrintf("  ✗ %s %d 失败 (重试%d次): %v\n", syntaxLabel, task.index, lastRetry, lastErr)
-	return diagramResult{task: task, success: false, err: lastErr, retries: lastRetry}
+	if verbose {
+		syncPrintf("  ✓ %s %d 成功\n", syntaxLabel, task.index)
+	}
+	return diagramResult{task: task, success: true}
 }
 
-// processTableTask 处理单个表格填充任务（带 429 重试）
-func processTableTask(documentID string, task tableTask, verbose bool) tableResult {
+// processTableTask 处理单个表格填充任务
+func processTableTask(ctx context.Context, documentID string, task tableTask, verbose bool) tableResult {
 	if verbose {
 		syncPrintf("  [表格 %d] 填充 %d×%d...\n", task.index, task.tableData.Rows, task.tableData.Cols)
 	}
 
-	const maxRetries = 5
-
-	for retry := 0; retry <= maxRetries; retry++ {
-		if retry > 0 {
-			delay := time.Duration(retry) * 2 * time.Second
-			if verbose {
-				syncPrintf("  ⚠ 表格 %d 重试 %d/%d (等待 %v)...\n", task.index, retry, maxRetries, delay)
-			}
-			time.Sleep(delay)
-		}
-
-		// 获取表格单元格 ID
-		cellIDs, err := client.GetTableCellIDs(documentID, task.tableBlockID)
-		if err != nil {
-			if isRateLimitError(err) && retry < maxRetries {
-				continue
-			}
-			if verbose {
-				syncPrintf("  ✗ 表格 %d 获取单元格失败: %v\n", task.index, err)
-			}
-			return tableResult{task: task, success: false, err: err}
-		}
-
-		// 填充单元格内容（优先使用富文本元素以保留链接等样式）
-		var fillErr error
-		if len(task.tableData.CellElements) > 0 {
-			fillErr = client.FillTableCellsRich(documentID, cellIDs, task.tableData.CellElements, task.tableData.CellContents)
-		} else {
-			fillErr = client.FillTableCells(documentID, cellIDs, task.tableData.CellContents)
-		}
-		if err := fillErr; err != nil {
-			if isRateLimitError(err) && retry < maxRetries {
-				continue
-			}
-			if verbose {
-				syncPrintf("  ✗ 表格 %d 填充失败: %v\n", task.index, err)
-			}
-			return tableResult{task: task, success: false, err: err}
+	// 获取表格单元格 ID
+	cellIDs, err := client.GetTableCellIDs(ctx, documentID, task.tableBlockID)
+	if err != nil {
+		if verbose {
+			syncPrintf("  ✗ 表格 %d 获取单元格失败: %v\n", task.index, err)
 		}
+		return tableResult{task: task, success: false, err: err}
+	}
 
+	// 填充单元格内容（优先使用富文本元素以保留链接等样式）
+	if len(task.tableData.CellElements) > 0 {
+		err = client.FillTableCellsRich(ctx, documentID, cellIDs, task.tableData.CellElements, task.tableData.CellContents)
+	} else {
+		err = client.FillTableCells(ctx, documentID, cellIDs, task.tableData.CellContents)
+	}
+	if err != nil {
 		if verbose {
-			syncPrintf("  ✓ 表格 %d 成功\n", task.index)
+			syncPrintf("  ✗ 表格 %d 填充失败: %v\n", task.index, err)
 		}
-		return tableResult{task: task, success: true}
+		return tableResult{task: task, success: false, err: err}
 	}
 
-	// 不应到达这里
-	return tableResult{task: task, success: false, err: fmt.Errorf("重试耗尽")}
+	if verbose {
+		syncPrintf("  ✓ 表格 %d 成功\n", task.index)
+	}
+	return tableResult{task: task, success: true}
 }
 
 // createNestedChildren 递归创建嵌套子块（如嵌套列表的父子关系）
 // 返回创建的块总数和可能的错误
-func createNestedChildren(documentID string, parentBlockID string, children []*converter.BlockNode) (int, error) {
+func createNestedChildren(ctx context.Context, documentID string, parentBlockID string, children []*converter.BlockNode) (int, error) {
 	if len(children) == 0 {
 		return 0, nil
 	}
@@ -855,7 +1023,7 @@ func createNestedChildren(documentID string, parentBlockID string, children []*c
 		}
 		batch := childBlocks[i:end]
 
-		createdBlocks, err := client.CreateBlock(documentID, parentBlockID, batch, -1)
+		createdBlocks, err := client.CreateBlock(ctx, documentID, parentBlockID, batch, -1)
 		if err != nil {
 			return totalCreated, fmt.Errorf("创建嵌套子块失败 (parent=%s): %w", parentBlockID, err)
 		}
@@ -871,7 +1039,7 @@ func createNestedChildren(documentID string, parentBlockID string, children []*c
 	// 递归创建更深层的子块
 	for i, child := range children {
 		if len(child.Children) > 0 && i < len(createdBlockIDs) {
-			nestedCount, err := createNestedChildren(documentID, createdBlockIDs[i], child.Children)
+			nestedCount, err := createNestedChildren(ctx, documentID, createdBlockIDs[i], child.Children)
 			totalCreated += nestedCount
 			if err != nil {
 				return totalCreated, err
@@ -882,20 +1050,16 @@ func createNestedChildren(documentID string, parentBlockID string, children []*c
 	return totalCreated, nil
 }
 
-// isRateLimitError 判断是否为频率限制错误（委托给 client 包统一实现）
-func isRateLimitError(err error) bool {
-	return client.IsRateLimitError(err)
-}
-
 // phase3HandleFallbacks 处理失败的图表，降级为代码块
 func phase3HandleFallbacks(
+	ctx context.Context,
 	documentID string,
 	failedDiagrams []diagramResult,
 	stats *importStats,
 	verbose bool,
 ) {
 	// 获取文档顶层子块列表
-	children, err := client.GetAllBlockChildren(documentID, documentID)
+	children, err := client.GetAllBlockChildren(ctx, documentID, documentID)
 	if err != nil {
 		fmt.Printf("  ✗ 获取文档子块失败，无法降级: %v\n", err)
 		stats.fallbackFailed += len(failedDiagrams)
@@ -939,7 +1103,7 @@ func phase3HandleFallbacks(
 		}
 
 		// 1. 删除空画板块
-		err := client.DeleteBlocks(documentID, documentID, item.index, item.index+1)
+		err := client.DeleteBlocks(ctx, documentID, documentID, item.index, item.index+1)
 		if err != nil {
 			fmt.Printf("  ✗ %s %d 删除画板失败: %v\n", syntaxLabel, item.result.task.index, err)
 			stats.fallbackFailed++
@@ -948,7 +1112,7 @@ func phase3HandleFallbacks(
 
 		// 2. 在同位置插入代码块
 		codeBlock := createDiagramCodeBlock(item.result.task.syntax, item.result.task.content)
-		_, err = client.CreateBlock(documentID, documentID, []*larkdocx.Block{codeBlock}, item.index)
+		_, err = client.CreateBlock(ctx, documentID, documentID, []*larkdocx.Block{codeBlock}, item.index)
 		if err != nil {
 			fmt.Printf("  ✗ %s %d 插入代码块失败: %v\n", syntaxLabel, item.result.task.index, err)
 			stats.fallbackFailed++
@@ -990,16 +1154,22 @@ func init() {
 	docCmd.AddCommand(importMarkdownCmd)
 	importMarkdownCmd.Flags().StringP("title", "t", "", "文档标题 (用于新建文档)")
 	importMarkdownCmd.Flags().StringP("document-id", "d", "", "已有文档ID (用于更新)")
+	importMarkdownCmd.Flags().Bool("replace", false, "导入前清空 --document-id 指定文档的原有内容（默认追加）")
 	importMarkdownCmd.Flags().Bool("upload-images", true, "上传本地图片")
 	importMarkdownCmd.Flags().StringP("folder", "f", "", "新文档的文件夹 Token")
-	importMarkdownCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addOutputFlag(importMarkdownCmd)
 	importMarkdownCmd.Flags().BoolP("verbose", "v", false, "显示详细进度")
 	importMarkdownCmd.Flags().Int("diagram-workers", 5, "图表 (Mermaid/PlantUML) 并发导入数")
 	importMarkdownCmd.Flags().Int("table-workers", 3, "表格并发填充数")
-	importMarkdownCmd.Flags().Int("diagram-retries", 10, "图表最大重试次数")
+	importMarkdownCmd.Flags().Int("diagram-retries", 10, "图表最大重试次数（已废弃，使用全局 --max-retries）")
+	importMarkdownCmd.Flags().Bool("watch", false, "监听文件变化并自动重新发布")
+	importMarkdownCmd.Flags().Duration("debounce", 500*time.Millisecond, "监听模式下的防抖间隔")
+	importMarkdownCmd.Flags().Int("expect-revision", 0, "期望的文档版本号，已有文档被修改时拒绝导入 (0 表示不检查)")
 	// 向后兼容别名
 	importMarkdownCmd.Flags().Int("mermaid-workers", 5, "图表并发导入数 (--diagram-workers 别名)")
 	importMarkdownCmd.Flags().Int("mermaid-retries", 10, "图表最大重试次数 (--diagram-retries 别名)")
 	_ = importMarkdownCmd.Flags().MarkHidden("mermaid-workers")
 	_ = importMarkdownCmd.Flags().MarkHidden("mermaid-retries")
+	_ = importMarkdownCmd.Flags().MarkDeprecated("diagram-retries", "重试已统一由全局 --max-retries 控制")
+	_ = importMarkdownCmd.Flags().MarkDeprecated("mermaid-retries", "重试已统一由全局 --max-retries 控制")
 }
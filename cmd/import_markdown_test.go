@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+func TestResolveImportTarget_ExportRoundTrip(t *testing.T) {
+	// doc export --front-matter 写出的文件再次导入时应替换源文档内容，而不是追加
+	fm := &converter.FrontMatter{Title: "设计文档", DocumentID: "doxcnSource"}
+	header, err := fm.Render()
+	if err != nil {
+		t.Fatalf("Render 返回错误: %v", err)
+	}
+	parsed, body, err := converter.ParseFrontMatter(header + "# 设计文档\n\n正文\n")
+	if err != nil {
+		t.Fatalf("ParseFrontMatter 返回错误: %v", err)
+	}
+	if body != "# 设计文档\n\n正文\n" {
+		t.Errorf("正文 = %q", body)
+	}
+
+	tests := []struct {
+		name        string
+		flagID      string
+		replace     bool
+		fm          *converter.FrontMatter
+		wantID      string
+		wantReplace bool
+	}{
+		{"front matter 中的 document_id 替换内容", "", false, parsed, "doxcnSource", true},
+		{"--document-id 默认追加", "doxcnFlag", false, parsed, "doxcnFlag", false},
+		{"--document-id 配合 --replace", "doxcnFlag", true, nil, "doxcnFlag", true},
+		{"没有目标文档时新建", "", false, &converter.FrontMatter{Title: "t"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, replace := resolveImportTarget(tt.flagID, tt.replace, tt.fm)
+			if id != tt.wantID || replace != tt.wantReplace {
+				t.Errorf("resolveImportTarget() = (%q, %v), 期望 (%q, %v)", id, replace, tt.wantID, tt.wantReplace)
+			}
+		})
+	}
+}
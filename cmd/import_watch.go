@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/converter"
+)
+
+// localImagePattern 匹配 Markdown 图片语法中的路径
+var localImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?`)
+
+// watchSession 维护 --watch 模式下本地文件与飞书文档的同步状态
+type watchSession struct {
+	documentID string
+	filePath   string
+	opts       importPipelineOptions
+
+	unitHashes  []string          // 上次同步后文档根节点各子块对应的导入单元指纹
+	imageHashes map[string]string // 上次同步时引用的本地图片内容指纹
+	synced      bool              // 是否已完成首次同步
+}
+
+// watchSyncResult 单次同步的结果，用于输出状态行
+type watchSyncResult struct {
+	mode    string // "full"、"incremental" 或 "unchanged"
+	deleted int
+	added   int
+	stats   *importStats
+}
+
+// runImportWatch 监听 Markdown 文件及其引用的本地图片，变化后经过防抖重新同步到文档
+// 首次同步会清空文档后全量导入，之后尽量只替换发生变化的顶层块
+func runImportWatch(ctx context.Context, documentID, filePath string, opts importPipelineOptions, debounce time.Duration) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("解析文件路径失败: %w", err)
+	}
+
+	session := &watchSession{
+		documentID: documentID,
+		filePath:   absPath,
+		opts:       opts,
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建文件监听失败: %w", err)
+	}
+	defer watcher.Close()
+
+	// 监听所在目录而不是文件本身，兼容编辑器"写临时文件再重命名"的保存方式
+	watchedDirs := map[string]bool{}
+	watchedFiles := map[string]bool{}
+	refreshWatches := func() {
+		files := append([]string{absPath}, session.imagePaths()...)
+		watchedFiles = map[string]bool{}
+		for _, f := range files {
+			watchedFiles[f] = true
+			dir := filepath.Dir(f)
+			if watchedDirs[dir] {
+				continue
+			}
+			if err := watcher.Add(dir); err != nil {
+				fmt.Printf("  ⚠ 监听目录失败 %s: %v\n", dir, err)
+				continue
+			}
+			watchedDirs[dir] = true
+		}
+	}
+
+	session.syncAndReport(ctx)
+	refreshWatches()
+
+	fmt.Printf("正在监听 %s 的变化 (防抖 %s)，按 Ctrl+C 退出\n", filePath, debounce)
+	fmt.Printf("链接: https://feishu.cn/docx/%s\n", documentID)
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watchedFiles[filepath.Clean(event.Name)] || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(debounce)
+			}
+			timerC = timer.C
+
+		case <-timerC:
+			timerC = nil
+			session.syncAndReport(ctx)
+			refreshWatches()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("  ⚠ 文件监听错误: %v\n", err)
+
+		case <-ctx.Done():
+			// Ctrl+C 是监听模式的正常退出方式；--timeout 到期时返回错误
+			fmt.Println("\n已停止监听")
+			if errors.Is(context.Cause(ctx), errCommandTimeout) {
+				return context.Cause(ctx)
+			}
+			return nil
+		}
+	}
+}
+
+// syncAndReport 执行一次同步并输出一行状态
+func (s *watchSession) syncAndReport(ctx context.Context) {
+	start := time.Now()
+	result, err := s.sync(ctx)
+	stamp := start.Format("15:04:05")
+	elapsed := time.Since(start).Seconds()
+
+	if err != nil {
+		fmt.Printf("[%s] ✗ 同步失败: %v\n", stamp, err)
+		return
+	}
+
+	switch result.mode {
+	case "unchanged":
+		fmt.Printf("[%s] 无变化\n", stamp)
+	case "full":
+		fmt.Printf("[%s] 已同步: 全量重建 (%d 块%s), 耗时 %.1fs\n", stamp, result.stats.totalBlocks, watchStatsSuffix(result.stats), elapsed)
+	default:
+		fmt.Printf("[%s] 已同步: 增量更新 (删除 %d 块, 新增 %d 块%s), 耗时 %.1fs\n", stamp, result.deleted, result.added, watchStatsSuffix(result.stats), elapsed)
+	}
+}
+
+// watchStatsSuffix 在状态行中附加失败的图表和表格数量
+func watchStatsSuffix(stats *importStats) string {
+	if stats == nil {
+		return ""
+	}
+	var parts []string
+	if stats.diagramFailed > 0 {
+		parts = append(parts, fmt.Sprintf("图表失败 %d", stats.diagramFailed))
+	}
+	if stats.tableFailed > 0 {
+		parts = append(parts, fmt.Sprintf("表格失败 %d", stats.tableFailed))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(parts, ", ")
+}
+
+// sync 读取本地文件并同步到文档
+// 通过比较导入单元指纹找出首尾未变化的部分，只删除并重建中间变化的块；
+// 文档结构与上次同步不一致（如被他人编辑）或引用的图片发生变化时退化为全量重建
+func (s *watchSession) sync(ctx context.Context) (*watchSyncResult, error) {
+	content, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	_, body, err := converter.ParseFrontMatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := filepath.Dir(s.filePath)
+	units, _, err := buildImportUnits(s.documentID, parseMarkdownSegments(body), s.opts.uploadImages, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes, err := importUnitHashes(units)
+	if err != nil {
+		return nil, err
+	}
+	imageHashes := hashLocalImages(body, basePath)
+
+	if !s.synced || !sameStringMap(imageHashes, s.imageHashes) {
+		return s.fullSync(ctx, units, hashes, imageHashes)
+	}
+
+	prefix, suffix := diffUnitRange(s.unitHashes, hashes)
+	if prefix == len(s.unitHashes) && prefix == len(hashes) {
+		return &watchSyncResult{mode: "unchanged"}, nil
+	}
+
+	children, err := client.GetAllBlockChildren(ctx, s.documentID, s.documentID)
+	if err != nil {
+		return nil, fmt.Errorf("获取文档子块失败: %w", err)
+	}
+	if len(children) != len(s.unitHashes) {
+		return s.fullSync(ctx, units, hashes, imageHashes)
+	}
+
+	// 同步中途失败时文档状态未知，下次强制全量重建
+	s.synced = false
+
+	deleteEnd := len(s.unitHashes) - suffix
+	if deleteEnd > prefix {
+		if err := client.DeleteBlocks(ctx, s.documentID, s.documentID, prefix, deleteEnd); err != nil {
+			return nil, fmt.Errorf("删除变化的块失败: %w", err)
+		}
+	}
+
+	added := units[prefix : len(units)-suffix]
+	stats := &importStats{}
+	if len(added) > 0 {
+		if err := importUnits(ctx, s.documentID, added, prefix, stats, s.opts); err != nil {
+			return nil, err
+		}
+	}
+
+	s.unitHashes = hashes
+	s.imageHashes = imageHashes
+	s.synced = true
+	return &watchSyncResult{
+		mode:    "incremental",
+		deleted: deleteEnd - prefix,
+		added:   len(added),
+		stats:   stats,
+	}, nil
+}
+
+// fullSync 清空文档后重新导入全部内容
+func (s *watchSession) fullSync(ctx context.Context, units []importUnit, hashes []string, imageHashes map[string]string) (*watchSyncResult, error) {
+	s.synced = false
+	if err := clearDocumentContent(ctx, s.documentID); err != nil {
+		return nil, fmt.Errorf("清空文档失败: %w", err)
+	}
+
+	stats := &importStats{}
+	if err := importUnits(ctx, s.documentID, units, -1, stats, s.opts); err != nil {
+		return nil, err
+	}
+
+	s.unitHashes = hashes
+	s.imageHashes = imageHashes
+	s.synced = true
+	return &watchSyncResult{mode: "full", added: len(units), stats: stats}, nil
+}
+
+// imagePaths 返回上次同步时引用的本地图片路径
+func (s *watchSession) imagePaths() []string {
+	paths := make([]string, 0, len(s.imageHashes))
+	for p := range s.imageHashes {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// importUnitHashes 计算每个导入单元的内容指纹
+func importUnitHashes(units []importUnit) ([]string, error) {
+	hashes := make([]string, len(units))
+	for i, u := range units {
+		data, err := json.Marshal(struct {
+			Kind      string
+			Node      *converter.BlockNode
+			TableData *converter.TableData
+			Content   string
+		}{u.kind, u.node, u.tableData, u.content})
+		if err != nil {
+			return nil, fmt.Errorf("计算块指纹失败: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		hashes[i] = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
+// diffUnitRange 返回新旧指纹序列的公共前缀长度和公共后缀长度（两者不重叠）
+func diffUnitRange(prev, next []string) (prefix, suffix int) {
+	for prefix < len(prev) && prefix < len(next) && prev[prefix] == next[prefix] {
+		prefix++
+	}
+	for suffix < len(prev)-prefix && suffix < len(next)-prefix &&
+		prev[len(prev)-1-suffix] == next[len(next)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// hashLocalImages 计算 Markdown 中引用的本地图片的内容指纹，键为绝对路径
+// 不存在的图片记为空指纹，以便图片创建后能触发同步
+func hashLocalImages(markdown, basePath string) map[string]string {
+	result := map[string]string{}
+	for _, m := range localImagePattern.FindAllStringSubmatch(markdown, -1) {
+		ref := m[1]
+		if strings.Contains(ref, "://") || strings.HasPrefix(ref, "data:") {
+			continue
+		}
+		path := ref
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(basePath, path)
+		}
+		path = filepath.Clean(path)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			result[path] = ""
+			continue
+		}
+		sum := sha256.Sum256(data)
+		result[path] = hex.EncodeToString(sum[:])
+	}
+	return result
+}
+
+// sameStringMap 判断两个字符串映射是否完全相同
+func sameStringMap(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffUnitRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next []string
+		prefix     int
+		suffix     int
+	}{
+		{"无变化", []string{"a", "b", "c"}, []string{"a", "b", "c"}, 3, 0},
+		{"修改中间", []string{"a", "b", "c"}, []string{"a", "x", "c"}, 1, 1},
+		{"末尾追加", []string{"a", "b"}, []string{"a", "b", "c"}, 2, 0},
+		{"开头插入", []string{"a", "b"}, []string{"x", "a", "b"}, 0, 2},
+		{"删除中间", []string{"a", "b", "c"}, []string{"a", "c"}, 1, 1},
+		{"重复块不重叠", []string{"a", "a"}, []string{"a", "a", "a"}, 2, 0},
+		{"首次同步", nil, []string{"a"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, suffix := diffUnitRange(tt.prev, tt.next)
+			if prefix != tt.prefix || suffix != tt.suffix {
+				t.Errorf("diffUnitRange() = (%d, %d), 期望 (%d, %d)", prefix, suffix, tt.prefix, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestHashLocalImages(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.png"), []byte("png"), 0600)
+
+	md := "![a](a.png)\n![远程](https://example.com/b.png)\n![缺失](img/missing.png)"
+	hashes := hashLocalImages(md, dir)
+
+	if len(hashes) != 2 {
+		t.Fatalf("hashLocalImages() 返回 %d 个图片, 期望 2 个（忽略远程图片）: %v", len(hashes), hashes)
+	}
+	if hashes[filepath.Join(dir, "a.png")] == "" {
+		t.Error("已存在的图片应有内容指纹")
+	}
+	if h, ok := hashes[filepath.Join(dir, "img", "missing.png")]; !ok || h != "" {
+		t.Error("不存在的图片应记录为空指纹")
+	}
+}
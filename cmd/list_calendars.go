@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -36,22 +37,32 @@ var listCalendarsCmd = &cobra.Command{
   feishu-cli calendar list --output json
 
   # 指定每页数量
-  feishu-cli calendar list --page-size 20`,
+  feishu-cli calendar list --page-size 20
+
+  # 获取全部日历（自动翻页，逐行输出 JSON）
+  feishu-cli calendar list --all`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		pageSize, _ := cmd.Flags().GetInt("page-size")
 		pageToken, _ := cmd.Flags().GetString("page-token")
 		output, _ := cmd.Flags().GetString("output")
 
-		calendars, nextToken, hasMore, err := client.ListCalendars(pageSize, pageToken)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.Calendar, string, bool, error) {
+				return client.ListCalendars(ctx, pageSize, pageToken)
+			})
+		}
+
+		calendars, nextToken, hasMore, err := client.ListCalendars(ctx, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]any{
 				"calendars": calendars,
 				"has_more":  hasMore,
@@ -59,7 +70,7 @@ var listCalendarsCmd = &cobra.Command{
 			if nextToken != "" {
 				result["page_token"] = nextToken
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -104,5 +115,6 @@ func init() {
 	calendarCmd.AddCommand(listCalendarsCmd)
 	listCalendarsCmd.Flags().Int("page-size", 50, "每页数量")
 	listCalendarsCmd.Flags().String("page-token", "", "分页标记")
-	listCalendarsCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addPaginationFlags(listCalendarsCmd)
+	addOutputFlag(listCalendarsCmd)
 }
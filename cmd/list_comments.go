@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,7 +13,8 @@ import (
 var listCommentsCmd = &cobra.Command{
 	Use:   "list <file_token>",
 	Short: "列出文档评论",
-	Long: `列出指定文档的所有评论。
+	Long: `列出指定文档的所有评论，包含局部评论的引用文本和完整的回复链（作者、时间、内容）。
+开放平台的评论接口不返回表情回应，因此不包含表情回应数量。
 
 参数:
   file_token    文档 Token
@@ -23,25 +25,36 @@ var listCommentsCmd = &cobra.Command{
   feishu-cli comment list doccnXXX --type docx
 
   # JSON 格式输出
-  feishu-cli comment list doccnXXX --type docx --output json`,
+  feishu-cli comment list doccnXXX --type docx --output json
+
+  # 获取全部评论（自动翻页，逐行输出 JSON）
+  feishu-cli comment list doccnXXX --type docx --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		fileType, _ := cmd.Flags().GetString("type")
 		pageSize, _ := cmd.Flags().GetInt("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		output, _ := cmd.Flags().GetString("output")
 
-		comments, _, _, err := client.ListComments(fileToken, fileType, pageSize, "")
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.Comment, string, bool, error) {
+				return client.ListComments(ctx, fileToken, fileType, pageSize, pageToken)
+			})
+		}
+
+		comments, nextToken, hasMore, err := client.ListComments(ctx, fileToken, fileType, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(comments); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(comments); err != nil {
 				return err
 			}
 		} else {
@@ -67,18 +80,42 @@ var listCommentsCmd = &cobra.Command{
 					t := time.Unix(int64(c.CreateTime), 0)
 					fmt.Printf("    创建时间: %s\n", t.Format("2006-01-02 15:04:05"))
 				}
+				if c.Quote != "" {
+					fmt.Printf("    引用:     %s\n", c.Quote)
+				}
+				printCommentReplies(c.Replies)
 				fmt.Println()
 			}
+			if hasMore {
+				fmt.Printf("还有更多评论，使用 --page-token %s 获取下一页，或使用 --all 获取全部\n", nextToken)
+			}
 		}
 
 		return nil
 	},
 }
 
+// printCommentReplies 输出评论的回复链
+func printCommentReplies(replies []*client.CommentReply) {
+	if len(replies) == 0 {
+		return
+	}
+	fmt.Printf("    回复 (%d):\n", len(replies))
+	for _, r := range replies {
+		created := ""
+		if r.CreateTime > 0 {
+			created = time.Unix(int64(r.CreateTime), 0).Format("2006-01-02 15:04")
+		}
+		fmt.Printf("      - [%s] %s %s: %s\n", r.ReplyID, created, r.UserID, r.Text)
+	}
+}
+
 func init() {
 	commentCmd.AddCommand(listCommentsCmd)
 	listCommentsCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
 	listCommentsCmd.Flags().Int("page-size", 50, "每页数量")
-	listCommentsCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	listCommentsCmd.Flags().String("page-token", "", "分页标记")
+	addPaginationFlags(listCommentsCmd)
+	addOutputFlag(listCommentsCmd)
 	mustMarkFlagRequired(listCommentsCmd, "type")
 }
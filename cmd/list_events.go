@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -41,13 +42,17 @@ var listEventsCmd = &cobra.Command{
   feishu-cli calendar list-events CAL_ID --output json
 
   # 指定每页数量
-  feishu-cli calendar list-events CAL_ID --page-size 20`,
+  feishu-cli calendar list-events CAL_ID --page-size 20
+
+  # 获取时间范围内的全部日程（自动翻页，逐行输出 JSON）
+  feishu-cli calendar list-events CAL_ID --start-time 2024-01-01T00:00:00+08:00 --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		calendarID := args[0]
 		startTime, _ := cmd.Flags().GetString("start-time")
 		endTime, _ := cmd.Flags().GetString("end-time")
@@ -63,12 +68,20 @@ var listEventsCmd = &cobra.Command{
 			PageToken:  pageToken,
 		}
 
-		events, nextToken, hasMore, err := client.ListEvents(params)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.CalendarEvent, string, bool, error) {
+				page := *params
+				page.PageToken = pageToken
+				return client.ListEvents(ctx, &page)
+			})
+		}
+
+		events, nextToken, hasMore, err := client.ListEvents(ctx, params)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
+		if isStructuredOutput(output) {
 			result := map[string]any{
 				"events":   events,
 				"has_more": hasMore,
@@ -76,7 +89,7 @@ var listEventsCmd = &cobra.Command{
 			if nextToken != "" {
 				result["page_token"] = nextToken
 			}
-			if err := printJSON(result); err != nil {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -115,5 +128,6 @@ func init() {
 	listEventsCmd.Flags().String("end-time", "", "结束时间过滤，RFC3339 格式")
 	listEventsCmd.Flags().Int("page-size", 50, "每页数量")
 	listEventsCmd.Flags().String("page-token", "", "分页标记")
-	listEventsCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addPaginationFlags(listEventsCmd)
+	addOutputFlag(listEventsCmd)
 }
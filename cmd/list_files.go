@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -24,28 +25,39 @@ var listFilesCmd = &cobra.Command{
   feishu-cli file list fldcnXXXXXXXXX
 
   # JSON 格式输出
-  feishu-cli file list --output json`,
+  feishu-cli file list --output json
+
+  # 获取全部文件（自动翻页，逐行输出 JSON）
+  feishu-cli file list fldcnXXXXXXXXX --all`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		var folderToken string
 		if len(args) > 0 {
 			folderToken = args[0]
 		}
 
 		pageSize, _ := cmd.Flags().GetInt("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		output, _ := cmd.Flags().GetString("output")
 
-		files, _, _, err := client.ListFiles(folderToken, pageSize, "")
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.DriveFile, string, bool, error) {
+				return client.ListFiles(ctx, folderToken, pageSize, pageToken)
+			})
+		}
+
+		files, nextToken, hasMore, err := client.ListFiles(ctx, folderToken, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(files); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(files); err != nil {
 				return err
 			}
 		} else {
@@ -68,6 +80,9 @@ var listFilesCmd = &cobra.Command{
 				}
 				fmt.Println()
 			}
+			if hasMore {
+				fmt.Printf("还有更多文件，使用 --page-token %s 获取下一页，或使用 --all 获取全部\n", nextToken)
+			}
 		}
 
 		return nil
@@ -98,5 +113,7 @@ func getFileTypeIcon(fileType string) string {
 func init() {
 	fileCmd.AddCommand(listFilesCmd)
 	listFilesCmd.Flags().Int("page-size", 50, "每页数量")
-	listFilesCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	listFilesCmd.Flags().String("page-token", "", "分页标记")
+	addPaginationFlags(listFilesCmd)
+	addOutputFlag(listFilesCmd)
 }
@@ -21,6 +21,8 @@ var listMessagesCmd = &cobra.Command{
   --sort-type          排序方式（ByCreateTimeAsc/ByCreateTimeDesc）
   --page-size          每页数量（默认: 20，最大: 50）
   --page-token         分页标记
+  --all                自动翻页获取全部消息，逐行输出 JSON
+  --limit              自动翻页，最多获取的消息数
   --output, -o         输出格式（json）
 
 示例:
@@ -34,12 +36,16 @@ var listMessagesCmd = &cobra.Command{
   feishu-cli msg list --container-id oc_xxx --page-size 10
 
   # JSON 格式输出
-  feishu-cli msg list --container-id oc_xxx --output json`,
+  feishu-cli msg list --container-id oc_xxx --output json
+
+  # 获取最近 1000 条消息（自动翻页，逐行输出 JSON）
+  feishu-cli msg list --container-id oc_xxx --sort-type ByCreateTimeDesc --limit 1000`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		containerID, _ := cmd.Flags().GetString("container-id")
 		containerIDType, _ := cmd.Flags().GetString("container-id-type")
 		startTime, _ := cmd.Flags().GetString("start-time")
@@ -57,14 +63,18 @@ var listMessagesCmd = &cobra.Command{
 			PageToken:       pageToken,
 		}
 
-		result, err := client.ListMessages(containerID, opts)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.ListMessagesPages(containerID, opts))
+		}
+
+		result, err := client.ListMessages(ctx, containerID, opts)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"items":      result.Items,
 				"page_token": result.PageToken,
 				"has_more":   result.HasMore,
@@ -113,6 +123,7 @@ func init() {
 	listMessagesCmd.Flags().String("sort-type", "", "排序方式（ByCreateTimeAsc/ByCreateTimeDesc）")
 	listMessagesCmd.Flags().Int("page-size", 20, "每页数量（最大 50）")
 	listMessagesCmd.Flags().String("page-token", "", "分页标记")
-	listMessagesCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addPaginationFlags(listMessagesCmd)
+	addOutputFlag(listMessagesCmd)
 	mustMarkFlagRequired(listMessagesCmd, "container-id")
 }
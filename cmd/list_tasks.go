@@ -18,6 +18,8 @@ var listTasksCmd = &cobra.Command{
   --page-token      分页标记
   --completed       只显示已完成的任务
   --uncompleted     只显示未完成的任务
+  --all             自动翻页获取全部任务，逐行输出 JSON
+  --limit           自动翻页，最多获取的任务数
   --output, -o      输出格式（json）
 
 示例:
@@ -34,12 +36,16 @@ var listTasksCmd = &cobra.Command{
   feishu-cli task list --page-size 10
 
   # JSON 格式输出
-  feishu-cli task list --output json`,
+  feishu-cli task list --output json
+
+  # 获取全部未完成任务
+  feishu-cli task list --uncompleted --all`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		pageSize, _ := cmd.Flags().GetInt("page-size")
 		pageToken, _ := cmd.Flags().GetString("page-token")
 		completedFlag, _ := cmd.Flags().GetBool("completed")
@@ -54,14 +60,18 @@ var listTasksCmd = &cobra.Command{
 			completed = &f
 		}
 
-		result, err := client.ListTasks(pageSize, pageToken, completed)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.ListTasksPages(pageSize, completed))
+		}
+
+		result, err := client.ListTasks(ctx, pageSize, pageToken, completed)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -108,7 +118,8 @@ func init() {
 	taskCmd.AddCommand(listTasksCmd)
 	listTasksCmd.Flags().Int("page-size", 50, "每页数量")
 	listTasksCmd.Flags().String("page-token", "", "分页标记")
+	addPaginationFlags(listTasksCmd)
 	listTasksCmd.Flags().Bool("completed", false, "只显示已完成的任务")
 	listTasksCmd.Flags().Bool("uncompleted", false, "只显示未完成的任务")
-	listTasksCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(listTasksCmd)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -34,25 +35,36 @@ var listWikiNodesCmd = &cobra.Command{
   feishu-cli wiki nodes 7012345678901234567 --parent Ad8Iw0oz3iSp4kkIi7Q
 
   # JSON 格式输出
-  feishu-cli wiki nodes 7012345678901234567 --output json`,
+  feishu-cli wiki nodes 7012345678901234567 --output json
+
+  # 获取全部根节点（自动翻页，逐行输出 JSON）
+  feishu-cli wiki nodes 7012345678901234567 --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		spaceID := args[0]
 		parentToken, _ := cmd.Flags().GetString("parent")
 		pageSize, _ := cmd.Flags().GetInt("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		output, _ := cmd.Flags().GetString("output")
 
-		nodes, _, _, err := client.ListWikiNodes(spaceID, parentToken, pageSize, "")
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.WikiNode, string, bool, error) {
+				return client.ListWikiNodes(ctx, spaceID, parentToken, pageSize, pageToken)
+			})
+		}
+
+		nodes, nextToken, hasMore, err := client.ListWikiNodes(ctx, spaceID, parentToken, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(nodes); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(nodes); err != nil {
 				return err
 			}
 		} else {
@@ -72,6 +84,9 @@ var listWikiNodesCmd = &cobra.Command{
 				fmt.Printf("    文档 Token: %s\n", node.ObjToken)
 				fmt.Println()
 			}
+			if hasMore {
+				fmt.Printf("还有更多节点，使用 --page-token %s 获取下一页，或使用 --all 获取全部\n", nextToken)
+			}
 		}
 
 		return nil
@@ -82,5 +97,7 @@ func init() {
 	wikiCmd.AddCommand(listWikiNodesCmd)
 	listWikiNodesCmd.Flags().String("parent", "", "父节点 Token（不指定则列出根节点）")
 	listWikiNodesCmd.Flags().Int("page-size", 50, "每页数量")
-	listWikiNodesCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	listWikiNodesCmd.Flags().String("page-token", "", "分页标记")
+	addPaginationFlags(listWikiNodesCmd)
+	addOutputFlag(listWikiNodesCmd)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/riba2534/feishu-cli/internal/client"
@@ -29,22 +30,33 @@ var listWikiSpacesCmd = &cobra.Command{
   feishu-cli wiki spaces --output json
 
   # 指定每页数量
-  feishu-cli wiki spaces --page-size 20`,
+  feishu-cli wiki spaces --page-size 20
+
+  # 获取全部知识空间（自动翻页，逐行输出 JSON）
+  feishu-cli wiki spaces --all`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		pageSize, _ := cmd.Flags().GetInt("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		output, _ := cmd.Flags().GetString("output")
 
-		spaces, _, _, err := client.ListWikiSpaces(pageSize, "")
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, func(ctx context.Context, pageToken string) ([]*client.WikiSpace, string, bool, error) {
+				return client.ListWikiSpaces(ctx, pageSize, pageToken)
+			})
+		}
+
+		spaces, nextToken, hasMore, err := client.ListWikiSpaces(ctx, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(spaces); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(spaces); err != nil {
 				return err
 			}
 		} else {
@@ -68,6 +80,9 @@ var listWikiSpacesCmd = &cobra.Command{
 				}
 				fmt.Println()
 			}
+			if hasMore {
+				fmt.Printf("还有更多知识空间，使用 --page-token %s 获取下一页，或使用 --all 获取全部\n", nextToken)
+			}
 		}
 
 		return nil
@@ -77,5 +92,7 @@ var listWikiSpacesCmd = &cobra.Command{
 func init() {
 	wikiCmd.AddCommand(listWikiSpacesCmd)
 	listWikiSpacesCmd.Flags().Int("page-size", 50, "每页数量")
-	listWikiSpacesCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	listWikiSpacesCmd.Flags().String("page-token", "", "分页标记")
+	addPaginationFlags(listWikiSpacesCmd)
+	addOutputFlag(listWikiSpacesCmd)
 }
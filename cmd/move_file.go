@@ -39,15 +39,25 @@ var moveFileCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		fileToken := args[0]
 		targetFolder, _ := cmd.Flags().GetString("target")
 		fileType, _ := cmd.Flags().GetString("type")
 
-		taskID, err := client.MoveFile(fileToken, targetFolder, fileType)
+		taskID, err := client.MoveFile(ctx, fileToken, targetFolder, fileType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"file_token":    fileToken,
+				"target_folder": targetFolder,
+				"task_id":       taskID,
+			})
+		}
+
 		fmt.Printf("移动操作已提交！\n")
 		fmt.Printf("  文件 Token: %s\n", fileToken)
 		fmt.Printf("  目标文件夹: %s\n", targetFolder)
@@ -63,5 +73,6 @@ func init() {
 	fileCmd.AddCommand(moveFileCmd)
 	moveFileCmd.Flags().String("target", "", "目标文件夹 Token（必填）")
 	moveFileCmd.Flags().String("type", "", "文件类型（必填）")
+	addOutputFlag(moveFileCmd)
 	mustMarkFlagRequired(moveFileCmd, "target", "type")
 }
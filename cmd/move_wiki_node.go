@@ -43,6 +43,7 @@ var moveWikiNodeCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		nodeToken, err := extractWikiToken(args[0])
 		if err != nil {
 			return err
@@ -52,18 +53,18 @@ var moveWikiNodeCmd = &cobra.Command{
 		output, _ := cmd.Flags().GetString("output")
 
 		// 先获取节点信息以获取当前 space_id
-		node, err := client.GetWikiNode(nodeToken)
+		node, err := client.GetWikiNode(ctx, nodeToken)
 		if err != nil {
 			return fmt.Errorf("获取节点信息失败: %w", err)
 		}
 
-		result, err := client.MoveWikiNode(node.SpaceID, nodeToken, targetSpace, targetParent)
+		result, err := client.MoveWikiNode(ctx, node.SpaceID, nodeToken, targetSpace, targetParent)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -85,6 +86,6 @@ func init() {
 	wikiCmd.AddCommand(moveWikiNodeCmd)
 	moveWikiNodeCmd.Flags().String("target-space", "", "目标知识空间 ID（必填）")
 	moveWikiNodeCmd.Flags().String("target-parent", "", "目标父节点 Token（可选）")
-	moveWikiNodeCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(moveWikiNodeCmd)
 	mustMarkFlagRequired(moveWikiNodeCmd, "target-space")
 }
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/riba2534/feishu-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// outputFlagUsage 各命令 -o 参数的说明
+const outputFlagUsage = "输出格式: text, json, yaml, table, csv, tsv, jsonl"
+
+// outputFormatAnnotation 标记 -o 参数表示输出格式（而不是输出文件路径）
+const outputFormatAnnotation = "feishu-cli/output-format"
+
+var (
+	outputFields    string
+	outputFilter    string
+	outputNoHeaders bool
+
+	// outputOpts 当前命令的输出选项，在 PersistentPreRunE 中设置
+	outputOpts output.Options
+	// resultStdout 结构化输出时结果写入的标准输出，其余输出转到 stderr
+	resultStdout *os.File
+)
+
+// addOutputFlag 为命令添加 -o 输出格式参数，extraFormats 为命令自己支持的其他格式
+func addOutputFlag(cmd *cobra.Command, extraFormats ...string) {
+	usage := outputFlagUsage
+	for _, f := range extraFormats {
+		usage += ", " + f
+	}
+	cmd.Flags().StringP("output", "o", output.FormatText, usage)
+	if err := cmd.Flags().SetAnnotation("output", outputFormatAnnotation, append([]string{}, extraFormats...)); err != nil {
+		panic(fmt.Sprintf("设置 output 参数失败: %v", err))
+	}
+}
+
+// isStructuredOutput 判断 -o 是否为机器可读的格式
+func isStructuredOutput(format string) bool {
+	return output.IsStructured(format)
+}
+
+// setupOutput 解析输出选项。选择结构化格式时，命令中的提示信息改为输出到 stderr，
+// 保证 stdout 只包含可解析的结果
+func setupOutput(cmd *cobra.Command) error {
+	outputOpts = output.Options{
+		Fields:    output.ParseFields(outputFields),
+		Filter:    outputFilter,
+		NoHeaders: outputNoHeaders,
+	}
+	filtering := len(outputOpts.Fields) > 0 || outputOpts.Filter != ""
+
+	flag := cmd.Flags().Lookup("output")
+	var extraFormats []string
+	if flag != nil {
+		extraFormats = flag.Annotations[outputFormatAnnotation]
+	}
+	if extraFormats == nil {
+		if filtering {
+			return fmt.Errorf("命令 %s 不支持 --fields 和 --jq", cmd.CommandPath())
+		}
+		return nil
+	}
+
+	// 命令自己支持的其他格式（如 doc outline 的 markdown）由命令处理
+	value := flag.Value.String()
+	for _, f := range extraFormats {
+		if value == f {
+			if filtering {
+				return fmt.Errorf("-o %s 不支持 --fields 和 --jq", f)
+			}
+			return nil
+		}
+	}
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return err
+	}
+	// 只指定 --fields 或 --jq 时默认输出 JSON
+	if format == output.FormatText && filtering {
+		format = output.FormatJSON
+	}
+	if err := flag.Value.Set(format); err != nil {
+		return err
+	}
+	outputOpts.Format = format
+
+	if output.IsStructured(format) && resultStdout == nil {
+		resultStdout = os.Stdout
+		os.Stdout = os.Stderr
+	}
+	return nil
+}
+
+// restoreStdout 恢复 setupOutput 重定向的标准输出
+func restoreStdout() {
+	if resultStdout != nil {
+		os.Stdout = resultStdout
+		resultStdout = nil
+	}
+}
+
+// resultWriter 返回命令结果写入的标准输出
+func resultWriter() io.Writer {
+	if resultStdout != nil {
+		return resultStdout
+	}
+	return os.Stdout
+}
+
+// printOutput 按 -o、--fields、--jq 和 --no-headers 输出命令结果
+func printOutput(v any) error {
+	return output.Write(resultWriter(), v, outputOpts)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addPaginationFlags 为列表命令添加 --all 和 --limit 参数
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "自动翻页获取全部结果，逐条输出为 JSONL")
+	cmd.Flags().Int("limit", 0, "自动翻页，最多获取的记录数（0 表示不限制）")
+}
+
+// paginateAll 判断是否指定了 --all 或 --limit
+func paginateAll(cmd *cobra.Command) bool {
+	all, _ := cmd.Flags().GetBool("all")
+	limit, _ := cmd.Flags().GetInt("limit")
+	return all || limit != 0
+}
+
+// streamPages 从 pageToken 开始自动翻页，逐条输出记录
+//
+// 默认每条记录输出一行 JSON（JSONL），不在内存中保留所有记录；
+// -o yaml/table/csv/tsv 时获取全部记录后统一输出
+func streamPages[T any](cmd *cobra.Command, pageToken string, fetch client.PageFunc[T]) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return newUsageError(fmt.Errorf("--limit 不能为负数: %d", limit))
+	}
+
+	stream := output.NewStream(resultWriter(), outputOpts)
+	_, err := client.Paginate(cmd.Context(), pageToken, limit, fetch, func(item T) error {
+		return stream.Write(item)
+	})
+	// 中断或出错时仍输出已获取的记录
+	if closeErr := stream.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -18,6 +18,8 @@ var readUsersCmd = &cobra.Command{
   --user-id-type   用户 ID 类型（默认: open_id）
   --page-size      每页数量（默认: 20，最大: 100）
   --page-token     分页标记
+  --all            自动翻页获取全部已读用户，逐行输出 JSON
+  --limit          自动翻页，最多获取的用户数
   --output, -o     输出格式（json）
 
 用户 ID 类型:
@@ -36,26 +38,34 @@ var readUsersCmd = &cobra.Command{
   feishu-cli msg read-users om_xxx --page-size 50
 
   # JSON 格式输出
-  feishu-cli msg read-users om_xxx --output json`,
+  feishu-cli msg read-users om_xxx --output json
+
+  # 获取全部已读用户
+  feishu-cli msg read-users om_xxx --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		messageID := args[0]
 		userIDType, _ := cmd.Flags().GetString("user-id-type")
 		pageSize, _ := cmd.Flags().GetInt("page-size")
 		pageToken, _ := cmd.Flags().GetString("page-token")
 
-		result, err := client.GetReadUsers(messageID, userIDType, pageSize, pageToken)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.ReadUsersPages(messageID, userIDType, pageSize))
+		}
+
+		result, err := client.GetReadUsers(ctx, messageID, userIDType, pageSize, pageToken)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"items":      result.Items,
 				"page_token": result.PageToken,
 				"has_more":   result.HasMore,
@@ -86,5 +96,6 @@ func init() {
 	readUsersCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型（open_id/user_id/union_id）")
 	readUsersCmd.Flags().Int("page-size", 20, "每页数量（最大 100）")
 	readUsersCmd.Flags().String("page-token", "", "分页标记")
-	readUsersCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addPaginationFlags(readUsersCmd)
+	addOutputFlag(readUsersCmd)
 }
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var replyCommentCmd = &cobra.Command{
+	Use:   "reply <file_token> <comment_id>",
+	Short: "回复评论",
+	Long: `在已有评论下追加一条回复。
+
+参数:
+  file_token    文档 Token
+  comment_id    评论 ID
+  --type        文件类型（必填）
+  --text        回复内容（必填）
+
+示例:
+  # 回复评论
+  feishu-cli comment reply doccnXXX comment123 --type docx --text "已修复"
+
+  # JSON 格式输出
+  feishu-cli comment reply doccnXXX comment123 --type docx --text "已修复" -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		fileToken := args[0]
+		commentID := args[1]
+		fileType, _ := cmd.Flags().GetString("type")
+		text, _ := cmd.Flags().GetString("text")
+		output, _ := cmd.Flags().GetString("output")
+
+		replyID, err := client.ReplyComment(ctx, fileToken, commentID, fileType, text)
+		if err != nil {
+			return err
+		}
+
+		if isStructuredOutput(output) {
+			return printOutput(map[string]string{
+				"comment_id": commentID,
+				"reply_id":   replyID,
+			})
+		}
+
+		fmt.Printf("回复成功！\n")
+		fmt.Printf("  评论 ID: %s\n", commentID)
+		if replyID != "" {
+			fmt.Printf("  回复 ID: %s\n", replyID)
+		}
+		return nil
+	},
+}
+
+var updateReplyCmd = &cobra.Command{
+	Use:   "update-reply <file_token> <comment_id> <reply_id>",
+	Short: "更新评论回复",
+	Long: `更新评论中一条回复的内容，只能更新自己发布的回复。
+
+参数:
+  file_token    文档 Token
+  comment_id    评论 ID
+  reply_id      回复 ID（可通过 comment list 查看）
+  --type        文件类型（必填）
+  --text        新的回复内容（必填）
+
+示例:
+  feishu-cli comment update-reply doccnXXX comment123 reply456 --type docx --text "修改后的内容"`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		fileType, _ := cmd.Flags().GetString("type")
+		text, _ := cmd.Flags().GetString("text")
+
+		if err := client.UpdateCommentReply(ctx, args[0], args[1], args[2], fileType, text); err != nil {
+			return err
+		}
+
+		fmt.Printf("回复更新成功！\n")
+		fmt.Printf("  评论 ID: %s\n", args[1])
+		fmt.Printf("  回复 ID: %s\n", args[2])
+		return nil
+	},
+}
+
+var deleteReplyCmd = &cobra.Command{
+	Use:   "delete-reply <file_token> <comment_id> <reply_id>",
+	Short: "删除评论回复",
+	Long: `删除评论中的一条回复，只能删除自己发布的回复。
+
+参数:
+  file_token    文档 Token
+  comment_id    评论 ID
+  reply_id      回复 ID（可通过 comment list 查看）
+  --type        文件类型（必填）
+
+示例:
+  feishu-cli comment delete-reply doccnXXX comment123 reply456 --type docx`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		fileType, _ := cmd.Flags().GetString("type")
+
+		if err := client.DeleteCommentReply(ctx, args[0], args[1], args[2], fileType); err != nil {
+			return err
+		}
+
+		fmt.Printf("回复删除成功！\n")
+		fmt.Printf("  评论 ID: %s\n", args[1])
+		fmt.Printf("  回复 ID: %s\n", args[2])
+		return nil
+	},
+}
+
+func init() {
+	commentCmd.AddCommand(replyCommentCmd)
+	replyCommentCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
+	replyCommentCmd.Flags().String("text", "", "回复内容（必填）")
+	addOutputFlag(replyCommentCmd)
+	mustMarkFlagRequired(replyCommentCmd, "type", "text")
+
+	commentCmd.AddCommand(updateReplyCmd)
+	updateReplyCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
+	updateReplyCmd.Flags().String("text", "", "新的回复内容（必填）")
+	mustMarkFlagRequired(updateReplyCmd, "type", "text")
+
+	commentCmd.AddCommand(deleteReplyCmd)
+	deleteReplyCmd.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
+	mustMarkFlagRequired(deleteReplyCmd, "type")
+}
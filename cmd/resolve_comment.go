@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var resolveCommentCmd = &cobra.Command{
+	Use:   "resolve <file_token> <comment_id>",
+	Short: "将评论标记为已解决",
+	Long: `将评论标记为已解决。
+
+参数:
+  file_token    文档 Token
+  comment_id    评论 ID
+  --type        文件类型（必填）
+
+示例:
+  feishu-cli comment resolve doccnXXX comment123 --type docx`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCommentSolved(cmd, args, true)
+	},
+}
+
+var unresolveCommentCmd = &cobra.Command{
+	Use:   "unresolve <file_token> <comment_id>",
+	Short: "重新打开已解决的评论",
+	Long: `将已解决的评论重新标记为未解决。
+
+参数:
+  file_token    文档 Token
+  comment_id    评论 ID
+  --type        文件类型（必填）
+
+示例:
+  feishu-cli comment unresolve doccnXXX comment123 --type docx`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCommentSolved(cmd, args, false)
+	},
+}
+
+// setCommentSolved 更新评论的解决状态
+func setCommentSolved(cmd *cobra.Command, args []string, solved bool) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	fileType, _ := cmd.Flags().GetString("type")
+	if err := client.SetCommentSolved(ctx, args[0], args[1], fileType, solved); err != nil {
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if isStructuredOutput(output) {
+		return printOutput(map[string]any{
+			"file_token": args[0],
+			"comment_id": args[1],
+			"solved":     solved,
+		})
+	}
+
+	status := "未解决"
+	if solved {
+		status = "已解决"
+	}
+	fmt.Printf("评论已标记为%s\n", status)
+	fmt.Printf("  评论 ID: %s\n", args[1])
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{resolveCommentCmd, unresolveCommentCmd} {
+		commentCmd.AddCommand(c)
+		c.Flags().String("type", "", "文件类型（必填: doc/docx/sheet/bitable）")
+		addOutputFlag(c)
+		mustMarkFlagRequired(c, "type")
+	}
+}
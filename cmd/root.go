@@ -1,18 +1,28 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/riba2534/feishu-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgFile   string
-	debug     bool
-	version   = "dev"
-	buildTime = "unknown"
+	cfgFile    string
+	profile    string
+	debug      bool
+	dryRun     bool
+	maxRetries int
+	qps        float64
+	timeout    time.Duration
+	version    = "dev"
+	buildTime  = "unknown"
 )
 
 // SetVersionInfo sets version information from main package
@@ -53,6 +63,44 @@ var rootCmd = &cobra.Command{
 
   配置优先级: 环境变量 > 配置文件 > 默认值
 
+  3. 配置档案（多个应用或租户）:
+     feishu-cli config profile add lark --app-id cli_xxx --app-secret xxx --lark
+     feishu-cli --profile lark doc get <document_id>
+     也可通过环境变量 FEISHU_PROFILE 或 config profile use 切换默认档案
+
+限流与重试:
+  所有 API 请求按接口类别限流，遇到限流（429）或服务端错误时自动按指数退避重试，
+  并遵循响应头中建议的等待时间。创建、发送等写请求可能已经生效，只在限流时重试，
+  遇到服务端错误或网络错误时直接返回错误，避免重复创建内容。
+  可通过 --max-retries、--qps 或配置项 max_retries、qps
+  （环境变量 FEISHU_MAX_RETRIES、FEISHU_QPS）调整。
+
+中断与超时:
+  按 Ctrl+C 或设置 --timeout 后命令会停止后续请求，导入、导出、同步等命令
+  输出已完成部分的结果并保存同步状态；再次按 Ctrl+C 立即退出。
+  退出码: 130 表示被中断，124 表示超时。
+
+输出格式:
+  支持 -o 的命令可选择 text（默认，便于阅读）、json、yaml、table、csv、tsv、jsonl。
+  选择 text 以外的格式时 stdout 只包含结果，提示和进度信息输出到 stderr。
+  --fields 只保留指定字段（可用 a.b 访问嵌套字段），--jq 按路径过滤结果
+  （如 .items[].name，也支持 JSONPath 写法 $.items[*].name），
+  --no-headers 去掉 table/csv/tsv 的表头。指定 --fields 或 --jq 时默认输出 JSON。
+  列表命令支持 --all 自动翻页获取全部结果、--limit N 最多获取 N 条，
+  结果逐条输出为 JSONL（--fields、--jq 作用于每条记录）。
+
+退出码:
+  0 成功，1 其他错误，2 参数错误，3 文档版本冲突，4 认证失败，5 权限不足，
+  6 资源不存在，7 触发限流，8 网络错误，9 服务端错误，124 超时，130 被中断。
+  选择结构化输出格式时，错误以 JSON 写入 stderr：
+  {"error": {"message", "kind", "exit_code", "code", "msg", "log_id", "http_status", "retryable", "hint"}}
+
+预览（dry-run）:
+  --dry-run 时创建、更新、删除、移动、权限、发送消息、写表格等写请求不会发送，
+  而是输出请求的方法、地址和请求体（凭证等敏感字段已脱敏），命令以 0 退出。
+  读请求照常发送，删除等命令不再询问确认，便于在 CI 中先预览再执行。
+  写请求依赖前一个写请求的结果时（如导入文档先创建文档），只输出第一个请求。
+
 快速开始:
   # 创建文档
   feishu-cli doc create --title "我的文档"
@@ -68,13 +116,36 @@ var rootCmd = &cobra.Command{
 
 更多信息请访问: https://github.com/riba2534/feishu-cli`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setupOutput(cmd); err != nil {
+			return newUsageError(err)
+		}
+		// 提前检查必填参数，避免参数错误时先去加载配置
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return newUsageError(err)
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return newUsageError(err)
+		}
+		if timeout < 0 {
+			return newUsageError(fmt.Errorf("--timeout 不能为负数"))
+		}
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeoutCause(cmd.Context(), timeout, errCommandTimeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+
 		// Skip config initialization for commands that don't need it
 		switch cmd.Name() {
 		case "init", "help", "completion", "version", "doc", "wiki", "file", "media", "comment", "perm", "msg", "config", "calendar", "task", "search", "user", "board":
 			return nil
 		}
+		// 配置档案管理命令直接读写配置文件，不依赖当前档案能否加载
+		if cmd.Parent() == configProfileCmd {
+			return nil
+		}
 
-		if err := config.Init(cfgFile); err != nil {
+		if err := config.InitWithProfile(cfgFile, profile); err != nil {
 			return err
 		}
 
@@ -84,19 +155,98 @@ var rootCmd = &cobra.Command{
 			cfg.Debug = true
 		}
 
+		if dryRun {
+			config.Get().DryRun = true
+		}
+
+		// 命令行指定的限流重试参数优先于配置
+		if cmd.Flags().Changed("max-retries") {
+			config.Get().MaxRetries = maxRetries
+		}
+		if cmd.Flags().Changed("qps") {
+			config.Get().QPS = qps
+		}
+
 		return nil
 	},
 }
 
+// 命令上下文被取消的原因
+var (
+	errCommandInterrupted = errors.New("操作已中断")
+	errCommandTimeout     = errors.New("操作超时")
+)
+
+// cancelTimeout 释放 --timeout 创建的计时器
+var cancelTimeout context.CancelFunc = func() {}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
+// 所有命令共享一个根上下文，Ctrl+C、SIGTERM 和 --timeout 都通过它取消正在进行的请求
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	ctx, stop := newRootContext()
+	markUsageErrors(rootCmd)
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	restoreStdout()
+	if dryRun {
+		err = finishDryRun(err)
+	}
+	code := exitCodeFor(cmd, err)
+	cancelTimeout()
+	stop()
+
+	if err != nil {
+		printError(cmd, err, code)
+		os.Exit(code)
+	}
+}
+
+// newRootContext 创建根上下文：第一次收到 Ctrl+C 或 SIGTERM 时取消上下文，
+// 让命令停止后续请求并保存已完成的部分；再次收到时立即退出
+func newRootContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+		case <-done:
+			return
+		}
+		fmt.Fprintln(os.Stderr, "\n正在中断，等待进行中的请求结束（再次按 Ctrl+C 强制退出）...")
+		cancel(errCommandInterrupted)
+
+		select {
+		case <-sigCh:
+			os.Exit(exitCodeInterrupted)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigCh)
+		close(done)
+		cancel(nil)
 	}
 }
 
 func init() {
+	// 错误由 Execute 统一输出，只在参数错误时显示用法
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return newUsageError(err)
+	})
+
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "配置文件路径（默认: ~/.feishu-cli/config.yaml）")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "使用的配置档案（默认: 环境变量 FEISHU_PROFILE 或配置文件中的 current_profile）")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "启用调试模式")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "只输出将要发送的写请求（已脱敏），不实际调用接口")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", config.DefaultMaxRetries, "API 请求遇到限流或服务端错误时的最大重试次数（0 表示不重试）")
+	rootCmd.PersistentFlags().Float64Var(&qps, "qps", 0, "全局 QPS 上限（0 表示按接口类别使用默认限额）")
+	rootCmd.PersistentFlags().StringVar(&outputFields, "fields", "", "只输出指定字段，逗号分隔，如 id,name,owner.name")
+	rootCmd.PersistentFlags().StringVar(&outputFilter, "jq", "", "按路径表达式过滤输出，如 .items[].name 或 $.items[*].name")
+	rootCmd.PersistentFlags().BoolVar(&outputNoHeaders, "no-headers", false, "table/csv/tsv 输出不包含表头")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "命令整体超时时间，如 30s、5m（0 表示不限制）")
 }
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	withCause := func(cause error) *cobra.Command {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		return cmd
+	}
+
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		err  error
+		want int
+	}{
+		{"成功", &cobra.Command{}, nil, 0},
+		{"普通错误", &cobra.Command{}, errors.New("失败"), 1},
+		{"版本冲突", &cobra.Command{}, fmt.Errorf("写入失败: %w", &client.RevisionConflictError{}), exitCodeRevisionConflict},
+		{"中断", withCause(errCommandInterrupted), fmt.Errorf("获取块失败: %w", context.Canceled), exitCodeInterrupted},
+		{"超时", withCause(errCommandTimeout), fmt.Errorf("获取块失败: %w", context.DeadlineExceeded), exitCodeTimeout},
+		{"中断但命令正常结束", withCause(errCommandInterrupted), nil, 0},
+		{"无命令", nil, errors.New("失败"), 1},
+		{"参数错误", &cobra.Command{}, newUsageError(errors.New("缺少参数")), exitCodeUsage},
+		{"未登录", &cobra.Command{}, fmt.Errorf("获取 token 失败: %w", client.ErrUserAuthRequired), exitCodeAuth},
+		{"权限不足", &cobra.Command{}, &client.APIError{Op: "获取文档失败", Code: 99991672}, exitCodePermission},
+		{"文档不存在", &cobra.Command{}, fmt.Errorf("导出失败: %w", &client.APIError{Code: 1770002}), exitCodeNotFound},
+		{"限流", &cobra.Command{}, &client.APIError{Code: 99991400}, exitCodeRateLimit},
+		{"服务端错误", &cobra.Command{}, &client.APIError{Code: 1, HTTPStatus: 502}, exitCodeServer},
+	}
+	for _, tt := range tests {
+		if got := exitCodeFor(tt.cmd, tt.err); got != tt.want {
+			t.Errorf("%s: exitCodeFor = %d, 期望 %d", tt.name, got, tt.want)
+		}
+	}
+}
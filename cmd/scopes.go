@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// scopeRequirement 命令所需的权限 scope
+// 每一项中用 "|" 分隔的多个 scope 开通任意一个即可
+type scopeRequirement struct {
+	Tenant []string `json:"tenant,omitempty"` // 应用身份（tenant_access_token）
+	User   []string `json:"user,omitempty"`   // 用户身份（user_access_token）
+}
+
+// 常用的 scope 组合
+const (
+	scopeDocRead    = "docx:document|docx:document:readonly"
+	scopeDocWrite   = "docx:document"
+	scopeDriveRead  = "drive:drive|drive:drive:readonly"
+	scopeDriveWrite = "drive:drive"
+	scopeWikiRead   = "wiki:wiki|wiki:wiki:readonly"
+	scopeWikiWrite  = "wiki:wiki"
+	scopeSheetRead  = "sheets:spreadsheet|sheets:spreadsheet:readonly"
+	scopeSheetWrite = "sheets:spreadsheet"
+	scopeBoard      = "board:board"
+	scopeMsgRead    = "im:message|im:message:readonly"
+	scopeMsgSend    = "im:message|im:message:send_as_bot"
+	scopeCalRead    = "calendar:calendar|calendar:calendar:readonly"
+	scopeCalWrite   = "calendar:calendar"
+	scopeTaskRead   = "task:task:read|task:task:write"
+	scopeTaskWrite  = "task:task:write"
+)
+
+// commandScopes 各命令所需的权限，键为不含程序名的命令路径
+// 新增调用 API 的命令时需要在此登记（TestCommandScopesCoverAllCommands 会检查）
+var commandScopes = map[string]scopeRequirement{
+	// 文档
+	"doc get":          {Tenant: []string{scopeDocRead}},
+	"doc blocks":       {Tenant: []string{scopeDocRead}},
+	"doc outline":      {Tenant: []string{scopeDocRead}},
+	"doc stats":        {Tenant: []string{scopeDocRead}},
+	"doc diff":         {Tenant: []string{scopeDocRead}},
+	"doc status":       {Tenant: []string{scopeDocRead}},
+	"doc pull":         {Tenant: []string{scopeDocRead, scopeDriveRead}},
+	"doc export":       {Tenant: []string{scopeDocRead, scopeDriveRead}},
+	"doc create":       {Tenant: []string{scopeDocWrite}},
+	"doc add":          {Tenant: []string{scopeDocWrite}},
+	"doc add-callout":  {Tenant: []string{scopeDocWrite}},
+	"doc add-board":    {Tenant: []string{scopeDocWrite, scopeBoard}},
+	"doc batch-update": {Tenant: []string{scopeDocWrite}},
+	"doc update":       {Tenant: []string{scopeDocWrite}},
+	"doc delete":       {Tenant: []string{scopeDocWrite}},
+	"doc import":       {Tenant: []string{scopeDocWrite, scopeDriveWrite, scopeBoard}},
+	"doc push":         {Tenant: []string{scopeDocWrite, scopeDriveWrite, scopeBoard}},
+
+	// 知识库
+	"wiki get":         {Tenant: []string{scopeWikiRead}},
+	"wiki nodes":       {Tenant: []string{scopeWikiRead}},
+	"wiki spaces":      {Tenant: []string{scopeWikiRead}},
+	"wiki export":      {Tenant: []string{scopeWikiRead, scopeDocRead, scopeDriveRead}},
+	"wiki check-links": {Tenant: []string{scopeWikiRead, scopeDocRead}},
+	"wiki create":      {Tenant: []string{scopeWikiWrite}},
+	"wiki update":      {Tenant: []string{scopeWikiWrite}},
+	"wiki move":        {Tenant: []string{scopeWikiWrite}},
+	"wiki delete":      {Tenant: []string{scopeWikiWrite}},
+
+	// 云空间
+	"file list":        {Tenant: []string{scopeDriveRead}},
+	"file quota":       {Tenant: []string{scopeDriveRead}},
+	"file check-links": {Tenant: []string{scopeDriveRead, scopeDocRead}},
+	"file mkdir":       {Tenant: []string{scopeDriveWrite}},
+	"file copy":        {Tenant: []string{scopeDriveWrite}},
+	"file move":        {Tenant: []string{scopeDriveWrite}},
+	"file delete":      {Tenant: []string{scopeDriveWrite}},
+	"file shortcut":    {Tenant: []string{scopeDriveWrite}},
+	"media upload":     {Tenant: []string{scopeDriveWrite}},
+	"media download":   {Tenant: []string{scopeDriveRead}},
+
+	// 评论
+	"comment list":         {Tenant: []string{scopeDriveRead}},
+	"comment add":          {Tenant: []string{scopeDriveWrite}},
+	"comment reply":        {Tenant: []string{scopeDriveWrite}},
+	"comment update-reply": {Tenant: []string{scopeDriveWrite}},
+	"comment delete-reply": {Tenant: []string{scopeDriveWrite}},
+	"comment resolve":      {Tenant: []string{scopeDriveWrite}},
+	"comment unresolve":    {Tenant: []string{scopeDriveWrite}},
+	"comment delete":       {Tenant: []string{scopeDriveWrite}},
+	"comment import":       {Tenant: []string{scopeDocRead, scopeDriveWrite}},
+
+	// 权限
+	"perm add":    {Tenant: []string{"drive:permission:member:create|drive:drive"}},
+	"perm update": {Tenant: []string{scopeDriveWrite}},
+
+	// 画板
+	"board image":        {Tenant: []string{scopeBoard}},
+	"board import":       {Tenant: []string{scopeBoard}},
+	"board create-notes": {Tenant: []string{scopeBoard}},
+
+	// 消息
+	"msg send":         {Tenant: []string{scopeMsgSend}},
+	"msg forward":      {Tenant: []string{scopeMsgSend}},
+	"msg delete":       {Tenant: []string{"im:message"}},
+	"msg get":          {Tenant: []string{scopeMsgRead}},
+	"msg list":         {Tenant: []string{scopeMsgRead}},
+	"msg history":      {Tenant: []string{scopeMsgRead}},
+	"msg read-users":   {Tenant: []string{scopeMsgRead}},
+	"msg search-chats": {Tenant: []string{"im:chat|im:chat:readonly"}},
+
+	// 日历
+	"calendar list":         {Tenant: []string{scopeCalRead}},
+	"calendar list-events":  {Tenant: []string{scopeCalRead}},
+	"calendar get-event":    {Tenant: []string{scopeCalRead}},
+	"calendar create-event": {Tenant: []string{scopeCalWrite}},
+	"calendar update-event": {Tenant: []string{scopeCalWrite}},
+	"calendar delete-event": {Tenant: []string{scopeCalWrite}},
+
+	// 任务
+	"task list":     {Tenant: []string{scopeTaskRead}},
+	"task get":      {Tenant: []string{scopeTaskRead}},
+	"task create":   {Tenant: []string{scopeTaskWrite}},
+	"task update":   {Tenant: []string{scopeTaskWrite}},
+	"task complete": {Tenant: []string{scopeTaskWrite}},
+	"task delete":   {Tenant: []string{scopeTaskWrite}},
+
+	// 用户
+	"user info": {Tenant: []string{"contact:user.base:readonly"}},
+
+	// 搜索（用户身份）
+	"search messages": {User: []string{"search:message"}},
+	"search apps":     {User: []string{"search:app"}},
+	"search docs":     {User: []string{"search:docs:read"}},
+}
+
+func init() {
+	// 电子表格命令按读写区分
+	sheetReads := []string{"get", "read", "read-plain", "read-rich", "find", "meta", "list-sheets", "filter get", "image list"}
+	sheetWrites := []string{
+		"create", "write", "write-rich", "append", "append-rich", "insert", "insert-rows", "add-rows", "add-cols",
+		"delete-rows", "delete-cols", "add-sheet", "delete-sheet", "copy-sheet", "clear", "replace", "merge",
+		"unmerge", "style", "protect", "unprotect", "filter create", "filter delete", "image add", "image delete",
+	}
+	for _, name := range sheetReads {
+		commandScopes["sheet "+name] = scopeRequirement{Tenant: []string{scopeSheetRead}}
+	}
+	for _, name := range sheetWrites {
+		commandScopes["sheet "+name] = scopeRequirement{Tenant: []string{scopeSheetWrite}}
+	}
+}
+
+// commandKey 返回命令在 commandScopes 中的键，如 "doc import"
+func commandKey(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
+
+// missingScopes 返回 granted 中未满足的要求项
+func missingScopes(required []string, granted map[string]bool) []string {
+	var missing []string
+	for _, item := range required {
+		ok := false
+		for _, scope := range strings.Split(item, "|") {
+			if granted[scope] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// 权限相关的错误码
+const (
+	codeAppScopeMissing  = 99991672 // 应用未开通所需的应用身份权限
+	codeUserScopeMissing = 99991679 // 用户授权中缺少所需的权限
+	codeTokenInvalid     = 99991663 // access_token 无效
+	codeUserTokenInvalid = 99991668 // user_access_token 无效
+	codeUserTokenExpired = 99991677 // user_access_token 已过期
+)
+
+var (
+	errorCodePattern  = regexp.MustCompile(`code=(\d+)`)
+	scopeListPattern  = regexp.MustCompile(`\[([a-z0-9_.:\-]+(?:\s*,\s*[a-z0-9_.:\-]+)*)\]`)
+	scopeQueryPattern = regexp.MustCompile(`[?&]q=([^&\s]+)`)
+)
+
+// permissionHint 根据错误中的权限错误码，返回需要开通的 scope 提示；不是权限错误时返回空字符串
+// 优先使用错误信息中列出的 scope，没有时使用 commandScopes 中登记的 scope
+func permissionHint(cmd *cobra.Command, err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	m := errorCodePattern.FindStringSubmatch(msg)
+	if m == nil {
+		return ""
+	}
+	code, _ := strconv.Atoi(m[1])
+
+	var req scopeRequirement
+	if cmd != nil {
+		req = commandScopes[commandKey(cmd)]
+	}
+	cfg := config.Get()
+
+	switch code {
+	case codeAppScopeMissing:
+		scopes := scopesFromMessage(msg)
+		if len(scopes) == 0 {
+			scopes = req.Tenant
+		}
+		if len(scopes) == 0 {
+			return ""
+		}
+		var b strings.Builder
+		b.WriteString("应用缺少应用身份权限，请在开放平台为应用开通以下 scope（每行任选其一）并发布新版本:\n")
+		for _, s := range scopes {
+			fmt.Fprintf(&b, "  %s\n", strings.ReplaceAll(s, "|", " 或 "))
+		}
+		fmt.Fprintf(&b, "开通地址: %s\n", scopeConsoleURL(cfg.BaseURL, cfg.AppID, scopes))
+		b.WriteString("可运行 feishu-cli auth doctor 检查所有命令的权限")
+		return b.String()
+	case codeUserScopeMissing:
+		scopes := scopesFromMessage(msg)
+		if len(scopes) == 0 {
+			scopes = req.User
+		}
+		if len(scopes) == 0 {
+			return ""
+		}
+		var first []string
+		for _, s := range scopes {
+			first = append(first, strings.Split(s, "|")[0])
+		}
+		var b strings.Builder
+		b.WriteString("用户授权缺少以下 scope（每行任选其一），请确认应用已开通后重新登录授权:\n")
+		for _, s := range scopes {
+			fmt.Fprintf(&b, "  %s\n", strings.ReplaceAll(s, "|", " 或 "))
+		}
+		fmt.Fprintf(&b, "  feishu-cli auth login --scope %q", strings.Join(first, " "))
+		return b.String()
+	case codeTokenInvalid, codeUserTokenInvalid, codeUserTokenExpired:
+		if len(req.User) == 0 && code == codeTokenInvalid {
+			return ""
+		}
+		return "User Access Token 无效或已过期，请运行 feishu-cli auth refresh 或 feishu-cli auth login 重新授权"
+	}
+	return ""
+}
+
+// scopesFromMessage 从开放平台的错误信息中取出 scope 列表
+// 错误信息形如 "... One of the following scopes is required: [docx:document, docx:document:readonly] ..."，
+// 其中列出的 scope 任选其一即可，合并为一项
+func scopesFromMessage(msg string) []string {
+	if m := scopeListPattern.FindStringSubmatch(msg); m != nil && strings.Contains(m[1], ":") {
+		parts := strings.Split(m[1], ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return []string{strings.Join(parts, "|")}
+	}
+	if m := scopeQueryPattern.FindStringSubmatch(msg); m != nil {
+		if q, err := url.QueryUnescape(m[1]); err == nil && strings.Contains(q, ":") {
+			return []string{strings.ReplaceAll(q, ",", "|")}
+		}
+	}
+	return nil
+}
+
+// scopeConsoleURL 返回开放平台中应用权限管理页面的地址
+func scopeConsoleURL(baseURL, appID string, scopes []string) string {
+	var all []string
+	for _, s := range scopes {
+		all = append(all, strings.Split(s, "|")...)
+	}
+	return fmt.Sprintf("%s/app/%s/auth?q=%s", strings.TrimSuffix(baseURL, "/"), appID, url.QueryEscape(strings.Join(all, ",")))
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommandScopesCoverAllCommands(t *testing.T) {
+	// 这些命令不调用需要 scope 的业务 API，api 和 batch 所需权限取决于调用的接口和执行的操作
+	noScope := map[string]bool{"auth": true, "config": true, "help": true, "completion": true, "api": true, "batch": true}
+
+	existing := map[string]bool{}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+		if c == rootCmd || !c.Runnable() {
+			return
+		}
+		key := commandKey(c)
+		existing[key] = true
+		if noScope[strings.Fields(key)[0]] {
+			return
+		}
+		if _, ok := commandScopes[key]; !ok {
+			t.Errorf("命令 %q 未在 commandScopes 中登记所需权限", key)
+		}
+	}
+	walk(rootCmd)
+
+	for key := range commandScopes {
+		if !existing[key] {
+			t.Errorf("commandScopes 中的 %q 不是已有命令", key)
+		}
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	granted := map[string]bool{"docx:document:readonly": true, "drive:drive": true}
+	got := missingScopes([]string{scopeDocRead, scopeDriveWrite, scopeBoard}, granted)
+	if len(got) != 1 || got[0] != scopeBoard {
+		t.Errorf("missingScopes = %v, 期望 [%s]", got, scopeBoard)
+	}
+}
+
+func TestPermissionHint(t *testing.T) {
+	importCmd, _, err := rootCmd.Find([]string{"doc", "import"})
+	if err != nil {
+		t.Fatalf("找不到 doc import 命令: %v", err)
+	}
+	searchCmd, _, err := rootCmd.Find([]string{"search", "messages"})
+	if err != nil {
+		t.Fatalf("找不到 search messages 命令: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		err     error
+		want    []string
+		wantNot []string
+	}{
+		{
+			name: "错误信息中列出 scope",
+			cmd:  importCmd,
+			err:  errors.New("创建文档失败: code=99991672, msg=Access denied. One of the following scopes is required: [docx:document, docx:document:create]."),
+			want: []string{"docx:document 或 docx:document:create", "/auth?q=docx%3Adocument%2Cdocx%3Adocument%3Acreate"},
+		},
+		{
+			name:    "错误信息中没有 scope 时使用命令登记的权限",
+			cmd:     importCmd,
+			err:     errors.New("上传图片失败: code=99991672, msg=Access denied"),
+			want:    []string{"docx:document", "board:board"},
+			wantNot: []string{"search:message"},
+		},
+		{
+			name: "用户授权缺少 scope",
+			cmd:  searchCmd,
+			err:  errors.New("搜索消息失败: code=99991679, msg=Unauthorized"),
+			want: []string{"auth login --scope \"search:message\""},
+		},
+		{
+			name: "用户 token 过期",
+			cmd:  searchCmd,
+			err:  errors.New("搜索消息失败: code=99991677, msg=token expired"),
+			want: []string{"auth refresh"},
+		},
+	}
+	for _, tt := range tests {
+		hint := permissionHint(tt.cmd, tt.err)
+		for _, want := range tt.want {
+			if !strings.Contains(hint, want) {
+				t.Errorf("%s: 提示中缺少 %q:\n%s", tt.name, want, hint)
+			}
+		}
+		for _, not := range tt.wantNot {
+			if strings.Contains(hint, not) {
+				t.Errorf("%s: 提示中不应包含 %q:\n%s", tt.name, not, hint)
+			}
+		}
+	}
+
+	for _, err := range []error{nil, errors.New("文档不存在: code=1770002, msg=not found"), errors.New("网络错误")} {
+		if hint := permissionHint(importCmd, err); hint != "" {
+			t.Errorf("非权限错误 %v 不应有提示: %s", err, hint)
+		}
+	}
+}
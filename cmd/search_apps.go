@@ -22,6 +22,8 @@ var searchAppsCmd = &cobra.Command{
 选项:
   --page-size     每页数量（默认 20）
   --page-token    分页 token
+  --all           自动翻页获取全部结果，逐行输出 JSON
+  --limit         自动翻页，最多获取的结果数
   --user-id-type  用户 ID 类型（open_id/union_id/user_id，默认 open_id）
 
 示例:
@@ -33,13 +35,17 @@ var searchAppsCmd = &cobra.Command{
   feishu-cli search apps "审批"
 
   # 分页获取更多结果
-  feishu-cli search apps "审批" --page-size 50`,
+  feishu-cli search apps "审批" --page-size 50
+
+  # 自动翻页，最多获取 100 条结果
+  feishu-cli search apps "审批" --limit 100`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		query := args[0]
 
 		// 获取 user access token
@@ -70,13 +76,17 @@ var searchAppsCmd = &cobra.Command{
 			UserIDType: userIDType,
 		}
 
-		result, err := client.SearchApps(opts, userAccessToken)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.SearchAppsPages(opts, userAccessToken))
+		}
+
+		result, err := client.SearchApps(ctx, opts, userAccessToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -105,6 +115,7 @@ func init() {
 	searchAppsCmd.Flags().String("user-access-token", "", "User Access Token（用户授权令牌）")
 	searchAppsCmd.Flags().Int("page-size", 20, "每页数量")
 	searchAppsCmd.Flags().String("page-token", "", "分页 token")
+	addPaginationFlags(searchAppsCmd)
 	searchAppsCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型（open_id/union_id/user_id）")
-	searchAppsCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(searchAppsCmd)
 }
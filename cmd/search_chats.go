@@ -18,6 +18,8 @@ var searchChatsCmd = &cobra.Command{
   --query          关键词搜索
   --page-token     分页标记
   --page-size      分页大小 (1-100)，默认 50
+  --all            自动翻页获取全部群聊，逐行输出 JSON
+  --limit          自动翻页，最多获取的群聊数
   --output, -o     输出格式 (json)
 
 示例:
@@ -31,12 +33,16 @@ var searchChatsCmd = &cobra.Command{
   feishu-cli msg search-chats --page-size 20 --page-token xxx
 
   # JSON 格式输出
-  feishu-cli msg search-chats -o json`,
+  feishu-cli msg search-chats -o json
+
+  # 以表格列出全部群聊
+  feishu-cli msg search-chats --all -o table --fields chat_id,name`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		userIDType, _ := cmd.Flags().GetString("user-id-type")
 		query, _ := cmd.Flags().GetString("query")
 		pageToken, _ := cmd.Flags().GetString("page-token")
@@ -50,13 +56,17 @@ var searchChatsCmd = &cobra.Command{
 			PageSize:   pageSize,
 		}
 
-		result, err := client.SearchChats(opts)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.SearchChatsPages(opts))
+		}
+
+		result, err := client.SearchChats(ctx, opts)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -87,5 +97,6 @@ func init() {
 	searchChatsCmd.Flags().String("query", "", "关键词搜索")
 	searchChatsCmd.Flags().String("page-token", "", "分页标记")
 	searchChatsCmd.Flags().Int("page-size", 50, "分页大小 (1-100)")
-	searchChatsCmd.Flags().StringP("output", "o", "", "输出格式 (json)")
+	addPaginationFlags(searchChatsCmd)
+	addOutputFlag(searchChatsCmd)
 }
@@ -29,6 +29,8 @@ var searchDocsCmd = &cobra.Command{
   --doc-updated   文档更新时间筛选
   --page-size     每页数量（默认 20）
   --page-token    分页 token
+  --all           自动翻页获取全部结果，逐行输出 JSON
+  --limit         自动翻页，最多获取的结果数
   --user-id-type  用户 ID 类型（open_id/union_id/user_id，默认 open_id）
 
 示例:
@@ -42,13 +44,17 @@ var searchDocsCmd = &cobra.Command{
   feishu-cli search docs "项目" --doc-types doc,sheet
 
   # 搜索最近更新的文档
-  feishu-cli search docs "项目" --doc-updated ">=2024-01-01"`,
+  feishu-cli search docs "项目" --doc-updated ">=2024-01-01"
+
+  # 获取全部结果并导出为 CSV
+  feishu-cli search docs "项目" --all -o csv --fields title,url`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		query := args[0]
 
 		// 获取 user access token
@@ -113,13 +119,17 @@ var searchDocsCmd = &cobra.Command{
 			UserIDType:   userIDType,
 		}
 
-		result, err := client.SearchDocs(opts, userAccessToken)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.SearchDocsPages(opts, userAccessToken))
+		}
+
+		result, err := client.SearchDocs(ctx, opts, userAccessToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -163,6 +173,7 @@ func init() {
 	searchDocsCmd.Flags().String("doc-updated", "", "文档更新时间筛选（如: \u003e=2024-01-01）")
 	searchDocsCmd.Flags().Int("page-size", 20, "每页数量")
 	searchDocsCmd.Flags().String("page-token", "", "分页 token")
+	addPaginationFlags(searchDocsCmd)
 	searchDocsCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型（open_id/union_id/user_id）")
-	searchDocsCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(searchDocsCmd)
 }
@@ -30,6 +30,8 @@ var searchMessagesCmd = &cobra.Command{
   --end-time      消息发送结束时间（Unix 时间戳，秒）
   --page-size     每页数量（默认 20）
   --page-token    分页 token
+  --all           自动翻页获取全部结果，逐行输出 JSON
+  --limit         自动翻页，最多获取的结果数
   --user-id-type  用户 ID 类型（open_id/union_id/user_id，默认 open_id）
 
 示例:
@@ -47,13 +49,17 @@ var searchMessagesCmd = &cobra.Command{
 
   # 使用环境变量设置 token
   export FEISHU_USER_ACCESS_TOKEN="u-xxx"
-  feishu-cli search messages "会议"`,
+  feishu-cli search messages "会议"
+
+  # 获取全部结果（自动翻页，逐行输出 JSON）
+  feishu-cli search messages "会议" --chat-ids oc_xxx --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		query := args[0]
 
 		// 获取 user access token
@@ -119,13 +125,17 @@ var searchMessagesCmd = &cobra.Command{
 			UserIDType:   userIDType,
 		}
 
-		result, err := client.SearchMessages(opts, userAccessToken)
+		if paginateAll(cmd) {
+			return streamPages(cmd, pageToken, client.SearchMessagesPages(opts, userAccessToken))
+		}
+
+		result, err := client.SearchMessages(ctx, opts, userAccessToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -162,6 +172,7 @@ func init() {
 	searchMessagesCmd.Flags().String("end-time", "", "消息发送结束时间（Unix 时间戳）")
 	searchMessagesCmd.Flags().Int("page-size", 20, "每页数量")
 	searchMessagesCmd.Flags().String("page-token", "", "分页 token")
+	addPaginationFlags(searchMessagesCmd)
 	searchMessagesCmd.Flags().String("user-id-type", "open_id", "用户 ID 类型（open_id/union_id/user_id）")
-	searchMessagesCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(searchMessagesCmd)
 }
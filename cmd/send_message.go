@@ -66,6 +66,7 @@ var sendMessageCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		receiveIDType, _ := cmd.Flags().GetString("receive-id-type")
 		receiveID, _ := cmd.Flags().GetString("receive-id")
 		msgType, _ := cmd.Flags().GetString("msg-type")
@@ -91,14 +92,14 @@ var sendMessageCmd = &cobra.Command{
 			return fmt.Errorf("必须指定 --content、--content-file 或 --text")
 		}
 
-		messageID, err := client.SendMessage(receiveIDType, receiveID, msgType, msgContent)
+		messageID, err := client.SendMessage(ctx, receiveIDType, receiveID, msgType, msgContent)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"message_id": messageID,
 			}); err != nil {
 				return err
@@ -120,6 +121,6 @@ func init() {
 	sendMessageCmd.Flags().StringP("content", "c", "", "消息内容 JSON")
 	sendMessageCmd.Flags().String("content-file", "", "消息内容 JSON 文件")
 	sendMessageCmd.Flags().StringP("text", "t", "", "简单文本消息")
-	sendMessageCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(sendMessageCmd)
 	mustMarkFlagRequired(sendMessageCmd, "receive-id-type", "receive-id")
 }
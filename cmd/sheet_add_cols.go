@@ -17,11 +17,21 @@ var sheetAddColsCmd = &cobra.Command{
 		sheetID := args[1]
 		count, _ := cmd.Flags().GetInt("count")
 
-		err := client.AddDimension(client.Context(), spreadsheetToken, sheetID, "COLUMNS", count)
+		err := client.AddDimension(cmd.Context(), spreadsheetToken, sheetID, "COLUMNS", count)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"dimension":         "COLUMNS",
+				"count":             count,
+			})
+		}
+
 		fmt.Printf("成功添加 %d 列\n", count)
 		return nil
 	},
@@ -31,4 +41,5 @@ func init() {
 	sheetCmd.AddCommand(sheetAddColsCmd)
 
 	sheetAddColsCmd.Flags().IntP("count", "n", 1, "添加的列数")
+	addOutputFlag(sheetAddColsCmd)
 }
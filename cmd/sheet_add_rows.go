@@ -17,11 +17,21 @@ var sheetAddRowsCmd = &cobra.Command{
 		sheetID := args[1]
 		count, _ := cmd.Flags().GetInt("count")
 
-		err := client.AddDimension(client.Context(), spreadsheetToken, sheetID, "ROWS", count)
+		err := client.AddDimension(cmd.Context(), spreadsheetToken, sheetID, "ROWS", count)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"dimension":         "ROWS",
+				"count":             count,
+			})
+		}
+
 		fmt.Printf("成功添加 %d 行\n", count)
 		return nil
 	},
@@ -31,4 +41,5 @@ func init() {
 	sheetCmd.AddCommand(sheetAddRowsCmd)
 
 	sheetAddRowsCmd.Flags().IntP("count", "n", 1, "添加的行数")
+	addOutputFlag(sheetAddRowsCmd)
 }
@@ -18,13 +18,13 @@ var sheetAddSheetCmd = &cobra.Command{
 		index, _ := cmd.Flags().GetInt("index")
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.AddSheet(client.Context(), spreadsheetToken, title, index)
+		info, err := client.AddSheet(cmd.Context(), spreadsheetToken, title, index)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -43,5 +43,5 @@ func init() {
 
 	sheetAddSheetCmd.Flags().StringP("title", "t", "新工作表", "工作表标题")
 	sheetAddSheetCmd.Flags().Int("index", 0, "工作表位置索引（0 表示第一个）")
-	sheetAddSheetCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetAddSheetCmd)
 }
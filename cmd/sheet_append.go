@@ -55,13 +55,13 @@ var sheetAppendCmd = &cobra.Command{
 			return fmt.Errorf("解析数据失败: %w", err)
 		}
 
-		result, err := client.AppendCells(client.Context(), spreadsheetToken, rangeStr, values, insertOption)
+		result, err := client.AppendCells(cmd.Context(), spreadsheetToken, rangeStr, values, insertOption)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -81,5 +81,5 @@ func init() {
 	sheetAppendCmd.Flags().StringP("data", "d", "", "要追加的数据（JSON 二维数组）")
 	sheetAppendCmd.Flags().String("data-file", "", "数据文件路径")
 	sheetAppendCmd.Flags().String("insert-option", "", "插入选项: OVERWRITE, INSERT_ROWS")
-	sheetAppendCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetAppendCmd)
 }
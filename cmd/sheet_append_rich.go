@@ -85,11 +85,21 @@ var sheetAppendRichCmd = &cobra.Command{
 			}
 		}
 
-		err := client.AppendCellsV3(client.Context(), spreadsheetToken, sheetID, rangeStr, values, userIDType)
+		err := client.AppendCellsV3(cmd.Context(), spreadsheetToken, sheetID, rangeStr, values, userIDType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"range":             rangeStr,
+				"rows":              len(values),
+			})
+		}
+
 		fmt.Printf("追加成功！\n")
 		fmt.Printf("  工作表: %s\n", sheetID)
 		fmt.Printf("  追加范围: %s\n", rangeStr)
@@ -106,4 +116,5 @@ func init() {
 	sheetAppendRichCmd.Flags().String("data-file", "", "数据文件路径")
 	sheetAppendRichCmd.Flags().String("user-id-type", "", "用户 ID 类型: open_id, union_id, user_id")
 	sheetAppendRichCmd.Flags().Bool("simple", false, "使用简单模式（二维数组自动转换）")
+	addOutputFlag(sheetAppendRichCmd)
 }
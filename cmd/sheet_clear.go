@@ -40,11 +40,20 @@ var sheetClearCmd = &cobra.Command{
 			return fmt.Errorf("单次最多只能清除 10 个范围，当前传入 %d 个", len(ranges))
 		}
 
-		err := client.ClearCellsV3(client.Context(), spreadsheetToken, sheetID, ranges)
+		err := client.ClearCellsV3(cmd.Context(), spreadsheetToken, sheetID, ranges)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"ranges":            ranges,
+			})
+		}
+
 		fmt.Printf("清除成功！\n")
 		fmt.Printf("  工作表: %s\n", sheetID)
 		fmt.Printf("  清除范围: %v\n", ranges)
@@ -55,4 +64,5 @@ var sheetClearCmd = &cobra.Command{
 
 func init() {
 	sheetCmd.AddCommand(sheetClearCmd)
+	addOutputFlag(sheetClearCmd)
 }
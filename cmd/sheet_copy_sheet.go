@@ -18,13 +18,13 @@ var sheetCopySheetCmd = &cobra.Command{
 		newTitle, _ := cmd.Flags().GetString("title")
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.CopySheet(client.Context(), spreadsheetToken, sourceSheetID, newTitle)
+		info, err := client.CopySheet(cmd.Context(), spreadsheetToken, sourceSheetID, newTitle)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -41,5 +41,5 @@ func init() {
 	sheetCmd.AddCommand(sheetCopySheetCmd)
 
 	sheetCopySheetCmd.Flags().StringP("title", "t", "", "新工作表标题（可选）")
-	sheetCopySheetCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetCopySheetCmd)
 }
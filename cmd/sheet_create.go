@@ -16,13 +16,13 @@ var sheetCreateCmd = &cobra.Command{
 		folderToken, _ := cmd.Flags().GetString("folder")
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.CreateSpreadsheet(client.Context(), title, folderToken)
+		info, err := client.CreateSpreadsheet(cmd.Context(), title, folderToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -41,5 +41,5 @@ func init() {
 
 	sheetCreateCmd.Flags().StringP("title", "t", "新建电子表格", "表格标题")
 	sheetCreateCmd.Flags().StringP("folder", "f", "", "目标文件夹 Token（可选）")
-	sheetCreateCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetCreateCmd)
 }
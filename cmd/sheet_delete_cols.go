@@ -22,11 +22,22 @@ var sheetDeleteColsCmd = &cobra.Command{
 			endIndex = startIndex + 1
 		}
 
-		err := client.DeleteDimension(client.Context(), spreadsheetToken, sheetID, "COLUMNS", startIndex, endIndex)
+		err := client.DeleteDimension(cmd.Context(), spreadsheetToken, sheetID, "COLUMNS", startIndex, endIndex)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"dimension":         "COLUMNS",
+				"start":             startIndex,
+				"end":               endIndex,
+			})
+		}
+
 		fmt.Printf("成功删除第 %d 到 %d 列\n", startIndex+1, endIndex)
 		return nil
 	},
@@ -37,5 +48,6 @@ func init() {
 
 	sheetDeleteColsCmd.Flags().Int("start", 0, "起始列号（从 0 开始，A=0）")
 	sheetDeleteColsCmd.Flags().Int("end", 0, "结束列号（不包含）")
+	addOutputFlag(sheetDeleteColsCmd)
 	mustMarkFlagRequired(sheetDeleteColsCmd, "start")
 }
@@ -22,11 +22,22 @@ var sheetDeleteRowsCmd = &cobra.Command{
 			endIndex = startIndex + 1
 		}
 
-		err := client.DeleteDimension(client.Context(), spreadsheetToken, sheetID, "ROWS", startIndex, endIndex)
+		err := client.DeleteDimension(cmd.Context(), spreadsheetToken, sheetID, "ROWS", startIndex, endIndex)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"dimension":         "ROWS",
+				"start":             startIndex,
+				"end":               endIndex,
+			})
+		}
+
 		fmt.Printf("成功删除第 %d 到 %d 行\n", startIndex+1, endIndex)
 		return nil
 	},
@@ -37,5 +48,6 @@ func init() {
 
 	sheetDeleteRowsCmd.Flags().Int("start", 0, "起始行号（从 0 开始）")
 	sheetDeleteRowsCmd.Flags().Int("end", 0, "结束行号（不包含）")
+	addOutputFlag(sheetDeleteRowsCmd)
 	mustMarkFlagRequired(sheetDeleteRowsCmd, "start")
 }
@@ -16,11 +16,20 @@ var sheetDeleteSheetCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		sheetID := args[1]
 
-		err := client.DeleteSheet(client.Context(), spreadsheetToken, sheetID)
+		err := client.DeleteSheet(cmd.Context(), spreadsheetToken, sheetID)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"success":           true,
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+			})
+		}
+
 		fmt.Printf("删除成功！工作表 ID: %s\n", sheetID)
 		return nil
 	},
@@ -28,4 +37,5 @@ var sheetDeleteSheetCmd = &cobra.Command{
 
 func init() {
 	sheetCmd.AddCommand(sheetDeleteSheetCmd)
+	addOutputFlag(sheetDeleteSheetCmd)
 }
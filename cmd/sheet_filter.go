@@ -27,7 +27,7 @@ var sheetFilterCreateCmd = &cobra.Command{
 		sheetID := args[1]
 		rangeStr := unescapeSheetRange(args[2])
 
-		err := client.CreateFilter(client.Context(), spreadsheetToken, sheetID, rangeStr, nil)
+		err := client.CreateFilter(cmd.Context(), spreadsheetToken, sheetID, rangeStr, nil)
 		if err != nil {
 			return err
 		}
@@ -47,13 +47,13 @@ var sheetFilterGetCmd = &cobra.Command{
 		sheetID := args[1]
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.GetFilter(client.Context(), spreadsheetToken, sheetID)
+		info, err := client.GetFilter(cmd.Context(), spreadsheetToken, sheetID)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -77,7 +77,7 @@ var sheetFilterDeleteCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		sheetID := args[1]
 
-		err := client.DeleteFilter(client.Context(), spreadsheetToken, sheetID)
+		err := client.DeleteFilter(cmd.Context(), spreadsheetToken, sheetID)
 		if err != nil {
 			return err
 		}
@@ -94,5 +94,5 @@ func init() {
 	sheetFilterCmd.AddCommand(sheetFilterGetCmd)
 	sheetFilterCmd.AddCommand(sheetFilterDeleteCmd)
 
-	sheetFilterGetCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetFilterGetCmd)
 }
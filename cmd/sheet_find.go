@@ -32,13 +32,13 @@ var sheetFindCmd = &cobra.Command{
 		searchByRegex, _ := cmd.Flags().GetBool("regex")
 		output, _ := cmd.Flags().GetString("output")
 
-		result, err := client.FindCells(client.Context(), spreadsheetToken, sheetID, keyword, matchCase, matchEntireCell, searchByRegex, rangeStr)
+		result, err := client.FindCells(cmd.Context(), spreadsheetToken, sheetID, keyword, matchCase, matchEntireCell, searchByRegex, rangeStr)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -64,5 +64,5 @@ func init() {
 	sheetFindCmd.Flags().Bool("match-case", false, "区分大小写")
 	sheetFindCmd.Flags().Bool("match-entire-cell", false, "完全匹配单元格")
 	sheetFindCmd.Flags().Bool("regex", false, "使用正则表达式")
-	sheetFindCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetFindCmd)
 }
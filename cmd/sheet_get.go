@@ -16,13 +16,13 @@ var sheetGetCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		output, _ := cmd.Flags().GetString("output")
 
-		info, err := client.GetSpreadsheet(client.Context(), spreadsheetToken)
+		info, err := client.GetSpreadsheet(cmd.Context(), spreadsheetToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(info); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(info); err != nil {
 				return err
 			}
 		} else {
@@ -44,5 +44,5 @@ var sheetGetCmd = &cobra.Command{
 func init() {
 	sheetCmd.AddCommand(sheetGetCmd)
 
-	sheetGetCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetGetCmd)
 }
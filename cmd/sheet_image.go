@@ -42,13 +42,13 @@ var sheetImageAddCmd = &cobra.Command{
 			OffsetY:         offsetY,
 		}
 
-		result, err := client.CreateFloatImage(client.Context(), spreadsheetToken, sheetID, image)
+		result, err := client.CreateFloatImage(cmd.Context(), spreadsheetToken, sheetID, image)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -71,13 +71,13 @@ var sheetImageListCmd = &cobra.Command{
 		sheetID := args[1]
 		output, _ := cmd.Flags().GetString("output")
 
-		images, err := client.QueryFloatImages(client.Context(), spreadsheetToken, sheetID)
+		images, err := client.QueryFloatImages(cmd.Context(), spreadsheetToken, sheetID)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(images); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(images); err != nil {
 				return err
 			}
 		} else {
@@ -106,7 +106,7 @@ var sheetImageDeleteCmd = &cobra.Command{
 		sheetID := args[1]
 		floatImageID := args[2]
 
-		err := client.DeleteFloatImage(client.Context(), spreadsheetToken, sheetID, floatImageID)
+		err := client.DeleteFloatImage(cmd.Context(), spreadsheetToken, sheetID, floatImageID)
 		if err != nil {
 			return err
 		}
@@ -129,8 +129,8 @@ func init() {
 	sheetImageAddCmd.Flags().Float64("height", 100, "图片高度（像素，最小 20）")
 	sheetImageAddCmd.Flags().Float64("offset-x", 0, "水平偏移")
 	sheetImageAddCmd.Flags().Float64("offset-y", 0, "垂直偏移")
-	sheetImageAddCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetImageAddCmd)
 	mustMarkFlagRequired(sheetImageAddCmd, "token", "range")
 
-	sheetImageListCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetImageListCmd)
 }
@@ -83,11 +83,21 @@ var sheetInsertCmd = &cobra.Command{
 			}
 		}
 
-		err := client.InsertCellsV3(client.Context(), spreadsheetToken, sheetID, rangeStr, values, userIDType)
+		err := client.InsertCellsV3(cmd.Context(), spreadsheetToken, sheetID, rangeStr, values, userIDType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"range":             rangeStr,
+				"rows":              len(values),
+			})
+		}
+
 		fmt.Printf("插入成功！\n")
 		fmt.Printf("  工作表: %s\n", sheetID)
 		fmt.Printf("  插入范围: %s\n", rangeStr)
@@ -104,4 +114,5 @@ func init() {
 	sheetInsertCmd.Flags().String("data-file", "", "数据文件路径")
 	sheetInsertCmd.Flags().String("user-id-type", "", "用户 ID 类型: open_id, union_id, user_id")
 	sheetInsertCmd.Flags().Bool("simple", false, "使用简单模式（二维数组自动转换）")
+	addOutputFlag(sheetInsertCmd)
 }
@@ -23,11 +23,22 @@ var sheetInsertRowsCmd = &cobra.Command{
 			endIndex = startIndex + 1
 		}
 
-		err := client.InsertDimension(client.Context(), spreadsheetToken, sheetID, "ROWS", startIndex, endIndex, inheritStyle)
+		err := client.InsertDimension(cmd.Context(), spreadsheetToken, sheetID, "ROWS", startIndex, endIndex, inheritStyle)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"dimension":         "ROWS",
+				"start":             startIndex,
+				"end":               endIndex,
+			})
+		}
+
 		fmt.Printf("成功在位置 %d 插入 %d 行\n", startIndex, endIndex-startIndex)
 		return nil
 	},
@@ -39,5 +50,6 @@ func init() {
 	sheetInsertRowsCmd.Flags().Int("start", 0, "起始行号（从 0 开始）")
 	sheetInsertRowsCmd.Flags().Int("end", 0, "结束行号（不包含）")
 	sheetInsertRowsCmd.Flags().String("inherit-style", "", "继承样式: BEFORE, AFTER")
+	addOutputFlag(sheetInsertRowsCmd)
 	mustMarkFlagRequired(sheetInsertRowsCmd, "start")
 }
@@ -16,13 +16,13 @@ var sheetListSheetsCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		output, _ := cmd.Flags().GetString("output")
 
-		sheets, err := client.QuerySheets(client.Context(), spreadsheetToken)
+		sheets, err := client.QuerySheets(cmd.Context(), spreadsheetToken)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(sheets); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(sheets); err != nil {
 				return err
 			}
 		} else {
@@ -44,5 +44,5 @@ var sheetListSheetsCmd = &cobra.Command{
 func init() {
 	sheetCmd.AddCommand(sheetListSheetsCmd)
 
-	sheetListSheetsCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetListSheetsCmd)
 }
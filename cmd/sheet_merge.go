@@ -25,11 +25,20 @@ var sheetMergeCmd = &cobra.Command{
 		rangeStr := unescapeSheetRange(args[1])
 		mergeType, _ := cmd.Flags().GetString("type")
 
-		err := client.MergeCells(client.Context(), spreadsheetToken, rangeStr, mergeType)
+		err := client.MergeCells(cmd.Context(), spreadsheetToken, rangeStr, mergeType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"range":             rangeStr,
+				"merge_type":        mergeType,
+			})
+		}
+
 		fmt.Printf("合并成功！范围: %s, 类型: %s\n", rangeStr, mergeType)
 		return nil
 	},
@@ -39,4 +48,5 @@ func init() {
 	sheetCmd.AddCommand(sheetMergeCmd)
 
 	sheetMergeCmd.Flags().String("type", "MERGE_ALL", "合并类型: MERGE_ALL, MERGE_ROWS, MERGE_COLUMNS")
+	addOutputFlag(sheetMergeCmd)
 }
@@ -21,13 +21,13 @@ var sheetMetaCmd = &cobra.Command{
 		extFields, _ := cmd.Flags().GetString("ext-fields")
 		output, _ := cmd.Flags().GetString("output")
 
-		meta, err := client.GetSpreadsheetMeta(client.Context(), spreadsheetToken, extFields)
+		meta, err := client.GetSpreadsheetMeta(cmd.Context(), spreadsheetToken, extFields)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(meta); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(meta); err != nil {
 				return err
 			}
 		} else {
@@ -68,5 +68,5 @@ func init() {
 	sheetCmd.AddCommand(sheetMetaCmd)
 
 	sheetMetaCmd.Flags().String("ext-fields", "", "扩展字段（逗号分隔）: protectedRange, mergedCell")
-	sheetMetaCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetMetaCmd)
 }
@@ -41,13 +41,13 @@ var sheetProtectCmd = &cobra.Command{
 			},
 		}
 
-		protectIDs, err := client.CreateProtectedRange(client.Context(), spreadsheetToken, ranges)
+		protectIDs, err := client.CreateProtectedRange(cmd.Context(), spreadsheetToken, ranges)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(map[string]any{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]any{
 				"protect_ids": protectIDs,
 			}); err != nil {
 				return err
@@ -73,7 +73,7 @@ var sheetUnprotectCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		protectIDs := args[1:]
 
-		err := client.DeleteProtectedRange(client.Context(), spreadsheetToken, protectIDs)
+		err := client.DeleteProtectedRange(cmd.Context(), spreadsheetToken, protectIDs)
 		if err != nil {
 			return err
 		}
@@ -91,6 +91,6 @@ func init() {
 	sheetProtectCmd.Flags().Int("start", 0, "起始索引")
 	sheetProtectCmd.Flags().Int("end", 0, "结束索引")
 	sheetProtectCmd.Flags().String("lock-info", "", "锁定说明")
-	sheetProtectCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetProtectCmd)
 	mustMarkFlagRequired(sheetProtectCmd, "end")
 }
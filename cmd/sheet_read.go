@@ -39,13 +39,13 @@ var sheetReadCmd = &cobra.Command{
 			rangeStr = sheetID + "!" + rangeStr
 		}
 
-		cellRange, err := client.ReadCells(client.Context(), spreadsheetToken, rangeStr, valueRenderOption, dateTimeRenderOption)
+		cellRange, err := client.ReadCells(cmd.Context(), spreadsheetToken, rangeStr, valueRenderOption, dateTimeRenderOption)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(cellRange); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(cellRange); err != nil {
 				return err
 			}
 		} else {
@@ -74,5 +74,5 @@ func init() {
 	sheetReadCmd.Flags().String("sheet-id", "", "工作表 ID（如果范围中未指定）")
 	sheetReadCmd.Flags().String("value-render", "", "值渲染选项: ToString, FormattedValue, Formula, UnformattedValue")
 	sheetReadCmd.Flags().String("datetime-render", "", "日期时间渲染选项: FormattedString")
-	sheetReadCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetReadCmd)
 }
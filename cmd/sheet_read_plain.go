@@ -39,13 +39,13 @@ var sheetReadPlainCmd = &cobra.Command{
 			ranges[i] = unescapeSheetRange(ranges[i])
 		}
 
-		result, err := client.ReadCellsPlainV3(client.Context(), spreadsheetToken, sheetID, ranges)
+		result, err := client.ReadCellsPlainV3(cmd.Context(), spreadsheetToken, sheetID, ranges)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -73,5 +73,5 @@ var sheetReadPlainCmd = &cobra.Command{
 func init() {
 	sheetCmd.AddCommand(sheetReadPlainCmd)
 
-	sheetReadPlainCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetReadPlainCmd)
 }
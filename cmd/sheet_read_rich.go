@@ -45,13 +45,13 @@ var sheetReadRichCmd = &cobra.Command{
 			ranges[i] = unescapeSheetRange(ranges[i])
 		}
 
-		result, err := client.ReadCellsRichV3(client.Context(), spreadsheetToken, sheetID, ranges, dateTimeRender, valueRender, userIDType)
+		result, err := client.ReadCellsRichV3(cmd.Context(), spreadsheetToken, sheetID, ranges, dateTimeRender, valueRender, userIDType)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -140,5 +140,5 @@ func init() {
 	sheetReadRichCmd.Flags().String("datetime-render", "", "日期时间渲染选项: formatted_string, serial_number")
 	sheetReadRichCmd.Flags().String("value-render", "", "数值渲染选项: formatted_value, unformatted_value")
 	sheetReadRichCmd.Flags().String("user-id-type", "", "用户 ID 类型: open_id, union_id, user_id")
-	sheetReadRichCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetReadRichCmd)
 }
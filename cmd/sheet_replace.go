@@ -29,13 +29,13 @@ var sheetReplaceCmd = &cobra.Command{
 		matchEntireCell, _ := cmd.Flags().GetBool("match-entire-cell")
 		output, _ := cmd.Flags().GetString("output")
 
-		result, err := client.ReplaceCells(client.Context(), spreadsheetToken, sheetID, findStr, replacement, matchCase, matchEntireCell, rangeStr)
+		result, err := client.ReplaceCells(cmd.Context(), spreadsheetToken, sheetID, findStr, replacement, matchCase, matchEntireCell, rangeStr)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -57,5 +57,5 @@ func init() {
 	sheetReplaceCmd.Flags().String("range", "", "替换范围（如 A1:C10）")
 	sheetReplaceCmd.Flags().Bool("match-case", false, "区分大小写")
 	sheetReplaceCmd.Flags().Bool("match-entire-cell", false, "完全匹配单元格")
-	sheetReplaceCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetReplaceCmd)
 }
@@ -73,11 +73,20 @@ var sheetStyleCmd = &cobra.Command{
 			}
 		}
 
-		err := client.SetCellStyle(client.Context(), spreadsheetToken, rangeStr, style)
+		err := client.SetCellStyle(cmd.Context(), spreadsheetToken, rangeStr, style)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"range":             rangeStr,
+				"style":             style,
+			})
+		}
+
 		fmt.Printf("样式设置成功！范围: %s\n", rangeStr)
 		return nil
 	},
@@ -95,4 +104,5 @@ func init() {
 	sheetStyleCmd.Flags().String("fore-color", "", "字体颜色（如 #0000FF）")
 	sheetStyleCmd.Flags().String("formatter", "", "数字格式（如 yyyy/MM/dd）")
 	sheetStyleCmd.Flags().Bool("clean", false, "清除样式")
+	addOutputFlag(sheetStyleCmd)
 }
@@ -19,11 +19,19 @@ var sheetUnmergeCmd = &cobra.Command{
 		spreadsheetToken := args[0]
 		rangeStr := unescapeSheetRange(args[1])
 
-		err := client.UnmergeCells(client.Context(), spreadsheetToken, rangeStr)
+		err := client.UnmergeCells(cmd.Context(), spreadsheetToken, rangeStr)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"range":             rangeStr,
+			})
+		}
+
 		fmt.Printf("取消合并成功！范围: %s\n", rangeStr)
 		return nil
 	},
@@ -31,4 +39,5 @@ var sheetUnmergeCmd = &cobra.Command{
 
 func init() {
 	sheetCmd.AddCommand(sheetUnmergeCmd)
+	addOutputFlag(sheetUnmergeCmd)
 }
@@ -61,13 +61,13 @@ var sheetWriteCmd = &cobra.Command{
 			return fmt.Errorf("解析数据失败（需要 JSON 二维数组）: %w", err)
 		}
 
-		result, err := client.WriteCells(client.Context(), spreadsheetToken, rangeStr, values)
+		result, err := client.WriteCells(cmd.Context(), spreadsheetToken, rangeStr, values)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(result); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(result); err != nil {
 				return err
 			}
 		} else {
@@ -86,5 +86,5 @@ func init() {
 	sheetWriteCmd.Flags().String("sheet-id", "", "工作表 ID（如果范围中未指定）")
 	sheetWriteCmd.Flags().StringP("data", "d", "", "要写入的数据（JSON 二维数组）")
 	sheetWriteCmd.Flags().String("data-file", "", "数据文件路径")
-	sheetWriteCmd.Flags().StringP("output", "o", "text", "输出格式: text, json")
+	addOutputFlag(sheetWriteCmd)
 }
@@ -82,11 +82,20 @@ var sheetWriteRichCmd = &cobra.Command{
 			return fmt.Errorf("解析数据失败（需要 value_ranges JSON 数组）: %w", err)
 		}
 
-		err := client.WriteCellsV3(client.Context(), spreadsheetToken, sheetID, valueRanges, userIDType)
+		err := client.WriteCellsV3(cmd.Context(), spreadsheetToken, sheetID, valueRanges, userIDType)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"spreadsheet_token": spreadsheetToken,
+				"sheet_id":          sheetID,
+				"ranges":            len(valueRanges),
+			})
+		}
+
 		fmt.Printf("写入成功！\n")
 		fmt.Printf("  工作表: %s\n", sheetID)
 		fmt.Printf("  写入范围数: %d\n", len(valueRanges))
@@ -101,4 +110,5 @@ func init() {
 	sheetWriteRichCmd.Flags().StringP("data", "d", "", "要写入的数据（value_ranges JSON 数组）")
 	sheetWriteRichCmd.Flags().String("data-file", "", "数据文件路径")
 	sheetWriteRichCmd.Flags().String("user-id-type", "", "用户 ID 类型: open_id, union_id, user_id")
+	addOutputFlag(sheetWriteRichCmd)
 }
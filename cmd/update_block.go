@@ -17,15 +17,21 @@ var updateBlockCmd = &cobra.Command{
 
 内容应为 JSON 格式的更新请求体。
 
+并发控制:
+  --expect-revision 指定读取文档时的版本号，文档已被修改时拒绝写入并以退出码 3 退出；
+  同时指定 --rebase 时，若目标块在该版本之后未被修改，则基于最新版本继续写入。
+
 示例:
   feishu-cli doc update DOC_ID BLOCK_ID --content '{"update_text_elements":{"elements":[{"text_run":{"content":"已更新"}}]}}'
-  feishu-cli doc update DOC_ID BLOCK_ID --content-file update.json`,
+  feishu-cli doc update DOC_ID BLOCK_ID --content-file update.json
+  feishu-cli doc update DOC_ID BLOCK_ID --content-file update.json --expect-revision 42 --rebase`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := config.Validate(); err != nil {
 			return err
 		}
 
+		ctx := cmd.Context()
 		documentID := args[0]
 		blockID := args[1]
 		contentStr, _ := cmd.Flags().GetString("content")
@@ -51,11 +57,27 @@ var updateBlockCmd = &cobra.Command{
 			return fmt.Errorf("解析内容 JSON 失败: %w", err)
 		}
 
-		if err := client.UpdateBlock(documentID, blockID, updateContent); err != nil {
+		revision, err := newRevisionGuard(cmd, documentID).resolve(ctx, blockID)
+		if err != nil {
+			return err
+		}
+
+		newRevision, err := client.UpdateBlockAtRevision(ctx, documentID, blockID, updateContent, revision)
+		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"document_id": documentID,
+				"block_id":    blockID,
+				"revision":    newRevision,
+			})
+		}
+
 		fmt.Printf("块 %s 更新成功！\n", blockID)
+		fmt.Printf("  文档版本: %d\n", newRevision)
 		return nil
 	},
 }
@@ -64,4 +86,6 @@ func init() {
 	docCmd.AddCommand(updateBlockCmd)
 	updateBlockCmd.Flags().StringP("content", "c", "", "更新内容 (JSON 格式)")
 	updateBlockCmd.Flags().String("content-file", "", "包含更新内容的 JSON 文件")
+	addRevisionFlags(updateBlockCmd)
+	addOutputFlag(updateBlockCmd)
 }
@@ -53,6 +53,7 @@ var updateEventCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		calendarID := args[0]
 		eventID := args[1]
 
@@ -78,13 +79,13 @@ var updateEventCmd = &cobra.Command{
 			Location:    location,
 		}
 
-		event, err := client.UpdateEvent(params)
+		event, err := client.UpdateEvent(ctx, params)
 		if err != nil {
 			return err
 		}
 
-		if output == "json" {
-			if err := printJSON(event); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(event); err != nil {
 				return err
 			}
 		} else {
@@ -115,5 +116,5 @@ func init() {
 	updateEventCmd.Flags().String("end", "", "结束时间，RFC3339 格式")
 	updateEventCmd.Flags().StringP("description", "d", "", "日程描述")
 	updateEventCmd.Flags().StringP("location", "l", "", "地点")
-	updateEventCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(updateEventCmd)
 }
@@ -55,16 +55,28 @@ var updatePermissionCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		docToken := args[0]
 		docType, _ := cmd.Flags().GetString("doc-type")
 		memberType, _ := cmd.Flags().GetString("member-type")
 		memberID, _ := cmd.Flags().GetString("member-id")
 		perm, _ := cmd.Flags().GetString("perm")
 
-		if err := client.UpdatePermission(docToken, docType, memberID, memberType, perm); err != nil {
+		if err := client.UpdatePermission(ctx, docToken, docType, memberID, memberType, perm); err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"doc_token":   docToken,
+				"doc_type":    docType,
+				"member_type": memberType,
+				"member_id":   memberID,
+				"perm":        perm,
+			})
+		}
+
 		fmt.Printf("权限更新成功！\n")
 		fmt.Printf("  文档: %s\n", docToken)
 		fmt.Printf("  成员: %s（%s）\n", memberID, memberType)
@@ -79,5 +91,6 @@ func init() {
 	updatePermissionCmd.Flags().String("member-type", "", "成员类型（email/openid/userid 等）")
 	updatePermissionCmd.Flags().String("member-id", "", "成员标识")
 	updatePermissionCmd.Flags().String("perm", "", "新权限级别（view/edit/full_access）")
+	addOutputFlag(updatePermissionCmd)
 	mustMarkFlagRequired(updatePermissionCmd, "member-type", "member-id", "perm")
 }
@@ -42,6 +42,7 @@ var updateTaskCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		taskGuid := args[0]
 
 		summary, _ := cmd.Flags().GetString("summary")
@@ -69,14 +70,14 @@ var updateTaskCmd = &cobra.Command{
 			return fmt.Errorf("请指定要更新的字段（--summary, --description, --due 或 --completed）")
 		}
 
-		task, err := client.UpdateTask(taskGuid, opts)
+		task, err := client.UpdateTask(ctx, taskGuid, opts)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(task); err != nil {
+		if isStructuredOutput(output) {
+			if err := printOutput(task); err != nil {
 				return err
 			}
 		} else {
@@ -104,5 +105,5 @@ func init() {
 	updateTaskCmd.Flags().StringP("description", "d", "", "新的任务描述")
 	updateTaskCmd.Flags().String("due", "", "新的截止时间（格式: 2006-01-02 15:04:05）")
 	updateTaskCmd.Flags().Bool("completed", false, "标记任务为已完成")
-	updateTaskCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(updateTaskCmd)
 }
@@ -30,6 +30,7 @@ var updateWikiNodeCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		nodeToken, err := extractWikiToken(args[0])
 		if err != nil {
 			return err
@@ -37,16 +38,25 @@ var updateWikiNodeCmd = &cobra.Command{
 		title, _ := cmd.Flags().GetString("title")
 
 		// 先获取节点信息以获取 space_id
-		node, err := client.GetWikiNode(nodeToken)
+		node, err := client.GetWikiNode(ctx, nodeToken)
 		if err != nil {
 			return fmt.Errorf("获取节点信息失败: %w", err)
 		}
 
-		err = client.UpdateWikiNode(node.SpaceID, nodeToken, title)
+		err = client.UpdateWikiNode(ctx, node.SpaceID, nodeToken, title)
 		if err != nil {
 			return err
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if isStructuredOutput(output) {
+			return printOutput(map[string]any{
+				"node_token": nodeToken,
+				"space_id":   node.SpaceID,
+				"title":      title,
+			})
+		}
+
 		fmt.Printf("知识库节点标题更新成功！\n")
 		fmt.Printf("  节点 Token: %s\n", nodeToken)
 		fmt.Printf("  新标题:     %s\n", title)
@@ -58,5 +68,6 @@ var updateWikiNodeCmd = &cobra.Command{
 func init() {
 	wikiCmd.AddCommand(updateWikiNodeCmd)
 	updateWikiNodeCmd.Flags().String("title", "", "新标题（必填）")
+	addOutputFlag(updateWikiNodeCmd)
 	mustMarkFlagRequired(updateWikiNodeCmd, "title")
 }
@@ -41,6 +41,7 @@ var uploadMediaCmd = &cobra.Command{
 			return err
 		}
 
+		ctx := cmd.Context()
 		filePath := args[0]
 		parentType, _ := cmd.Flags().GetString("parent-type")
 		parentNode, _ := cmd.Flags().GetString("parent-node")
@@ -58,14 +59,14 @@ var uploadMediaCmd = &cobra.Command{
 			fileName = filepath.Base(filePath)
 		}
 
-		token, err := client.UploadMedia(filePath, parentType, parentNode, fileName)
+		token, err := client.UploadMedia(ctx, filePath, parentType, parentNode, fileName)
 		if err != nil {
 			return err
 		}
 
 		output, _ := cmd.Flags().GetString("output")
-		if output == "json" {
-			if err := printJSON(map[string]string{
+		if isStructuredOutput(output) {
+			if err := printOutput(map[string]string{
 				"file_token": token,
 			}); err != nil {
 				return err
@@ -84,6 +85,6 @@ func init() {
 	uploadMediaCmd.Flags().String("parent-type", "docx_image", "父节点类型（docx_image/docx_file/doc_image/doc_file）")
 	uploadMediaCmd.Flags().String("parent-node", "", "父节点 token（文档ID）")
 	uploadMediaCmd.Flags().String("name", "", "文件名（默认使用原文件名）")
-	uploadMediaCmd.Flags().StringP("output", "o", "", "输出格式（json）")
+	addOutputFlag(uploadMediaCmd)
 	mustMarkFlagRequired(uploadMediaCmd, "parent-node")
 }
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,20 +20,14 @@ func mustMarkFlagRequired(cmd *cobra.Command, flags ...string) {
 	}
 }
 
-// printJSON 安全地打印 JSON 格式的数据
-// 如果序列化失败，会返回错误而不是静默忽略
-func printJSON(v any) error {
-	data, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		return fmt.Errorf("JSON 序列化失败: %w", err)
-	}
-	fmt.Println(string(data))
-	return nil
-}
-
 // confirmAction 在执行危险操作前请求用户确认
 // 返回 true 表示用户确认执行，false 表示取消
 func confirmAction(prompt string) bool {
+	// dry-run 不会发送写请求，无需确认，便于在非交互环境中预览
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "[dry-run] 跳过确认: %s\n", prompt)
+		return true
+	}
 	fmt.Printf("%s (y/N): ", prompt)
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
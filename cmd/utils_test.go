@@ -10,7 +10,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func TestPrintJSON_Success(t *testing.T) {
+func TestPrintOutput_Success(t *testing.T) {
 	// 保存原始 stdout
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -24,14 +24,14 @@ func TestPrintJSON_Success(t *testing.T) {
 		Age:  25,
 	}
 
-	err := printJSON(testData)
+	err := printOutput(testData)
 
 	// 恢复 stdout
 	w.Close()
 	os.Stdout = oldStdout
 
 	if err != nil {
-		t.Errorf("printJSON() 返回错误: %v", err)
+		t.Errorf("printOutput() 返回错误: %v", err)
 	}
 
 	var buf bytes.Buffer
@@ -46,29 +46,29 @@ func TestPrintJSON_Success(t *testing.T) {
 	}
 }
 
-func TestPrintJSON_Error(t *testing.T) {
+func TestPrintOutput_Error(t *testing.T) {
 	// channel 无法被 JSON 序列化
 	badData := make(chan int)
 
-	err := printJSON(badData)
+	err := printOutput(badData)
 	if err == nil {
-		t.Error("printJSON() 应返回错误，因为 channel 无法序列化")
+		t.Error("printOutput() 应返回错误，因为 channel 无法序列化")
 	}
 }
 
-func TestPrintJSON_Nil(t *testing.T) {
+func TestPrintOutput_Nil(t *testing.T) {
 	// 保存原始 stdout
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := printJSON(nil)
+	err := printOutput(nil)
 
 	w.Close()
 	os.Stdout = oldStdout
 
 	if err != nil {
-		t.Errorf("printJSON(nil) 返回错误: %v", err)
+		t.Errorf("printOutput(nil) 返回错误: %v", err)
 	}
 
 	var buf bytes.Buffer
@@ -80,18 +80,18 @@ func TestPrintJSON_Nil(t *testing.T) {
 	}
 }
 
-func TestPrintJSON_EmptyStruct(t *testing.T) {
+func TestPrintOutput_EmptyStruct(t *testing.T) {
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := printJSON(struct{}{})
+	err := printOutput(struct{}{})
 
 	w.Close()
 	os.Stdout = oldStdout
 
 	if err != nil {
-		t.Errorf("printJSON() 返回错误: %v", err)
+		t.Errorf("printOutput() 返回错误: %v", err)
 	}
 
 	var buf bytes.Buffer
@@ -103,19 +103,19 @@ func TestPrintJSON_EmptyStruct(t *testing.T) {
 	}
 }
 
-func TestPrintJSON_Slice(t *testing.T) {
+func TestPrintOutput_Slice(t *testing.T) {
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
 	testData := []string{"a", "b", "c"}
-	err := printJSON(testData)
+	err := printOutput(testData)
 
 	w.Close()
 	os.Stdout = oldStdout
 
 	if err != nil {
-		t.Errorf("printJSON() 返回错误: %v", err)
+		t.Errorf("printOutput() 返回错误: %v", err)
 	}
 
 	var buf bytes.Buffer
@@ -18,6 +18,7 @@ var wikiCmd = &cobra.Command{
   spaces    列出知识空间
   nodes     列出空间下的节点
   export    导出知识库文档为 Markdown
+  check-links 检查知识空间中的失效链接
 
 知识库 URL 格式:
   https://xxx.feishu.cn/wiki/<node_token>
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var wikiCheckLinksCmd = &cobra.Command{
+	Use:   "check-links <space_id>",
+	Short: "检查知识空间中的失效链接",
+	Long: `遍历知识空间中的所有新版文档（docx），检查其中的超链接和 @文档 引用。
+
+检查规则:
+  @文档、飞书文档链接   通过 API 查询目标文档，已删除或无权访问时报告失效；
+                        限流、网络错误等无法确定时列入 errors，不报告为失效
+  外部链接              默认不检查；指定 --external 后发送 HTTP HEAD 请求，
+                        返回 4xx/5xx 或请求失败时报告失效
+
+允许列表:
+  --allow-host 和 --allow-list 中的域名（含子域名）或 URL 前缀视为有效，不发送请求。
+  允许列表文件每行一个域名或 URL 前缀，# 开头为注释。
+
+输出包含来源文档、块 ID、链接目标和失效原因。
+
+示例:
+  feishu-cli wiki check-links 7012345678901234567
+  feishu-cli wiki check-links 7012345678901234567 --external --allow-list allow.txt
+  feishu-cli wiki check-links 7012345678901234567 -o json --exit-code`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		checker, err := newLinkChecker(cmd)
+		if err != nil {
+			return err
+		}
+
+		var sources []linkSource
+		if err := collectWikiSources(ctx, args[0], "", "", &sources); err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			fmt.Println("知识空间中没有新版文档")
+			return nil
+		}
+
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return printLinkReport(cmd, checker.run(ctx, sources, verbose))
+	},
+}
+
+// collectWikiSources 递归收集知识空间中 parentToken 节点下的所有 docx 文档
+func collectWikiSources(ctx context.Context, spaceID, parentToken, path string, sources *[]linkSource) error {
+	pageToken := ""
+	for {
+		nodes, nextPageToken, hasMore, err := client.ListWikiNodes(ctx, spaceID, parentToken, 50, pageToken)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			nodePath := path + "/" + node.Title
+			if node.ObjType == "docx" {
+				*sources = append(*sources, linkSource{DocumentID: node.ObjToken, Title: node.Title, Path: nodePath})
+			}
+			if node.HasChild {
+				if err := collectWikiSources(ctx, spaceID, node.NodeToken, nodePath, sources); err != nil {
+					return err
+				}
+			}
+		}
+		if !hasMore || nextPageToken == "" {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func init() {
+	wikiCmd.AddCommand(wikiCheckLinksCmd)
+	addLinkCheckFlags(wikiCheckLinksCmd)
+}
@@ -0,0 +1,106 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Action 可在计划中使用的操作
+type Action struct {
+	Description string
+	Params      []string // 支持的参数
+	Required    []string // 必填参数
+	Outputs     []string // 输出字段，后续步骤通过 ${{ steps.<id>.<字段> }} 引用
+	Run         func(ctx context.Context, p Params) (map[string]any, error)
+}
+
+// checkParams 检查未知参数和缺少的必填参数
+func (a Action) checkParams(with map[string]any) error {
+	allowed := map[string]bool{}
+	for _, name := range a.Params {
+		allowed[name] = true
+	}
+	var unknown []string
+	for key := range with {
+		if !allowed[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("未知参数 %s（支持: %s）", strings.Join(unknown, ", "), strings.Join(a.Params, ", "))
+	}
+	for _, name := range a.Required {
+		if _, ok := with[name]; !ok {
+			return fmt.Errorf("缺少必填参数 %s", name)
+		}
+	}
+	return nil
+}
+
+// Params 展开表达式后的步骤参数
+type Params map[string]any
+
+// String 返回字符串参数，未设置时返回空字符串
+func (p Params) String(key string) (string, error) {
+	switch v := p[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case int, int64, float64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("参数 %s 应为字符串", key)
+	}
+}
+
+// RequiredString 返回必填的字符串参数
+func (p Params) RequiredString(key string) (string, error) {
+	s, err := p.String(key)
+	if err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "", fmt.Errorf("参数 %s 不能为空", key)
+	}
+	return s, nil
+}
+
+// Bool 返回布尔参数，支持 "true"/"false" 字符串，未设置时返回 def
+func (p Params) Bool(key string, def bool) (bool, error) {
+	switch v := p[key].(type) {
+	case nil:
+		return def, nil
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("参数 %s 应为布尔值: %s", key, v)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf("参数 %s 应为布尔值", key)
+	}
+}
+
+// Rows 返回二维数组参数，如表格数据
+func (p Params) Rows(key string) ([][]any, error) {
+	list, ok := p[key].([]any)
+	if !ok {
+		return nil, fmt.Errorf("参数 %s 应为二维数组", key)
+	}
+	rows := make([][]any, 0, len(list))
+	for i, item := range list {
+		row, ok := item.([]any)
+		if !ok {
+			return nil, fmt.Errorf("参数 %s 的第 %d 行应为数组", key, i+1)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,182 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testActions 记录调用顺序的测试操作
+func testActions(calls *[]string, mu *sync.Mutex) map[string]Action {
+	return map[string]Action{
+		"echo": {
+			Params:   []string{"value", "fail"},
+			Required: []string{"value"},
+			Run: func(ctx context.Context, p Params) (map[string]any, error) {
+				value, err := p.String("value")
+				if err != nil {
+					return nil, err
+				}
+				mu.Lock()
+				*calls = append(*calls, value)
+				mu.Unlock()
+				if fail, _ := p.Bool("fail", false); fail {
+					return nil, errors.New("失败")
+				}
+				return map[string]any{"value": value, "list": []any{"a", "b"}}, nil
+			},
+		},
+	}
+}
+
+func runPlan(t *testing.T, src string, dryRun bool) ([]string, []*Result, error) {
+	t.Helper()
+	plan, err := ParsePlan([]byte(src))
+	if err != nil {
+		t.Fatalf("ParsePlan 返回错误: %v", err)
+	}
+	var calls []string
+	var mu sync.Mutex
+	var results []*Result
+	r := &Runner{
+		Actions:  testActions(&calls, &mu),
+		Vars:     map[string]any{"name": "覆盖"},
+		DryRun:   dryRun,
+		OnResult: func(res *Result) { results = append(results, res) },
+	}
+	_, err = r.Run(context.Background(), plan)
+	return calls, results, err
+}
+
+func statuses(results []*Result) string {
+	var s []string
+	for _, r := range results {
+		s = append(s, r.ID+"="+r.Status)
+	}
+	return strings.Join(s, ",")
+}
+
+func TestRunner(t *testing.T) {
+	calls, results, err := runPlan(t, `
+vars:
+  name: 原值
+  project: Apollo
+steps:
+  - id: a
+    action: echo
+    with:
+      value: ${{ vars.project }}-${{ vars.name }}
+  - id: b
+    action: echo
+    with:
+      value: ${{ steps.a.value }}/doc
+  - id: c
+    action: echo
+    with:
+      value: list=${{ steps.a.list }}
+`, false)
+	if err != nil {
+		t.Fatalf("Run 返回错误: %v", err)
+	}
+	if got := strings.Join(calls, "|"); got != `Apollo-覆盖|Apollo-覆盖/doc|list=["a","b"]` {
+		t.Errorf("调用参数 = %s", got)
+	}
+	if got := statuses(results); got != "a=success,b=success,c=success" {
+		t.Errorf("步骤状态 = %s", got)
+	}
+}
+
+func TestRunner_Failure(t *testing.T) {
+	calls, results, err := runPlan(t, `
+steps:
+  - id: a
+    action: echo
+    continue-on-error: true
+    with: {value: a, fail: true}
+  - id: b
+    action: echo
+    with: {value: "${{ steps.a.value }}"}
+  - id: c
+    action: echo
+    with: {value: c, fail: true}
+  - id: d
+    action: echo
+    with: {value: d}
+`, false)
+	if err == nil || !strings.Contains(err.Error(), "步骤 c 失败") {
+		t.Errorf("Run 错误 = %v, 期望步骤 c 失败", err)
+	}
+	if got := strings.Join(calls, "|"); got != "a|c" {
+		t.Errorf("调用 = %s, 期望 a|c", got)
+	}
+	if got := statuses(results); got != "a=failed,b=skipped,c=failed,d=skipped" {
+		t.Errorf("步骤状态 = %s", got)
+	}
+}
+
+func TestRunner_DryRun(t *testing.T) {
+	calls, results, err := runPlan(t, `
+steps:
+  - id: a
+    action: echo
+    with: {value: x}
+  - action: echo
+    with: {value: "${{ steps.a.value }}-y"}
+`, true)
+	if err != nil {
+		t.Fatalf("Run 返回错误: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("dry-run 不应执行操作, 调用了 %v", calls)
+	}
+	if got := statuses(results); got != "a=planned,step-2=planned" {
+		t.Errorf("步骤状态 = %s", got)
+	}
+	if got := results[1].Params["value"]; got != "${{ steps.a.value }}-y" {
+		t.Errorf("dry-run 应保留未执行步骤的表达式, got %v", got)
+	}
+}
+
+func TestRunner_Concurrency(t *testing.T) {
+	var steps strings.Builder
+	for i := 0; i < 6; i++ {
+		fmt.Fprintf(&steps, "  - action: echo\n    with: {value: v%d}\n", i)
+	}
+	calls, results, err := runPlan(t, "concurrency: 3\nsteps:\n"+steps.String(), false)
+	if err != nil {
+		t.Fatalf("Run 返回错误: %v", err)
+	}
+	if len(calls) != 6 || len(results) != 6 {
+		t.Errorf("应执行 6 个步骤, 调用 %d 次, 结果 %d 条", len(calls), len(results))
+	}
+}
+
+func TestPlanValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"未知操作", "steps:\n  - action: nope\n", "未知的操作"},
+		{"未知参数", "steps:\n  - action: echo\n    with: {value: x, typo: 1}\n", "未知参数 typo"},
+		{"缺少必填参数", "steps:\n  - action: echo\n", "缺少必填参数 value"},
+		{"引用后面的步骤", "steps:\n  - action: echo\n    with: {value: '${{ steps.b.x }}'}\n  - id: b\n    action: echo\n    with: {value: x}\n", "不存在或不在它之前"},
+		{"ID 重复", "steps:\n  - {id: a, action: echo, with: {value: x}}\n  - {id: a, action: echo, with: {value: x}}\n", "重复"},
+		{"无效表达式", "steps:\n  - action: echo\n    with: {value: '${{ foo.bar }}'}\n", "无效的表达式"},
+	}
+	var calls []string
+	var mu sync.Mutex
+	for _, tt := range tests {
+		plan, err := ParsePlan([]byte(tt.src))
+		if err != nil {
+			t.Fatalf("%s: ParsePlan 返回错误: %v", tt.name, err)
+		}
+		err = plan.Validate(testActions(&calls, &mu))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate 错误 = %v, 期望包含 %q", tt.name, err, tt.want)
+		}
+	}
+}
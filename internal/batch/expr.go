@@ -0,0 +1,192 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// exprPattern 匹配 ${{ vars.name }}、${{ steps.<id>.<字段> }} 和 ${{ env.NAME }}
+var exprPattern = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+// errUnresolved dry-run 时引用的步骤尚未执行，保留原表达式
+var errUnresolved = errors.New("unresolved")
+
+// scope 表达式求值的上下文
+type scope struct {
+	vars    map[string]any
+	outputs map[string]map[string]any // 已成功步骤的输出
+	dryRun  bool
+}
+
+// expand 递归展开参数中的表达式
+//
+// 字符串恰好是一个表达式时保留引用值的类型（如数组），否则按字符串拼接
+func (s *scope) expand(v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return s.expandString(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			expanded, err := s.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			expanded, err := s.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+func (s *scope) expandString(str string) (any, error) {
+	if m := exprPattern.FindStringSubmatchIndex(str); m != nil && m[0] == 0 && m[1] == len(str) {
+		v, err := s.lookup(str[m[2]:m[3]])
+		if errors.Is(err, errUnresolved) {
+			return str, nil
+		}
+		return v, err
+	}
+
+	var firstErr error
+	out := exprPattern.ReplaceAllStringFunc(str, func(match string) string {
+		expr := exprPattern.FindStringSubmatch(match)[1]
+		v, err := s.lookup(expr)
+		if errors.Is(err, errUnresolved) {
+			return match
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return match
+		}
+		return valueString(v)
+	})
+	return out, firstErr
+}
+
+// lookup 求单个表达式的值
+func (s *scope) lookup(expr string) (any, error) {
+	parts := strings.Split(expr, ".")
+	switch parts[0] {
+	case "vars":
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("无效的表达式 %q，应为 vars.<名称>", expr)
+		}
+		v, ok := walk(s.vars, parts[1:])
+		if !ok {
+			return nil, fmt.Errorf("未定义的变量: %s", strings.Join(parts[1:], "."))
+		}
+		return v, nil
+	case "steps":
+		if len(parts) < 3 {
+			return nil, fmt.Errorf("无效的表达式 %q，应为 steps.<步骤 ID>.<字段>", expr)
+		}
+		outputs, ok := s.outputs[parts[1]]
+		if !ok {
+			if s.dryRun {
+				return nil, errUnresolved
+			}
+			return nil, fmt.Errorf("步骤 %s 没有成功执行，无法引用其输出", parts[1])
+		}
+		v, ok := walk(outputs, parts[2:])
+		if !ok {
+			return nil, fmt.Errorf("步骤 %s 没有输出 %s", parts[1], strings.Join(parts[2:], "."))
+		}
+		return v, nil
+	case "env":
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("无效的表达式 %q，应为 env.<名称>", expr)
+		}
+		v, ok := os.LookupEnv(parts[1])
+		if !ok {
+			return nil, fmt.Errorf("环境变量 %s 未设置", parts[1])
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("无效的表达式 %q，只支持 vars.、steps. 和 env.", expr)
+}
+
+// walk 按路径访问嵌套的 map
+func walk(v any, path []string) (any, bool) {
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// valueString 拼接到字符串中的值，数组和对象使用 JSON
+func valueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]any, []any:
+		data, err := json.Marshal(val)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// stepRefs 返回参数中引用的步骤 ID，并检查表达式的写法
+func stepRefs(v any) ([]string, error) {
+	var refs []string
+	var visit func(v any) error
+	visit = func(v any) error {
+		switch val := v.(type) {
+		case string:
+			for _, m := range exprPattern.FindAllStringSubmatch(val, -1) {
+				parts := strings.Split(m[1], ".")
+				switch parts[0] {
+				case "steps":
+					if len(parts) < 3 {
+						return fmt.Errorf("无效的表达式 %q，应为 steps.<步骤 ID>.<字段>", m[1])
+					}
+					refs = append(refs, parts[1])
+				case "vars", "env":
+				default:
+					return fmt.Errorf("无效的表达式 %q，只支持 vars.、steps. 和 env.", m[1])
+				}
+			}
+		case map[string]any:
+			for _, item := range val {
+				if err := visit(item); err != nil {
+					return err
+				}
+			}
+		case []any:
+			for _, item := range val {
+				if err := visit(item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	err := visit(v)
+	return refs, err
+}
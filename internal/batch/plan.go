@@ -0,0 +1,126 @@
+// Package batch 按计划文件批量执行操作，支持步骤间引用输出、并发和失败继续
+package batch
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Plan 批量操作计划
+//
+//	vars:
+//	  project: Apollo
+//	concurrency: 2
+//	steps:
+//	  - id: folder
+//	    action: folder.create
+//	    with:
+//	      name: ${{ vars.project }}
+//	  - id: doc
+//	    action: doc.create
+//	    with:
+//	      title: ${{ vars.project }} 设计文档
+//	      folder_token: ${{ steps.folder.folder_token }}
+type Plan struct {
+	Vars        map[string]any `yaml:"vars,omitempty"`
+	Concurrency int            `yaml:"concurrency,omitempty"` // 同时执行的步骤数，默认 1（按顺序执行）
+	Steps       []*Step        `yaml:"steps"`
+}
+
+// Step 单个操作步骤
+type Step struct {
+	ID              string         `yaml:"id,omitempty"`   // 步骤 ID，供后续步骤引用输出，默认 step-<序号>
+	Name            string         `yaml:"name,omitempty"` // 描述，只用于日志
+	Action          string         `yaml:"action"`
+	With            map[string]any `yaml:"with,omitempty"`
+	Needs           []string       `yaml:"needs,omitempty"` // 额外依赖的步骤（引用输出的步骤自动成为依赖）
+	ContinueOnError bool           `yaml:"continue-on-error,omitempty"`
+
+	deps []string
+}
+
+// stepIDPattern 步骤 ID 只能包含字母、数字、下划线和连字符，便于在表达式中引用
+var stepIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// LoadPlan 读取计划文件
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取计划文件失败: %w", err)
+	}
+	return ParsePlan(data)
+}
+
+// ParsePlan 解析计划内容，未知字段视为错误以便发现拼写错误
+func ParsePlan(data []byte) (*Plan, error) {
+	var plan Plan
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("解析计划文件失败: %w", err)
+	}
+	if plan.Vars == nil {
+		plan.Vars = map[string]any{}
+	}
+	return &plan, nil
+}
+
+// Validate 校验步骤 ID、操作和参数，并计算每个步骤的依赖
+//
+// 步骤只能引用排在它前面的步骤，因此依赖关系不会成环
+func (p *Plan) Validate(actions map[string]Action) error {
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("计划中没有步骤")
+	}
+	if p.Concurrency < 0 {
+		return fmt.Errorf("concurrency 不能为负数: %d", p.Concurrency)
+	}
+
+	seen := map[string]bool{}
+	for i, step := range p.Steps {
+		if step == nil {
+			return fmt.Errorf("第 %d 个步骤为空", i+1)
+		}
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("step-%d", i+1)
+		}
+		if !stepIDPattern.MatchString(step.ID) {
+			return fmt.Errorf("步骤 ID %q 无效，只能包含字母、数字、下划线和连字符", step.ID)
+		}
+		if seen[step.ID] {
+			return fmt.Errorf("步骤 ID %q 重复", step.ID)
+		}
+
+		action, ok := actions[step.Action]
+		if !ok {
+			return fmt.Errorf("步骤 %s: 未知的操作 %q", step.ID, step.Action)
+		}
+		if err := action.checkParams(step.With); err != nil {
+			return fmt.Errorf("步骤 %s: %w", step.ID, err)
+		}
+
+		deps := append([]string{}, step.Needs...)
+		refs, err := stepRefs(step.With)
+		if err != nil {
+			return fmt.Errorf("步骤 %s: %w", step.ID, err)
+		}
+		deps = append(deps, refs...)
+		step.deps = nil
+		added := map[string]bool{}
+		for _, dep := range deps {
+			if !seen[dep] {
+				return fmt.Errorf("步骤 %s 依赖的步骤 %q 不存在或不在它之前", step.ID, dep)
+			}
+			if !added[dep] {
+				added[dep] = true
+				step.deps = append(step.deps, dep)
+			}
+		}
+		seen[step.ID] = true
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 步骤状态
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+	StatusPlanned = "planned" // dry-run 时未执行
+)
+
+// Result 步骤的执行结果，按完成顺序写入 JSONL 日志
+type Result struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name,omitempty"`
+	Action     string         `json:"action"`
+	Status     string         `json:"status"`
+	Params     map[string]any `json:"params,omitempty"` // 展开后的参数，仅 dry-run 时输出
+	Outputs    map[string]any `json:"outputs,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	DurationMS int64          `json:"duration_ms"`
+}
+
+// Summary 执行统计
+type Summary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Planned   int `json:"planned,omitempty"`
+}
+
+// Runner 执行计划
+type Runner struct {
+	Actions     map[string]Action
+	Vars        map[string]any // 覆盖计划中的同名变量
+	Concurrency int            // 大于 0 时覆盖计划中的 concurrency
+	DryRun      bool           // 只展开参数并输出计划，不执行操作
+	OnResult    func(*Result)  // 每个步骤结束时调用，调用是串行的
+}
+
+// stepDone 步骤执行完成的通知
+type stepDone struct {
+	step    *Step
+	outputs map[string]any
+	err     error
+	elapsed time.Duration
+}
+
+// Run 校验并执行计划
+//
+// 步骤在依赖的步骤成功后执行，依赖失败或被跳过时跳过该步骤。
+// 未设置 continue-on-error 的步骤失败后不再启动新的步骤，返回该步骤的错误
+func (r *Runner) Run(ctx context.Context, plan *Plan) (*Summary, error) {
+	if err := plan.Validate(r.Actions); err != nil {
+		return nil, err
+	}
+
+	vars := map[string]any{}
+	for k, v := range plan.Vars {
+		vars[k] = v
+	}
+	for k, v := range r.Vars {
+		vars[k] = v
+	}
+	sc := &scope{vars: vars, outputs: map[string]map[string]any{}, dryRun: r.DryRun}
+
+	summary := &Summary{Total: len(plan.Steps)}
+	emit := func(res *Result) {
+		switch res.Status {
+		case StatusSuccess:
+			summary.Succeeded++
+		case StatusFailed:
+			summary.Failed++
+		case StatusSkipped:
+			summary.Skipped++
+		case StatusPlanned:
+			summary.Planned++
+		}
+		if r.OnResult != nil {
+			r.OnResult(res)
+		}
+	}
+
+	if r.DryRun {
+		return summary, r.plan(plan, sc, emit)
+	}
+
+	concurrency := plan.Concurrency
+	if r.Concurrency > 0 {
+		concurrency = r.Concurrency
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	status := map[string]string{}
+	pending := append([]*Step{}, plan.Steps...)
+	done := make(chan stepDone)
+	running := 0
+	var stopErr error
+
+	finish := func(step *Step, res *Result, err error) {
+		status[step.ID] = res.Status
+		emit(res)
+		if err != nil && !step.ContinueOnError && stopErr == nil {
+			stopErr = fmt.Errorf("步骤 %s 失败: %w", step.ID, err)
+		}
+	}
+
+	for {
+		if stopErr == nil && ctx.Err() != nil {
+			stopErr = ctx.Err()
+		}
+
+		for i := 0; i < len(pending) && running < concurrency; {
+			step := pending[i]
+			if stopErr != nil {
+				break
+			}
+			ready, blockedBy := true, ""
+			for _, dep := range step.deps {
+				switch status[dep] {
+				case StatusSuccess:
+				case StatusFailed, StatusSkipped:
+					blockedBy = dep
+				default:
+					ready = false
+				}
+			}
+			if blockedBy != "" {
+				pending = append(pending[:i], pending[i+1:]...)
+				finish(step, &Result{
+					ID: step.ID, Name: step.Name, Action: step.Action, Status: StatusSkipped,
+					Error: fmt.Sprintf("依赖的步骤 %s 未成功", blockedBy),
+				}, nil)
+				continue
+			}
+			if !ready {
+				i++
+				continue
+			}
+
+			pending = append(pending[:i], pending[i+1:]...)
+			params, err := sc.expand(step.With)
+			if err != nil {
+				finish(step, &Result{
+					ID: step.ID, Name: step.Name, Action: step.Action, Status: StatusFailed, Error: err.Error(),
+				}, err)
+				continue
+			}
+			running++
+			go func(step *Step, params Params) {
+				start := time.Now()
+				outputs, err := r.Actions[step.Action].Run(ctx, params)
+				done <- stepDone{step: step, outputs: outputs, err: err, elapsed: time.Since(start)}
+			}(step, params.(map[string]any))
+		}
+
+		if running == 0 {
+			break
+		}
+		d := <-done
+		running--
+		res := &Result{
+			ID: d.step.ID, Name: d.step.Name, Action: d.step.Action,
+			Outputs: d.outputs, DurationMS: d.elapsed.Milliseconds(),
+		}
+		if d.err != nil {
+			res.Status = StatusFailed
+			res.Error = d.err.Error()
+		} else {
+			res.Status = StatusSuccess
+			if d.outputs == nil {
+				d.outputs = map[string]any{}
+			}
+			sc.outputs[d.step.ID] = d.outputs
+		}
+		finish(d.step, res, d.err)
+	}
+
+	// 失败或中断后未执行的步骤
+	for _, step := range pending {
+		reason := "之前的步骤失败，未执行"
+		if ctx.Err() != nil {
+			reason = "已中断，未执行"
+		}
+		emit(&Result{ID: step.ID, Name: step.Name, Action: step.Action, Status: StatusSkipped, Error: reason})
+	}
+	return summary, stopErr
+}
+
+// plan dry-run：按顺序展开每个步骤的参数，引用其他步骤输出的表达式原样保留
+func (r *Runner) plan(plan *Plan, sc *scope, emit func(*Result)) error {
+	var firstErr error
+	for _, step := range plan.Steps {
+		res := &Result{ID: step.ID, Name: step.Name, Action: step.Action, Status: StatusPlanned}
+		params, err := sc.expand(step.With)
+		if err != nil {
+			res.Status = StatusFailed
+			res.Error = err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("步骤 %s: %w", step.ID, err)
+			}
+		} else {
+			res.Params = params.(map[string]any)
+		}
+		emit(res)
+	}
+	return firstErr
+}
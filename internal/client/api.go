@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	lark "github.com/larksuite/oapi-sdk-go/v3"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// APIRequest 原始开放平台接口请求
+type APIRequest struct {
+	Method          string     // HTTP 方法: GET, POST, PUT, PATCH, DELETE
+	Path            string     // 接口路径，如 /open-apis/docx/v1/documents/xxx，可带查询参数
+	Query           url.Values // 查询参数
+	Body            any        // 请求体，序列化为 JSON
+	UserAccessToken string     // 非空时以用户身份调用，否则使用 tenant_access_token
+}
+
+// doAPI 通过 SDK 发送请求，鉴权、限流重试和调试日志与其他接口一致
+func doAPI(ctx context.Context, client *lark.Client, req APIRequest) (*larkcore.ApiResp, error) {
+	method := strings.ToUpper(req.Method)
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("不支持的 HTTP 方法: %s", req.Method)
+	}
+
+	apiReq := &larkcore.ApiReq{
+		HttpMethod:                method,
+		ApiPath:                   req.Path,
+		Body:                      req.Body,
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant},
+	}
+	for key, values := range req.Query {
+		apiReq.QueryParams[key] = values
+	}
+
+	var opts []larkcore.RequestOptionFunc
+	if req.UserAccessToken != "" {
+		apiReq.SupportedAccessTokenTypes = []larkcore.AccessTokenType{larkcore.AccessTokenTypeUser}
+		opts = append(opts, larkcore.WithUserAccessToken(req.UserAccessToken))
+	}
+	return client.Do(ctx, apiReq, opts...)
+}
+
+// normalizeAPIPath 校验接口路径，并将路径中的查询参数合并到 query
+func normalizeAPIPath(path string, query url.Values) (string, url.Values, error) {
+	u, err := url.Parse(strings.TrimSpace(path))
+	if err != nil {
+		return "", nil, fmt.Errorf("无效的接口路径: %w", err)
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return "", nil, fmt.Errorf("接口路径不能包含域名，请使用 /open-apis/... 形式，域名由 base_url 配置决定")
+	}
+	p := u.Path
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !strings.HasPrefix(p, "/open-apis/") {
+		return "", nil, fmt.Errorf("接口路径必须以 /open-apis/ 开头: %s", path)
+	}
+
+	merged := url.Values{}
+	for key, values := range u.Query() {
+		merged[key] = append(merged[key], values...)
+	}
+	for key, values := range query {
+		merged[key] = append(merged[key], values...)
+	}
+	return p, merged, nil
+}
+
+// CallAPI 调用任意开放平台接口，返回原始响应体
+//
+// HTTP 状态码非 200 或 JSON 响应中 code 非 0 时返回 *APIError
+func CallAPI(ctx context.Context, req APIRequest) ([]byte, error) {
+	path, query, err := normalizeAPIPath(req.Path, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	req.Path, req.Query = path, query
+
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	op := fmt.Sprintf("调用 %s %s 失败", strings.ToUpper(req.Method), req.Path)
+	resp, err := doAPI(ctx, client, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newHTTPError(op, resp)
+	}
+
+	// 文件下载等接口返回非 JSON 内容，原样返回
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if json.Unmarshal(resp.RawBody, &result) == nil && result.Code != 0 {
+		return nil, newAPIError(op, result.Code, result.Msg, resp)
+	}
+	return resp.RawBody, nil
+}
+
+// CallAPIPages 调用分页接口，按响应中的 has_more 和 page_token 依次请求每一页，
+// 每页的原始响应体交给 fn 处理
+func CallAPIPages(ctx context.Context, req APIRequest, fn func(page []byte) error) error {
+	query := url.Values{}
+	for key, values := range req.Query {
+		query[key] = append([]string(nil), values...)
+	}
+	req.Query = query
+
+	for {
+		body, err := CallAPI(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := fn(body); err != nil {
+			return err
+		}
+
+		var page struct {
+			Data struct {
+				HasMore       bool   `json:"has_more"`
+				PageToken     string `json:"page_token"`
+				NextPageToken string `json:"next_page_token"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("解析分页响应失败: %w", err)
+		}
+		next := page.Data.PageToken
+		if next == "" {
+			next = page.Data.NextPageToken
+		}
+		if !page.Data.HasMore || next == "" {
+			return nil
+		}
+		// 避免接口返回相同的 page_token 导致死循环
+		if next == query.Get("page_token") {
+			return fmt.Errorf("分页 token 未变化，停止翻页: %s", next)
+		}
+		query.Set("page_token", next)
+	}
+}
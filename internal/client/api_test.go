@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+)
+
+func TestNormalizeAPIPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantQ   string
+		wantErr bool
+	}{
+		{path: "/open-apis/docx/v1/documents/x", want: "/open-apis/docx/v1/documents/x"},
+		{path: "open-apis/drive/v1/files?page_size=10", want: "/open-apis/drive/v1/files", wantQ: "folder_token=f&page_size=10"},
+		{path: "https://open.feishu.cn/open-apis/drive/v1/files", wantErr: true},
+		{path: "/docx/v1/documents", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, query, err := normalizeAPIPath(tt.path, map[string][]string{"folder_token": {"f"}})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeAPIPath(%q) 错误 = %v, 期望错误 = %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeAPIPath(%q) = %q, 期望 %q", tt.path, got, tt.want)
+		}
+		if tt.wantQ != "" && query.Encode() != tt.wantQ {
+			t.Errorf("normalizeAPIPath(%q) 查询参数 = %q, 期望 %q", tt.path, query.Encode(), tt.wantQ)
+		}
+	}
+}
+
+func TestCallAPIPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer u-test" {
+			t.Errorf("Authorization = %q, 期望使用 User Access Token", got)
+		}
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			fmt.Fprint(w, `{"code":0,"data":{"items":[1,2],"has_more":true,"page_token":"p2"}}`)
+		case "p2":
+			fmt.Fprint(w, `{"code":0,"data":{"items":[3],"has_more":false}}`)
+		default:
+			w.Header().Set("X-Tt-Logid", "log-1")
+			fmt.Fprint(w, `{"code":1770002,"msg":"not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	resetClient()
+	resetConfig()
+	os.Unsetenv("FEISHU_APP_ID")
+	os.Unsetenv("FEISHU_APP_SECRET")
+	configFile := t.TempDir() + "/config.yaml"
+	os.WriteFile(configFile, []byte(fmt.Sprintf("app_id: a\napp_secret: b\nbase_url: %s\n", server.URL)), 0600)
+	config.Init(configFile)
+	defer resetClient()
+
+	var pages []string
+	req := APIRequest{Method: "get", Path: "/open-apis/test/v1/items", UserAccessToken: "u-test"}
+	err := CallAPIPages(context.Background(), req, func(page []byte) error {
+		pages = append(pages, string(page))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CallAPIPages 返回错误: %v", err)
+	}
+	if len(pages) != 2 || !strings.Contains(pages[1], "[3]") {
+		t.Errorf("分页结果 = %v, 期望两页", pages)
+	}
+
+	req.Query = map[string][]string{"page_token": {"bad"}}
+	_, err = CallAPI(context.Background(), req)
+	if ErrorKindOf(err) != ErrorKindNotFound || !strings.Contains(err.Error(), "log_id=log-1") {
+		t.Errorf("CallAPI 错误 = %v, 期望包含 log_id 的 not_found 错误", err)
+	}
+}
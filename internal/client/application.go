@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkapplication "github.com/larksuite/oapi-sdk-go/v3/service/application/v6"
+	"github.com/riba2534/feishu-cli/internal/config"
+)
+
+// PingBaseURL 检查与开放平台地址的网络连通性，返回耗时和 HTTP 状态码
+func PingBaseURL(ctx context.Context) (time.Duration, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, config.Get().BaseURL, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("创建请求失败: %w", err)
+	}
+	start := time.Now()
+	resp, err := (&http.Client{Transport: newBaseTransport()}).Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("连接 %s 失败: %w", config.Get().BaseURL, err)
+	}
+	resp.Body.Close()
+	return time.Since(start), resp.StatusCode, nil
+}
+
+// CheckAppCredentials 使用 app_id 和 app_secret 获取 tenant_access_token，返回有效期（秒）
+func CheckAppCredentials(ctx context.Context) (int, error) {
+	client, err := GetClient()
+	if err != nil {
+		return 0, err
+	}
+
+	cfg := config.Get()
+	resp, err := client.GetTenantAccessTokenBySelfBuiltApp(ctx, &larkcore.SelfBuiltTenantAccessTokenReq{
+		AppID:     cfg.AppID,
+		AppSecret: cfg.AppSecret,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("获取 tenant_access_token 失败: %w", err)
+	}
+	if !resp.Success() {
+		return 0, newAPIError("获取 tenant_access_token 失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+	return resp.Expire, nil
+}
+
+// GetAppScopes 获取应用已开通的权限 scope 列表
+// 需要应用开通 application:application:self_manage 权限
+func GetAppScopes(ctx context.Context) ([]string, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := larkapplication.NewGetApplicationReqBuilder().
+		AppId(config.Get().AppID).
+		Lang("zh_cn").
+		Build()
+
+	resp, err := client.Application.Application.Get(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("获取应用权限列表失败: %w", err)
+	}
+	if !resp.Success() {
+		return nil, newAPIError("获取应用权限列表失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	var scopes []string
+	if resp.Data != nil && resp.Data.App != nil {
+		for _, s := range resp.Data.App.Scopes {
+			if scope := StringVal(s.Scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// GetCurrentUserName 使用 User Access Token 获取当前登录用户的名称，用于验证 token 是否可用
+func GetCurrentUserName(ctx context.Context, userAccessToken string) (string, error) {
+	client, err := GetClient()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Get(ctx, "/open-apis/authen/v1/user_info", nil, larkcore.AccessTokenTypeUser,
+		larkcore.WithUserAccessToken(userAccessToken))
+	if err != nil {
+		return "", fmt.Errorf("获取登录用户信息失败: %w", err)
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+		Data struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp.RawBody, &result); err != nil {
+		return "", fmt.Errorf("解析登录用户信息失败: %w", err)
+	}
+	if result.Code != 0 {
+		return "", newAPIError("获取登录用户信息失败", result.Code, result.Msg, resp)
+	}
+	return result.Data.Name, nil
+}
@@ -2,10 +2,16 @@ package client
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/riba2534/feishu-cli/internal/config"
@@ -13,18 +19,24 @@ import (
 )
 
 const (
-	// OAuth 授权相关 URL
-	oauthAuthorizeURL = "https://open.feishu.cn/open-apis/authen/v1/authorize"
-	tokenURL          = "https://open.feishu.cn/open-apis/authen/v1/oidc/access_token"
-	refreshTokenURL   = "https://open.feishu.cn/open-apis/authen/v1/oidc/refresh_access_token"
+	// OAuth 授权相关路径，拼接在 base_url 之后（飞书与 Lark 国际版路径相同）
+	oauthAuthorizePath = "/open-apis/authen/v1/authorize"
+	tokenPath          = "/open-apis/authen/v1/oidc/access_token"
+	refreshTokenPath   = "/open-apis/authen/v1/oidc/refresh_access_token"
+	appAccessTokenPath = "/open-apis/auth/v3/app_access_token/internal"
+	// OAuth 2.0 标准令牌接口，支持 PKCE（code_verifier）
+	oauthV2TokenPath = "/open-apis/authen/v2/oauth/token"
 )
 
 // OAuthClient OAuth 客户端
 type OAuthClient struct {
 	AppID       string
 	AppSecret   string
+	BaseURL     string
 	RedirectURI string
 	HTTPClient  *http.Client
+	// CodeVerifier 不为空时使用 PKCE：授权链接携带 code_challenge，换取 token 时使用 OAuth 2.0 标准接口
+	CodeVerifier string
 }
 
 // NewOAuthClient 创建 OAuth 客户端
@@ -37,6 +49,7 @@ func NewOAuthClient(redirectURI string) (*OAuthClient, error) {
 	return &OAuthClient{
 		AppID:       cfg.AppID,
 		AppSecret:   cfg.AppSecret,
+		BaseURL:     strings.TrimSuffix(cfg.BaseURL, "/"),
 		RedirectURI: redirectURI,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -47,7 +60,7 @@ func NewOAuthClient(redirectURI string) (*OAuthClient, error) {
 // GetAuthorizeURL 生成授权 URL
 func (c *OAuthClient) GetAuthorizeURL(state string, scope string) string {
 	authURL := fmt.Sprintf("%s?app_id=%s&redirect_uri=%s&state=%s",
-		oauthAuthorizeURL,
+		c.BaseURL+oauthAuthorizePath,
 		c.AppID,
 		url.QueryEscape(c.RedirectURI),
 		state,
@@ -55,27 +68,153 @@ func (c *OAuthClient) GetAuthorizeURL(state string, scope string) string {
 	if scope != "" {
 		authURL = fmt.Sprintf("%s&scope=%s", authURL, url.QueryEscape(scope))
 	}
+	if c.CodeVerifier != "" {
+		authURL = fmt.Sprintf("%s&code_challenge=%s&code_challenge_method=S256", authURL, pkceChallenge(c.CodeVerifier))
+	}
 	return authURL
 }
 
 // ExchangeCodeForToken 用授权码换取 access token
 func (c *OAuthClient) ExchangeCodeForToken(code string) (*token.UserToken, error) {
+	if c.CodeVerifier != "" {
+		return c.doOAuthV2Request(map[string]string{
+			"grant_type":    "authorization_code",
+			"code":          code,
+			"redirect_uri":  c.RedirectURI,
+			"code_verifier": c.CodeVerifier,
+		})
+	}
+
 	reqBody := map[string]string{
 		"grant_type": "authorization_code",
 		"code":       code,
 	}
 
-	return c.doTokenRequest(tokenURL, reqBody)
+	return c.doTokenRequest(c.BaseURL+tokenPath, reqBody)
 }
 
-// RefreshUserAccessToken 刷新用户访问令牌
-func (c *OAuthClient) RefreshUserAccessToken(refreshToken string) (*token.UserToken, error) {
+// RefreshUserAccessToken 刷新用户访问令牌，按 token 的签发接口选择对应的刷新接口
+func (c *OAuthClient) RefreshUserAccessToken(tok *token.UserToken) (*token.UserToken, error) {
+	if tok.RefreshToken == "" {
+		return nil, fmt.Errorf("没有 refresh_token，请重新运行 'feishu-cli auth login'")
+	}
+	if tok.APIVersion == token.APIVersionOAuthV2 {
+		return c.doOAuthV2Request(map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": tok.RefreshToken,
+		})
+	}
+
 	reqBody := map[string]string{
 		"grant_type":    "refresh_token",
-		"refresh_token": refreshToken,
+		"refresh_token": tok.RefreshToken,
+	}
+
+	return c.doTokenRequest(c.BaseURL+refreshTokenPath, reqBody)
+}
+
+// doOAuthV2Request 调用 OAuth 2.0 标准令牌接口，使用应用凭证直接认证
+func (c *OAuthClient) doOAuthV2Request(reqBody map[string]string) (*token.UserToken, error) {
+	reqBody["client_id"] = c.AppID
+	reqBody["client_secret"] = c.AppSecret
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
 	}
 
-	return c.doTokenRequest(refreshTokenURL, reqBody)
+	req, err := http.NewRequest("POST", c.BaseURL+oauthV2TokenPath, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Code             int    `json:"code"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Scope            string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.Code != 0 || result.AccessToken == "" {
+		return nil, newAPIErrorFromHTTP("获取 token 失败", result.Code, result.Error+": "+result.ErrorDescription, resp)
+	}
+
+	return &token.UserToken{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresIn:    result.ExpiresIn,
+		ExpiresAt:    time.Now().Unix() + result.ExpiresIn,
+		APIVersion:   token.APIVersionOAuthV2,
+		Scope:        result.Scope,
+	}, nil
+}
+
+// NewOAuthState 生成随机的 state，用于校验授权回调来自本次登录
+func NewOAuthState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// NewPKCEVerifier 生成 PKCE code_verifier（RFC 7636，43 个字符）
+func NewPKCEVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceChallenge 按 S256 方法计算 code_challenge
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// ParseAuthorizationResponse 从授权回调地址或授权码中取出 code
+// input 为完整的回调地址时校验 state；为单独的授权码时直接返回
+func ParseAuthorizationResponse(input, state string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("未输入授权码")
+	}
+	if !strings.ContainsAny(input, "?=/:") {
+		return input, nil
+	}
+
+	query := input
+	if u, err := url.Parse(input); err == nil && u.RawQuery != "" {
+		query = u.RawQuery
+	}
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return "", fmt.Errorf("解析回调地址失败: %w", err)
+	}
+	if errMsg := values.Get("error"); errMsg != "" {
+		return "", fmt.Errorf("授权失败: %s", errMsg)
+	}
+	if values.Get("state") != state {
+		return "", fmt.Errorf("state 不匹配，回调地址不属于本次登录，请重新运行命令")
+	}
+	code := values.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("回调地址中没有授权码")
+	}
+	return code, nil
 }
 
 // getAppAccessToken 获取应用级 Access Token
@@ -90,7 +229,7 @@ func (c *OAuthClient) getAppAccessToken() (string, error) {
 		return "", fmt.Errorf("序列化请求失败: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://open.feishu.cn/open-apis/auth/v3/app_access_token/internal", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest("POST", c.BaseURL+appAccessTokenPath, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -115,7 +254,7 @@ func (c *OAuthClient) getAppAccessToken() (string, error) {
 	}
 
 	if result.Code != 0 {
-		return "", fmt.Errorf("获取 app_access_token 失败: code=%d, msg=%s", result.Code, result.Msg)
+		return "", newAPIErrorFromHTTP("获取 app_access_token 失败", result.Code, result.Msg, resp)
 	}
 
 	return result.AppAccessToken, nil
@@ -157,7 +296,7 @@ func (c *OAuthClient) doTokenRequest(apiURL string, reqBody map[string]string) (
 	}
 
 	if tokenResp.Code != 0 {
-		return nil, fmt.Errorf("获取 token 失败: code=%d, msg=%s", tokenResp.Code, tokenResp.Msg)
+		return nil, newAPIErrorFromHTTP("获取 token 失败", tokenResp.Code, tokenResp.Msg, resp)
 	}
 
 	userToken := &token.UserToken{
@@ -165,6 +304,7 @@ func (c *OAuthClient) doTokenRequest(apiURL string, reqBody map[string]string) (
 		RefreshToken: tokenResp.Data.RefreshToken,
 		ExpiresIn:    tokenResp.Data.ExpiresIn,
 		ExpiresAt:    time.Now().Unix() + tokenResp.Data.ExpiresIn,
+		Scope:        tokenResp.Data.Scope,
 	}
 
 	return userToken, nil
@@ -180,7 +320,7 @@ func GetUserAccessToken() (string, error) {
 	// 2. 检查 token 文件
 	tok, err := token.LoadToken()
 	if err != nil {
-		return "", fmt.Errorf("未找到 User Access Token，请先运行 'feishu-cli auth login' 进行授权: %w", err)
+		return "", fmt.Errorf("未找到 User Access Token，%w: %v", ErrUserAuthRequired, err)
 	}
 
 	// 3. 检查是否过期
@@ -194,8 +334,13 @@ func GetUserAccessToken() (string, error) {
 		return "", err
 	}
 
-	newToken, err := oauthClient.RefreshUserAccessToken(tok.RefreshToken)
+	newToken, err := oauthClient.RefreshUserAccessToken(tok)
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			// refresh_token 被拒绝，需要重新登录
+			return "", fmt.Errorf("刷新 token 失败，%w: %w", ErrUserAuthRequired, err)
+		}
 		return "", fmt.Errorf("刷新 token 失败: %w", err)
 	}
 
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAuthorizationResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"完整回调地址", "http://127.0.0.1:8080/callback?code=abc123&state=s1", "abc123", false},
+		{"带空白的回调地址", "  http://127.0.0.1:8080/callback?state=s1&code=abc123\n", "abc123", false},
+		{"只有查询参数", "?code=abc123&state=s1", "abc123", false},
+		{"单独的授权码", "abc123", "abc123", false},
+		{"state 不匹配", "http://127.0.0.1:8080/callback?code=abc123&state=other", "", true},
+		{"缺少 state", "http://127.0.0.1:8080/callback?code=abc123", "", true},
+		{"授权被拒绝", "http://127.0.0.1:8080/callback?error=access_denied&state=s1", "", true},
+		{"没有授权码", "http://127.0.0.1:8080/callback?state=s1", "", true},
+		{"没有查询参数的地址", "http://127.0.0.1:8080/callback", "", true},
+		{"空输入", "\n", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAuthorizationResponse(tt.input, "s1")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: ParseAuthorizationResponse = (%q, %v), 期望 (%q, 错误=%v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPKCE(t *testing.T) {
+	if got := pkceChallenge("dBjftJeZ4CVP-mJ92ZnGKCGSMJiVwd9sI6PZ94YGN0"); got != "eIJ-u3BeGL79Wqw-J5ULEhLlo4Ta8OKndC_ObXcY7XM" {
+		t.Errorf("pkceChallenge = %q", got)
+	}
+
+	verifier, err := NewPKCEVerifier()
+	if err != nil {
+		t.Fatalf("NewPKCEVerifier 返回错误: %v", err)
+	}
+	if len(verifier) != 43 || strings.ContainsAny(verifier, "+/=") {
+		t.Errorf("code_verifier 应为 43 个 URL 安全字符: %q", verifier)
+	}
+
+	c := &OAuthClient{AppID: "cli_x", BaseURL: "https://open.feishu.cn", RedirectURI: "http://127.0.0.1:8080/callback", CodeVerifier: verifier}
+	authURL := c.GetAuthorizeURL("s1", "")
+	for _, want := range []string{"state=s1", "code_challenge=" + pkceChallenge(verifier), "code_challenge_method=S256"} {
+		if !strings.Contains(authURL, want) {
+			t.Errorf("授权链接缺少 %q: %s", want, authURL)
+		}
+	}
+	if strings.Contains(authURL, verifier) {
+		t.Errorf("授权链接不应包含 code_verifier: %s", authURL)
+	}
+}
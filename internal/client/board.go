@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,7 +14,7 @@ import (
 )
 
 // GetBoardImage downloads whiteboard image and saves to file
-func GetBoardImage(whiteboardID string, outputPath string) error {
+func GetBoardImage(ctx context.Context, whiteboardID string, outputPath string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -22,14 +23,14 @@ func GetBoardImage(whiteboardID string, outputPath string) error {
 	// 使用通用 HTTP 请求方式
 	apiPath := fmt.Sprintf("/open-apis/board/v1/whiteboards/%s/download_as_image", whiteboardID)
 
-	resp, err := client.Get(Context(), apiPath, nil, larkcore.AccessTokenTypeTenant)
+	resp, err := client.Get(ctx, apiPath, nil, larkcore.AccessTokenTypeTenant)
 	if err != nil {
 		return fmt.Errorf("获取画板图片失败: %w", err)
 	}
 
 	// Check response
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("获取画板图片失败: HTTP %d, body: %s", resp.StatusCode, string(resp.RawBody))
+		return newHTTPError("获取画板图片失败", resp)
 	}
 
 	// Check if outputPath is a directory
@@ -67,7 +68,7 @@ type ImportDiagramResult struct {
 }
 
 // ImportDiagram imports a diagram to whiteboard
-func ImportDiagram(whiteboardID string, source string, opts ImportDiagramOptions) (*ImportDiagramResult, error) {
+func ImportDiagram(ctx context.Context, whiteboardID string, source string, opts ImportDiagramOptions) (*ImportDiagramResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -155,14 +156,14 @@ func ImportDiagram(whiteboardID string, source string, opts ImportDiagramOptions
 	// 正确的 API 路径是 /nodes/plantuml
 	apiPath := fmt.Sprintf("/open-apis/board/v1/whiteboards/%s/nodes/plantuml", whiteboardID)
 
-	resp, err := client.Post(Context(), apiPath, reqBody, larkcore.AccessTokenTypeTenant)
+	resp, err := client.Post(ctx, apiPath, reqBody, larkcore.AccessTokenTypeTenant)
 	if err != nil {
 		return nil, fmt.Errorf("导入图表失败: %w", err)
 	}
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("导入图表失败: HTTP %d, body: %s", resp.StatusCode, string(resp.RawBody))
+		return nil, newHTTPError("导入图表失败", resp)
 	}
 
 	// Parse response
@@ -180,7 +181,7 @@ func ImportDiagram(whiteboardID string, source string, opts ImportDiagramOptions
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("导入图表失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("导入图表失败", apiResp.Code, apiResp.Msg, resp)
 	}
 
 	nodeID := apiResp.Data.NodeID
@@ -200,7 +201,7 @@ type CreateBoardNotesOptions struct {
 }
 
 // CreateBoardNodes creates nodes on a whiteboard
-func CreateBoardNodes(whiteboardID string, nodesJSON string, opts CreateBoardNotesOptions) ([]string, error) {
+func CreateBoardNodes(ctx context.Context, whiteboardID string, nodesJSON string, opts CreateBoardNotesOptions) ([]string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -226,14 +227,14 @@ func CreateBoardNodes(whiteboardID string, nodesJSON string, opts CreateBoardNot
 		apiPath += "&client_token=" + opts.ClientToken
 	}
 
-	resp, err := client.Post(Context(), apiPath, bodyBytes, larkcore.AccessTokenTypeTenant)
+	resp, err := client.Post(ctx, apiPath, bodyBytes, larkcore.AccessTokenTypeTenant)
 	if err != nil {
 		return nil, fmt.Errorf("创建画板节点失败: %w", err)
 	}
 
 	// 检查 HTTP 状态码
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("创建画板节点失败: HTTP %d, body: %s", resp.StatusCode, string(resp.RawBody))
+		return nil, newHTTPError("创建画板节点失败", resp)
 	}
 
 	// Parse response
@@ -252,7 +253,7 @@ func CreateBoardNodes(whiteboardID string, nodesJSON string, opts CreateBoardNot
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("创建画板节点失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("创建画板节点失败", apiResp.Code, apiResp.Msg, resp)
 	}
 
 	var nodeIDs []string
@@ -264,8 +265,12 @@ func CreateBoardNodes(whiteboardID string, nodesJSON string, opts CreateBoardNot
 }
 
 // DownloadBoardImageByURL downloads image from URL and saves to file
-func DownloadBoardImageByURL(imageURL string, outputPath string) error {
-	resp, err := http.Get(imageURL)
+func DownloadBoardImageByURL(ctx context.Context, imageURL string, outputPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return fmt.Errorf("下载图片失败: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("下载图片失败: %w", err)
 	}
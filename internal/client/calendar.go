@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -42,7 +43,7 @@ type CalendarEvent struct {
 }
 
 // ListCalendars 列出日历
-func ListCalendars(pageSize int, pageToken string) ([]*Calendar, string, bool, error) {
+func ListCalendars(ctx context.Context, pageSize int, pageToken string) ([]*Calendar, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -56,13 +57,13 @@ func ListCalendars(pageSize int, pageToken string) ([]*Calendar, string, bool, e
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Calendar.Calendar.List(Context(), reqBuilder.Build())
+	resp, err := client.Calendar.Calendar.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取日历列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取日历列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取日历列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var calendars []*Calendar
@@ -105,7 +106,7 @@ type CreateEventParams struct {
 }
 
 // CreateEvent 创建日程
-func CreateEvent(params *CreateEventParams) (*CalendarEvent, error) {
+func CreateEvent(ctx context.Context, params *CreateEventParams) (*CalendarEvent, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -148,13 +149,13 @@ func CreateEvent(params *CreateEventParams) (*CalendarEvent, error) {
 		CalendarEvent(eventBuilder.Build()).
 		Build()
 
-	resp, err := client.Calendar.CalendarEvent.Create(Context(), req)
+	resp, err := client.Calendar.CalendarEvent.Create(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("创建日程失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建日程失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建日程失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data == nil || resp.Data.Event == nil {
@@ -165,7 +166,7 @@ func CreateEvent(params *CreateEventParams) (*CalendarEvent, error) {
 }
 
 // GetEvent 获取日程详情
-func GetEvent(calendarID, eventID string) (*CalendarEvent, error) {
+func GetEvent(ctx context.Context, calendarID, eventID string) (*CalendarEvent, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -176,13 +177,13 @@ func GetEvent(calendarID, eventID string) (*CalendarEvent, error) {
 		EventId(eventID).
 		Build()
 
-	resp, err := client.Calendar.CalendarEvent.Get(Context(), req)
+	resp, err := client.Calendar.CalendarEvent.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取日程详情失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取日程详情失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取日程详情失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data == nil || resp.Data.Event == nil {
@@ -202,7 +203,7 @@ type ListEventsParams struct {
 }
 
 // ListEvents 列出日程
-func ListEvents(params *ListEventsParams) ([]*CalendarEvent, string, bool, error) {
+func ListEvents(ctx context.Context, params *ListEventsParams) ([]*CalendarEvent, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -235,13 +236,13 @@ func ListEvents(params *ListEventsParams) ([]*CalendarEvent, string, bool, error
 		reqBuilder.PageToken(params.PageToken)
 	}
 
-	resp, err := client.Calendar.CalendarEvent.List(Context(), reqBuilder.Build())
+	resp, err := client.Calendar.CalendarEvent.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取日程列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取日程列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取日程列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var events []*CalendarEvent
@@ -273,7 +274,7 @@ type UpdateEventParams struct {
 }
 
 // UpdateEvent 更新日程（使用 Patch 方式）
-func UpdateEvent(params *UpdateEventParams) (*CalendarEvent, error) {
+func UpdateEvent(ctx context.Context, params *UpdateEventParams) (*CalendarEvent, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -324,13 +325,13 @@ func UpdateEvent(params *UpdateEventParams) (*CalendarEvent, error) {
 		CalendarEvent(eventBuilder.Build()).
 		Build()
 
-	resp, err := client.Calendar.CalendarEvent.Patch(Context(), req)
+	resp, err := client.Calendar.CalendarEvent.Patch(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("更新日程失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("更新日程失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("更新日程失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data == nil || resp.Data.Event == nil {
@@ -341,7 +342,7 @@ func UpdateEvent(params *UpdateEventParams) (*CalendarEvent, error) {
 }
 
 // DeleteEvent 删除日程
-func DeleteEvent(calendarID, eventID string) error {
+func DeleteEvent(ctx context.Context, calendarID, eventID string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -352,13 +353,13 @@ func DeleteEvent(calendarID, eventID string) error {
 		EventId(eventID).
 		Build()
 
-	resp, err := client.Calendar.CalendarEvent.Delete(Context(), req)
+	resp, err := client.Calendar.CalendarEvent.Delete(ctx, req)
 	if err != nil {
 		return fmt.Errorf("删除日程失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除日程失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("删除日程失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -1,8 +1,8 @@
 package client
 
 import (
-	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -11,8 +11,9 @@ import (
 	"github.com/riba2534/feishu-cli/internal/config"
 )
 
-// 默认 API 调用超时时间
-const defaultTimeout = 30 * time.Second
+// 等待 API 响应头的超时时间，防止单次请求无限阻塞
+// 整个命令的超时和取消由调用方传入的 ctx 控制（全局 --timeout 和 Ctrl+C）
+const responseHeaderTimeout = 30 * time.Second
 
 var (
 	mu       sync.Mutex
@@ -22,6 +23,10 @@ var (
 		appID   string
 		baseURL string
 		debug   bool
+		// 限流重试参数
+		maxRetries int
+		qps        float64
+		dryRun     bool
 		// 使用配置的哈希值而非明文存储 secret
 		cfgHash string
 	}
@@ -45,11 +50,20 @@ func GetClient() (*lark.Client, error) {
 		lastCfg.appID != cfg.AppID ||
 		lastCfg.cfgHash != currentHash ||
 		lastCfg.baseURL != cfg.BaseURL ||
-		lastCfg.debug != cfg.Debug
+		lastCfg.debug != cfg.Debug ||
+		lastCfg.maxRetries != cfg.MaxRetries ||
+		lastCfg.qps != cfg.QPS ||
+		lastCfg.dryRun != cfg.DryRun
 
 	if configChanged {
+		// 所有请求经过统一的限流重试层，dry-run 时写请求在此之前被拦截
+		var transport http.RoundTripper = newRetryTransport(newBaseTransport(), cfg.MaxRetries, cfg.QPS)
+		if cfg.DryRun {
+			transport = &dryRunTransport{base: transport}
+		}
 		opts := []lark.ClientOptionFunc{
 			lark.WithOpenBaseUrl(cfg.BaseURL),
+			lark.WithHttpClient(&http.Client{Transport: transport}),
 		}
 		if cfg.Debug {
 			opts = append(opts, lark.WithLogLevel(larkcore.LogLevelDebug))
@@ -61,29 +75,17 @@ func GetClient() (*lark.Client, error) {
 		lastCfg.cfgHash = currentHash
 		lastCfg.baseURL = cfg.BaseURL
 		lastCfg.debug = cfg.Debug
+		lastCfg.maxRetries = cfg.MaxRetries
+		lastCfg.qps = cfg.QPS
+		lastCfg.dryRun = cfg.DryRun
 	}
 
 	return instance, nil
 }
 
-// Context returns a context with timeout for API calls.
-// 默认超时时间为 30 秒，防止 API 调用无限阻塞。
-// 通过 goroutine 等待 ctx.Done 后调用 cancel，释放关联的计时器资源。
-func Context() context.Context {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-	go func() {
-		<-ctx.Done()
-		cancel()
-	}()
-	return ctx
-}
-
-// ContextWithTimeout returns a context with custom timeout.
-func ContextWithTimeout(timeout time.Duration) context.Context {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	go func() {
-		<-ctx.Done()
-		cancel()
-	}()
-	return ctx
+// newBaseTransport 返回设置了响应头超时的 HTTP 传输层
+func newBaseTransport() http.RoundTripper {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.ResponseHeaderTimeout = responseHeaderTimeout
+	return base
 }
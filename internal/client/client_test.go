@@ -1,7 +1,7 @@
 package client
 
 import (
-	"context"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -156,86 +156,6 @@ app_secret: "test_app_secret_2"
 	}
 }
 
-func TestContext(t *testing.T) {
-	ctx := Context()
-
-	if ctx == nil {
-		t.Fatal("Context() 返回 nil")
-	}
-
-	// 验证 context 有 deadline
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		t.Error("Context() 应返回带有 deadline 的 context")
-	}
-
-	// 验证 deadline 大约是 30 秒后
-	expected := time.Now().Add(30 * time.Second)
-	diff := deadline.Sub(expected)
-	if diff < -time.Second || diff > time.Second {
-		t.Errorf("Deadline 与预期相差过大: %v", diff)
-	}
-}
-
-func TestContextWithTimeout(t *testing.T) {
-	tests := []struct {
-		name    string
-		timeout time.Duration
-	}{
-		{"1秒", 1 * time.Second},
-		{"5秒", 5 * time.Second},
-		{"1分钟", 1 * time.Minute},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctx := ContextWithTimeout(tt.timeout)
-
-			if ctx == nil {
-				t.Fatal("ContextWithTimeout() 返回 nil")
-			}
-
-			deadline, ok := ctx.Deadline()
-			if !ok {
-				t.Error("ContextWithTimeout() 应返回带有 deadline 的 context")
-			}
-
-			expected := time.Now().Add(tt.timeout)
-			diff := deadline.Sub(expected)
-			if diff < -100*time.Millisecond || diff > 100*time.Millisecond {
-				t.Errorf("Deadline 与预期相差过大: %v", diff)
-			}
-		})
-	}
-}
-
-func TestContext_Cancellation(t *testing.T) {
-	ctx := Context()
-
-	// 验证 context 尚未取消
-	select {
-	case <-ctx.Done():
-		t.Error("Context 不应立即取消")
-	default:
-		// 预期行为
-	}
-}
-
-func TestContextWithTimeout_Zero(t *testing.T) {
-	// 零超时应该立即过期
-	ctx := ContextWithTimeout(0)
-
-	// 等待一小段时间让 context 过期
-	time.Sleep(10 * time.Millisecond)
-
-	select {
-	case <-ctx.Done():
-		// 预期行为
-	default:
-		t.Error("零超时的 Context 应该已经过期")
-	}
-}
-
 func TestGetClient_WithDebugMode(t *testing.T) {
 	resetClient()
 	resetConfig()
@@ -288,16 +208,13 @@ base_url: "https://custom.feishu.cn"
 	}
 }
 
-func TestContext_Type(t *testing.T) {
-	ctx := Context()
-
-	// 验证返回的是 context.Context 类型
-	var _ context.Context = ctx
-}
-
-func TestDefaultTimeout(t *testing.T) {
-	if defaultTimeout != 30*time.Second {
-		t.Errorf("defaultTimeout = %v, 期望 30s", defaultTimeout)
+func TestNewBaseTransport(t *testing.T) {
+	base, ok := newBaseTransport().(*http.Transport)
+	if !ok {
+		t.Fatal("newBaseTransport() 应返回 *http.Transport")
+	}
+	if base.ResponseHeaderTimeout != 30*time.Second {
+		t.Errorf("ResponseHeaderTimeout = %v, 期望 30s", base.ResponseHeaderTimeout)
 	}
 }
 
@@ -1,7 +1,12 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
 )
@@ -16,12 +21,36 @@ type Comment struct {
 	SolvedTime   int             `json:"solved_time,omitempty"`
 	SolverUserID string          `json:"solver_user_id,omitempty"`
 	IsWhole      bool            `json:"is_whole"`
-	Content      *CommentContent `json:"reply_list,omitempty"`
+	Quote        string          `json:"quote,omitempty"` // 局部评论引用的文本
+	Replies      []*CommentReply `json:"reply_list,omitempty"`
 }
 
-// CommentContent 评论内容
-type CommentContent struct {
-	Elements []CommentElement `json:"elements,omitempty"`
+// CommentReply 评论回复，评论的第一条回复即评论正文
+// 开放平台的评论和回复接口都不返回表情回应，也没有单独查询表情回应的接口，
+// 因此无法提供表情回应数量
+type CommentReply struct {
+	ReplyID    string           `json:"reply_id"`
+	UserID     string           `json:"user_id,omitempty"`
+	CreateTime int              `json:"create_time,omitempty"`
+	UpdateTime int              `json:"update_time,omitempty"`
+	Text       string           `json:"text"` // 纯文本内容，@联系人和云文档链接以占位形式展示
+	Elements   []CommentElement `json:"elements,omitempty"`
+}
+
+// commentElementsText 将回复元素拼接为纯文本
+func commentElementsText(elements []CommentElement) string {
+	var sb strings.Builder
+	for _, e := range elements {
+		switch e.Type {
+		case "text_run":
+			sb.WriteString(e.TextRun)
+		case "docs_link":
+			sb.WriteString(e.DocsLink)
+		case "person":
+			sb.WriteString("@" + e.Person)
+		}
+	}
+	return sb.String()
 }
 
 // CommentElement 评论元素
@@ -33,7 +62,7 @@ type CommentElement struct {
 }
 
 // ListComments 获取文档评论列表
-func ListComments(fileToken string, fileType string, pageSize int, pageToken string) ([]*Comment, string, bool, error) {
+func ListComments(ctx context.Context, fileToken string, fileType string, pageSize int, pageToken string) ([]*Comment, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -50,28 +79,28 @@ func ListComments(fileToken string, fileType string, pageSize int, pageToken str
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Drive.FileComment.List(Context(), reqBuilder.Build())
+	resp, err := client.Drive.FileComment.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取评论列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取评论列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取评论列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var comments []*Comment
 	if resp.Data != nil && resp.Data.Items != nil {
 		for _, item := range resp.Data.Items {
-			comments = append(comments, &Comment{
-				CommentID:    StringVal(item.CommentId),
-				UserID:       StringVal(item.UserId),
-				CreateTime:   IntVal(item.CreateTime),
-				UpdateTime:   IntVal(item.UpdateTime),
-				IsSolved:     BoolVal(item.IsSolved),
-				SolvedTime:   IntVal(item.SolvedTime),
-				SolverUserID: StringVal(item.SolverUserId),
-				IsWhole:      BoolVal(item.IsWhole),
-			})
+			comment := convertComment(item)
+			// 列表接口只返回部分回复，其余回复需要单独分页获取
+			if BoolVal(item.HasMore) {
+				replies, err := ListCommentReplies(ctx, fileToken, comment.CommentID, fileType)
+				if err != nil {
+					return nil, "", false, err
+				}
+				comment.Replies = replies
+			}
+			comments = append(comments, comment)
 		}
 	}
 
@@ -85,44 +114,71 @@ func ListComments(fileToken string, fileType string, pageSize int, pageToken str
 	return comments, nextPageToken, hasMore, nil
 }
 
-// CreateComment 创建评论
-func CreateComment(fileToken string, fileType string, content string) (string, error) {
+// CreateComment 创建全文评论
+func CreateComment(ctx context.Context, fileToken string, fileType string, content string) (string, error) {
+	return createComment(ctx, fileToken, fileType, larkdrive.NewFileCommentBuilder(), content)
+}
+
+// CreatePartialComment 创建引用文档中指定文本的局部评论
+func CreatePartialComment(ctx context.Context, fileToken string, fileType string, quote string, content string) (string, error) {
+	builder := larkdrive.NewFileCommentBuilder().
+		IsWhole(false).
+		Quote(quote)
+	return createComment(ctx, fileToken, fileType, builder, content)
+}
+
+// ReplyComment 回复已有评论，返回回复 ID
+// SDK 的 FileCommentReply 没有创建接口，直接调用添加回复接口
+func ReplyComment(ctx context.Context, fileToken string, commentID string, fileType string, content string) (string, error) {
+	body, err := CallAPI(ctx, APIRequest{
+		Method: http.MethodPost,
+		Path:   replyCommentPath(fileToken, commentID),
+		Query:  url.Values{"file_type": {fileType}},
+		Body:   map[string]any{"content": newReplyContent(content)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("回复评论失败: %w", err)
+	}
+
+	var resp struct {
+		Data struct {
+			ReplyID string `json:"reply_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("解析回复评论响应失败: %w", err)
+	}
+	return resp.Data.ReplyID, nil
+}
+
+// replyCommentPath 添加回复接口的路径
+func replyCommentPath(fileToken, commentID string) string {
+	return fmt.Sprintf("/open-apis/drive/v1/files/%s/comments/%s/replies",
+		url.PathEscape(fileToken), url.PathEscape(commentID))
+}
+
+// createComment 创建评论，builder 中设置评论范围（全文或局部引用）
+func createComment(ctx context.Context, fileToken string, fileType string, builder *larkdrive.FileCommentBuilder, content string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
 	}
 
-	textRun := larkdrive.NewTextRunBuilder().
-		Text(content).
-		Build()
-	element := larkdrive.NewReplyElementBuilder().
-		Type("text_run").
-		TextRun(textRun).
-		Build()
-	replyContent := larkdrive.NewReplyContentBuilder().
-		Elements([]*larkdrive.ReplyElement{element}).
-		Build()
-	reply := larkdrive.NewFileCommentReplyBuilder().
-		Content(replyContent).
-		Build()
-
 	req := larkdrive.NewCreateFileCommentReqBuilder().
 		FileToken(fileToken).
 		FileType(fileType).
-		FileComment(larkdrive.NewFileCommentBuilder().
-			ReplyList(larkdrive.NewReplyListBuilder().
-				Replies([]*larkdrive.FileCommentReply{reply}).
-				Build()).
+		FileComment(builder.
+			ReplyList(newReplyList(content)).
 			Build()).
 		Build()
 
-	resp, err := client.Drive.FileComment.Create(Context(), req)
+	resp, err := client.Drive.FileComment.Create(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("创建评论失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("创建评论失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("创建评论失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data != nil && resp.Data.CommentId != nil {
@@ -132,8 +188,32 @@ func CreateComment(fileToken string, fileType string, content string) (string, e
 	return "", nil
 }
 
+// newReplyContent 构建纯文本回复内容
+func newReplyContent(content string) *larkdrive.ReplyContent {
+	textRun := larkdrive.NewTextRunBuilder().
+		Text(content).
+		Build()
+	element := larkdrive.NewReplyElementBuilder().
+		Type("text_run").
+		TextRun(textRun).
+		Build()
+	return larkdrive.NewReplyContentBuilder().
+		Elements([]*larkdrive.ReplyElement{element}).
+		Build()
+}
+
+// newReplyList 构建只包含一条纯文本回复的回复列表
+func newReplyList(content string) *larkdrive.ReplyList {
+	reply := larkdrive.NewFileCommentReplyBuilder().
+		Content(newReplyContent(content)).
+		Build()
+	return larkdrive.NewReplyListBuilder().
+		Replies([]*larkdrive.FileCommentReply{reply}).
+		Build()
+}
+
 // GetComment 获取评论详情
-func GetComment(fileToken string, commentID string, fileType string) (*Comment, error) {
+func GetComment(ctx context.Context, fileToken string, commentID string, fileType string) (*Comment, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -145,26 +225,40 @@ func GetComment(fileToken string, commentID string, fileType string) (*Comment,
 		FileType(fileType).
 		Build()
 
-	resp, err := client.Drive.FileComment.Get(Context(), req)
+	resp, err := client.Drive.FileComment.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取评论详情失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取评论详情失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取评论详情失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data == nil {
 		return nil, fmt.Errorf("评论不存在")
 	}
 
-	return &Comment{
-		CommentID:  StringVal(resp.Data.CommentId),
-		UserID:     StringVal(resp.Data.UserId),
-		CreateTime: IntVal(resp.Data.CreateTime),
-		IsSolved:   BoolVal(resp.Data.IsSolved),
-		IsWhole:    BoolVal(resp.Data.IsWhole),
-	}, nil
+	comment := &Comment{
+		CommentID:    StringVal(resp.Data.CommentId),
+		UserID:       StringVal(resp.Data.UserId),
+		CreateTime:   IntVal(resp.Data.CreateTime),
+		UpdateTime:   IntVal(resp.Data.UpdateTime),
+		IsSolved:     BoolVal(resp.Data.IsSolved),
+		SolvedTime:   IntVal(resp.Data.SolvedTime),
+		SolverUserID: StringVal(resp.Data.SolverUserId),
+		IsWhole:      BoolVal(resp.Data.IsWhole),
+		Quote:        StringVal(resp.Data.Quote),
+	}
+	if BoolVal(resp.Data.HasMore) {
+		comment.Replies, err = ListCommentReplies(ctx, fileToken, commentID, fileType)
+		if err != nil {
+			return nil, err
+		}
+	} else if resp.Data.ReplyList != nil {
+		comment.Replies = convertReplies(resp.Data.ReplyList.Replies)
+	}
+
+	return comment, nil
 }
 
 // DeleteComment 删除评论
@@ -172,3 +266,182 @@ func GetComment(fileToken string, commentID string, fileType string) (*Comment,
 func DeleteComment(fileToken string, commentID string, fileType string) error {
 	return fmt.Errorf("删除评论功能暂不支持：当前 SDK 版本未提供删除评论 API")
 }
+
+// SetCommentSolved 将评论标记为已解决或重新打开
+func SetCommentSolved(ctx context.Context, fileToken string, commentID string, fileType string, solved bool) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	req := larkdrive.NewPatchFileCommentReqBuilder().
+		FileToken(fileToken).
+		CommentId(commentID).
+		FileType(fileType).
+		Body(larkdrive.NewPatchFileCommentReqBodyBuilder().
+			IsSolved(solved).
+			Build()).
+		Build()
+
+	resp, err := client.Drive.FileComment.Patch(ctx, req)
+	if err != nil {
+		return fmt.Errorf("更新评论状态失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return newAPIError("更新评论状态失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	return nil
+}
+
+// ListCommentReplies 获取评论的全部回复
+func ListCommentReplies(ctx context.Context, fileToken string, commentID string, fileType string) ([]*CommentReply, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var replies []*CommentReply
+	pageToken := ""
+	for {
+		reqBuilder := larkdrive.NewListFileCommentReplyReqBuilder().
+			FileToken(fileToken).
+			CommentId(commentID).
+			FileType(fileType).
+			PageSize(100)
+		if pageToken != "" {
+			reqBuilder.PageToken(pageToken)
+		}
+
+		resp, err := client.Drive.FileCommentReply.List(ctx, reqBuilder.Build())
+		if err != nil {
+			return nil, fmt.Errorf("获取评论回复失败: %w", err)
+		}
+
+		if !resp.Success() {
+			return nil, newAPIError("获取评论回复失败", resp.Code, resp.Msg, resp.ApiResp)
+		}
+
+		if resp.Data == nil {
+			return replies, nil
+		}
+		replies = append(replies, convertReplies(resp.Data.Items)...)
+
+		pageToken = StringVal(resp.Data.PageToken)
+		if !BoolVal(resp.Data.HasMore) || pageToken == "" {
+			return replies, nil
+		}
+	}
+}
+
+// UpdateCommentReply 更新评论回复的内容
+func UpdateCommentReply(ctx context.Context, fileToken string, commentID string, replyID string, fileType string, content string) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	req := larkdrive.NewUpdateFileCommentReplyReqBuilder().
+		FileToken(fileToken).
+		CommentId(commentID).
+		ReplyId(replyID).
+		FileType(fileType).
+		Body(larkdrive.NewUpdateFileCommentReplyReqBodyBuilder().
+			Content(newReplyContent(content)).
+			Build()).
+		Build()
+
+	resp, err := client.Drive.FileCommentReply.Update(ctx, req)
+	if err != nil {
+		return fmt.Errorf("更新评论回复失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return newAPIError("更新评论回复失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	return nil
+}
+
+// DeleteCommentReply 删除评论回复
+func DeleteCommentReply(ctx context.Context, fileToken string, commentID string, replyID string, fileType string) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	req := larkdrive.NewDeleteFileCommentReplyReqBuilder().
+		FileToken(fileToken).
+		CommentId(commentID).
+		ReplyId(replyID).
+		FileType(fileType).
+		Build()
+
+	resp, err := client.Drive.FileCommentReply.Delete(ctx, req)
+	if err != nil {
+		return fmt.Errorf("删除评论回复失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return newAPIError("删除评论回复失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	return nil
+}
+
+// convertComment 转换 SDK 评论结构
+func convertComment(item *larkdrive.FileComment) *Comment {
+	comment := &Comment{
+		CommentID:    StringVal(item.CommentId),
+		UserID:       StringVal(item.UserId),
+		CreateTime:   IntVal(item.CreateTime),
+		UpdateTime:   IntVal(item.UpdateTime),
+		IsSolved:     BoolVal(item.IsSolved),
+		SolvedTime:   IntVal(item.SolvedTime),
+		SolverUserID: StringVal(item.SolverUserId),
+		IsWhole:      BoolVal(item.IsWhole),
+		Quote:        StringVal(item.Quote),
+	}
+	if item.ReplyList != nil {
+		comment.Replies = convertReplies(item.ReplyList.Replies)
+	}
+	return comment
+}
+
+// convertReplies 转换 SDK 回复结构
+func convertReplies(items []*larkdrive.FileCommentReply) []*CommentReply {
+	replies := make([]*CommentReply, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		reply := &CommentReply{
+			ReplyID:    StringVal(item.ReplyId),
+			UserID:     StringVal(item.UserId),
+			CreateTime: IntVal(item.CreateTime),
+			UpdateTime: IntVal(item.UpdateTime),
+		}
+		if item.Content != nil {
+			for _, e := range item.Content.Elements {
+				if e == nil {
+					continue
+				}
+				elem := CommentElement{Type: StringVal(e.Type)}
+				if e.TextRun != nil {
+					elem.TextRun = StringVal(e.TextRun.Text)
+				}
+				if e.DocsLink != nil {
+					elem.DocsLink = StringVal(e.DocsLink.Url)
+				}
+				if e.Person != nil {
+					elem.Person = StringVal(e.Person.UserId)
+				}
+				reply.Elements = append(reply.Elements, elem)
+			}
+		}
+		reply.Text = commentElementsText(reply.Elements)
+		replies = append(replies, reply)
+	}
+	return replies
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+
+	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
+)
+
+func TestConvertComment(t *testing.T) {
+	str := func(s string) *string { return &s }
+	isWhole := false
+	item := &larkdrive.FileComment{
+		CommentId: str("c1"),
+		IsWhole:   &isWhole,
+		Quote:     str("有问题的句子"),
+		ReplyList: &larkdrive.ReplyList{Replies: []*larkdrive.FileCommentReply{
+			{
+				ReplyId: str("r1"),
+				UserId:  str("ou_a"),
+				Content: &larkdrive.ReplyContent{Elements: []*larkdrive.ReplyElement{
+					{Type: str("text_run"), TextRun: &larkdrive.TextRun{Text: str("请参考 ")}},
+					{Type: str("docs_link"), DocsLink: &larkdrive.DocsLink{Url: str("https://example.feishu.cn/docx/abc")}},
+				}},
+			},
+			{
+				ReplyId: str("r2"),
+				UserId:  str("ou_b"),
+				Content: &larkdrive.ReplyContent{Elements: []*larkdrive.ReplyElement{
+					{Type: str("person"), Person: &larkdrive.Person{UserId: str("ou_a")}},
+					{Type: str("text_run"), TextRun: &larkdrive.TextRun{Text: str(" 已修复")}},
+				}},
+			},
+		}},
+	}
+
+	comment := convertComment(item)
+	if comment.CommentID != "c1" || comment.IsWhole || comment.Quote != "有问题的句子" {
+		t.Errorf("评论字段转换错误: %+v", comment)
+	}
+	if len(comment.Replies) != 2 {
+		t.Fatalf("回复数量 = %d, 期望 2", len(comment.Replies))
+	}
+
+	tests := []struct {
+		reply *CommentReply
+		user  string
+		text  string
+	}{
+		{comment.Replies[0], "ou_a", "请参考 https://example.feishu.cn/docx/abc"},
+		{comment.Replies[1], "ou_b", "@ou_a 已修复"},
+	}
+	for _, tt := range tests {
+		if tt.reply.UserID != tt.user || tt.reply.Text != tt.text {
+			t.Errorf("回复 %s = (%q, %q), 期望 (%q, %q)", tt.reply.ReplyID, tt.reply.UserID, tt.reply.Text, tt.user, tt.text)
+		}
+	}
+}
+
+func TestReplyComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/open-apis/auth/") {
+			fmt.Fprint(w, `{"code":0,"tenant_access_token":"t-test","expire":7200}`)
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/drive/v1/files/doc1/comments/c1/replies" {
+			t.Errorf("请求 = %s %s, 期望添加回复接口", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("file_type"); got != "docx" {
+			t.Errorf("file_type = %q, 期望 docx", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Content struct {
+				Elements []struct {
+					Type    string `json:"type"`
+					TextRun struct {
+						Text string `json:"text"`
+					} `json:"text_run"`
+				} `json:"elements"`
+			} `json:"content"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || len(req.Content.Elements) != 1 || req.Content.Elements[0].TextRun.Text != "已修复" {
+			t.Errorf("请求体 = %s", body)
+		}
+		fmt.Fprint(w, `{"code":0,"data":{"reply_id":"r9"}}`)
+	}))
+	defer server.Close()
+
+	resetClient()
+	resetConfig()
+	os.Unsetenv("FEISHU_APP_ID")
+	os.Unsetenv("FEISHU_APP_SECRET")
+	configFile := t.TempDir() + "/config.yaml"
+	os.WriteFile(configFile, []byte(fmt.Sprintf("app_id: a\napp_secret: b\nbase_url: %s\n", server.URL)), 0600)
+	config.Init(configFile)
+	defer resetClient()
+
+	replyID, err := ReplyComment(context.Background(), "doc1", "c1", "docx", "已修复")
+	if err != nil {
+		t.Fatalf("ReplyComment 返回错误: %v", err)
+	}
+	if replyID != "r9" {
+		t.Errorf("回复 ID = %q, 期望 r9", replyID)
+	}
+}
@@ -1,10 +1,10 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
 	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
 )
@@ -17,7 +17,7 @@ const (
 )
 
 // CreateDocument creates a new document
-func CreateDocument(title string, folderToken string) (*larkdocx.Document, error) {
+func CreateDocument(ctx context.Context, title string, folderToken string) (*larkdocx.Document, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -30,20 +30,20 @@ func CreateDocument(title string, folderToken string) (*larkdocx.Document, error
 			Build()).
 		Build()
 
-	resp, err := client.Docx.Document.Create(Context(), req)
+	resp, err := client.Docx.Document.Create(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("创建文档失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建文档失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建文档失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return resp.Data.Document, nil
 }
 
 // GetDocument retrieves document information
-func GetDocument(documentID string) (*larkdocx.Document, error) {
+func GetDocument(ctx context.Context, documentID string) (*larkdocx.Document, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -53,20 +53,20 @@ func GetDocument(documentID string) (*larkdocx.Document, error) {
 		DocumentId(documentID).
 		Build()
 
-	resp, err := client.Docx.Document.Get(Context(), req)
+	resp, err := client.Docx.Document.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取文档失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取文档失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取文档失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return resp.Data.Document, nil
 }
 
 // GetRawContent retrieves raw JSON content of a document
-func GetRawContent(documentID string) (string, error) {
+func GetRawContent(ctx context.Context, documentID string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -76,13 +76,13 @@ func GetRawContent(documentID string) (string, error) {
 		DocumentId(documentID).
 		Build()
 
-	resp, err := client.Docx.Document.RawContent(Context(), req)
+	resp, err := client.Docx.Document.RawContent(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("获取原始内容失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("获取原始内容失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("获取原始内容失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data.Content == nil {
@@ -93,7 +93,7 @@ func GetRawContent(documentID string) (string, error) {
 }
 
 // ListBlocks retrieves all blocks in a document
-func ListBlocks(documentID string, pageToken string, pageSize int) ([]*larkdocx.Block, string, error) {
+func ListBlocks(ctx context.Context, documentID string, pageToken string, pageSize int) ([]*larkdocx.Block, string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", err
@@ -107,13 +107,13 @@ func ListBlocks(documentID string, pageToken string, pageSize int) ([]*larkdocx.
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Docx.DocumentBlock.List(Context(), reqBuilder.Build())
+	resp, err := client.Docx.DocumentBlock.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", fmt.Errorf("获取块列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", fmt.Errorf("获取块列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", newAPIError("获取块列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	nextPageToken := StringVal(resp.Data.PageToken)
@@ -122,7 +122,7 @@ func ListBlocks(documentID string, pageToken string, pageSize int) ([]*larkdocx.
 }
 
 // GetAllBlocks retrieves all blocks in a document with pagination
-func GetAllBlocks(documentID string) ([]*larkdocx.Block, error) {
+func GetAllBlocks(ctx context.Context, documentID string) ([]*larkdocx.Block, error) {
 	var allBlocks []*larkdocx.Block
 	pageToken := ""
 	pageSize := 500
@@ -133,7 +133,7 @@ func GetAllBlocks(documentID string) ([]*larkdocx.Block, error) {
 		if pageCount >= maxPages {
 			return nil, fmt.Errorf("超过最大分页限制 %d，文档可能有异常", maxPages)
 		}
-		blocks, nextToken, err := ListBlocks(documentID, pageToken, pageSize)
+		blocks, nextToken, err := ListBlocks(ctx, documentID, pageToken, pageSize)
 		if err != nil {
 			return nil, err
 		}
@@ -151,7 +151,7 @@ func GetAllBlocks(documentID string) ([]*larkdocx.Block, error) {
 }
 
 // GetBlock retrieves a specific block
-func GetBlock(documentID string, blockID string) (*larkdocx.Block, error) {
+func GetBlock(ctx context.Context, documentID string, blockID string) (*larkdocx.Block, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -162,112 +162,130 @@ func GetBlock(documentID string, blockID string) (*larkdocx.Block, error) {
 		BlockId(blockID).
 		Build()
 
-	resp, err := client.Docx.DocumentBlock.Get(Context(), req)
+	resp, err := client.Docx.DocumentBlock.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return resp.Data.Block, nil
 }
 
 // CreateBlock creates a new block under a parent block
-func CreateBlock(documentID string, blockID string, children []*larkdocx.Block, index int) ([]*larkdocx.Block, error) {
+func CreateBlock(ctx context.Context, documentID string, blockID string, children []*larkdocx.Block, index int) ([]*larkdocx.Block, error) {
+	created, _, err := CreateBlockAtRevision(ctx, documentID, blockID, children, index, -1)
+	return created, err
+}
+
+// CreateBlockAtRevision 基于指定文档版本创建子块（-1 表示最新版本），返回创建的块和操作后的文档版本
+func CreateBlockAtRevision(ctx context.Context, documentID string, blockID string, children []*larkdocx.Block, index int, revision int) ([]*larkdocx.Block, int, error) {
 	client, err := GetClient()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	req := larkdocx.NewCreateDocumentBlockChildrenReqBuilder().
 		DocumentId(documentID).
 		BlockId(blockID).
-		DocumentRevisionId(-1).
+		DocumentRevisionId(revision).
 		Body(larkdocx.NewCreateDocumentBlockChildrenReqBodyBuilder().
 			Children(children).
 			Index(index).
 			Build()).
 		Build()
 
-	resp, err := client.Docx.DocumentBlockChildren.Create(Context(), req)
+	resp, err := client.Docx.DocumentBlockChildren.Create(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("创建块失败: %w", err)
+		return nil, 0, fmt.Errorf("创建块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, 0, newAPIError("创建块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
-	return resp.Data.Children, nil
+	return resp.Data.Children, IntVal(resp.Data.DocumentRevisionId), nil
 }
 
 // UpdateBlock updates an existing block
-func UpdateBlock(documentID string, blockID string, updateContent any) error {
+func UpdateBlock(ctx context.Context, documentID string, blockID string, updateContent any) error {
+	_, err := UpdateBlockAtRevision(ctx, documentID, blockID, updateContent, -1)
+	return err
+}
+
+// UpdateBlockAtRevision 基于指定文档版本更新块（-1 表示最新版本），返回操作后的文档版本
+func UpdateBlockAtRevision(ctx context.Context, documentID string, blockID string, updateContent any, revision int) (int, error) {
 	client, err := GetClient()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// The updateContent should be marshaled to the appropriate update request body
 	contentBytes, err := json.Marshal(updateContent)
 	if err != nil {
-		return fmt.Errorf("序列化更新内容失败: %w", err)
+		return 0, fmt.Errorf("序列化更新内容失败: %w", err)
 	}
 
 	var updateBody larkdocx.UpdateBlockRequest
 	if err := json.Unmarshal(contentBytes, &updateBody); err != nil {
-		return fmt.Errorf("反序列化更新内容失败: %w", err)
+		return 0, fmt.Errorf("反序列化更新内容失败: %w", err)
 	}
 
 	req := larkdocx.NewPatchDocumentBlockReqBuilder().
 		DocumentId(documentID).
 		BlockId(blockID).
-		DocumentRevisionId(-1).
+		DocumentRevisionId(revision).
 		UpdateBlockRequest(&updateBody).
 		Build()
 
-	resp, err := client.Docx.DocumentBlock.Patch(Context(), req)
+	resp, err := client.Docx.DocumentBlock.Patch(ctx, req)
 	if err != nil {
-		return fmt.Errorf("更新块失败: %w", err)
+		return 0, fmt.Errorf("更新块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return 0, newAPIError("更新块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
-	return nil
+	return IntVal(resp.Data.DocumentRevisionId), nil
 }
 
 // DeleteBlocks deletes child blocks from a parent block by index range
 // startIndex is the starting index (0-based), endIndex is exclusive
-func DeleteBlocks(documentID string, blockID string, startIndex int, endIndex int) error {
+func DeleteBlocks(ctx context.Context, documentID string, blockID string, startIndex int, endIndex int) error {
+	_, err := DeleteBlocksAtRevision(ctx, documentID, blockID, startIndex, endIndex, -1)
+	return err
+}
+
+// DeleteBlocksAtRevision 基于指定文档版本删除子块（-1 表示最新版本），返回操作后的文档版本
+func DeleteBlocksAtRevision(ctx context.Context, documentID string, blockID string, startIndex int, endIndex int, revision int) (int, error) {
 	client, err := GetClient()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	req := larkdocx.NewBatchDeleteDocumentBlockChildrenReqBuilder().
 		DocumentId(documentID).
 		BlockId(blockID).
-		DocumentRevisionId(-1).
+		DocumentRevisionId(revision).
 		Body(larkdocx.NewBatchDeleteDocumentBlockChildrenReqBodyBuilder().
 			StartIndex(startIndex).
 			EndIndex(endIndex).
 			Build()).
 		Build()
 
-	resp, err := client.Docx.DocumentBlockChildren.BatchDelete(Context(), req)
+	resp, err := client.Docx.DocumentBlockChildren.BatchDelete(ctx, req)
 	if err != nil {
-		return fmt.Errorf("删除块失败: %w", err)
+		return 0, fmt.Errorf("删除块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return 0, newAPIError("删除块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
-	return nil
+	return IntVal(resp.Data.DocumentRevisionId), nil
 }
 
 // BatchUpdateBlocksOptions contains options for batch updating blocks
@@ -284,7 +302,7 @@ type BatchUpdateBlocksResult struct {
 }
 
 // BatchUpdateBlocks batch updates blocks in a document
-func BatchUpdateBlocks(documentID string, requestsJSON string, opts BatchUpdateBlocksOptions) (*BatchUpdateBlocksResult, error) {
+func BatchUpdateBlocks(ctx context.Context, documentID string, requestsJSON string, opts BatchUpdateBlocksOptions) (*BatchUpdateBlocksResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -316,13 +334,13 @@ func BatchUpdateBlocks(documentID string, requestsJSON string, opts BatchUpdateB
 		reqBuilder.ClientToken(opts.ClientToken)
 	}
 
-	resp, err := client.Docx.DocumentBlock.BatchUpdate(Context(), reqBuilder.Build())
+	resp, err := client.Docx.DocumentBlock.BatchUpdate(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, fmt.Errorf("批量更新块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("批量更新块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("批量更新块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &BatchUpdateBlocksResult{}
@@ -337,7 +355,7 @@ func BatchUpdateBlocks(documentID string, requestsJSON string, opts BatchUpdateB
 }
 
 // GetBlockChildren retrieves children of a block (first page only)
-func GetBlockChildren(documentID string, blockID string) ([]*larkdocx.Block, error) {
+func GetBlockChildren(ctx context.Context, documentID string, blockID string) ([]*larkdocx.Block, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -348,20 +366,20 @@ func GetBlockChildren(documentID string, blockID string) ([]*larkdocx.Block, err
 		BlockId(blockID).
 		Build()
 
-	resp, err := client.Docx.DocumentBlockChildren.Get(Context(), req)
+	resp, err := client.Docx.DocumentBlockChildren.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取子块失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取子块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取子块失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return resp.Data.Items, nil
 }
 
 // GetAllBlockChildren retrieves all direct children of a block with pagination
-func GetAllBlockChildren(documentID string, blockID string) ([]*larkdocx.Block, error) {
+func GetAllBlockChildren(ctx context.Context, documentID string, blockID string) ([]*larkdocx.Block, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -383,13 +401,13 @@ func GetAllBlockChildren(documentID string, blockID string) ([]*larkdocx.Block,
 			reqBuilder.PageToken(pageToken)
 		}
 
-		resp, err := client.Docx.DocumentBlockChildren.Get(Context(), reqBuilder.Build())
+		resp, err := client.Docx.DocumentBlockChildren.Get(ctx, reqBuilder.Build())
 		if err != nil {
 			return nil, fmt.Errorf("获取子块失败: %w", err)
 		}
 
 		if !resp.Success() {
-			return nil, fmt.Errorf("获取子块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+			return nil, newAPIError("获取子块失败", resp.Code, resp.Msg, resp.ApiResp)
 		}
 
 		allChildren = append(allChildren, resp.Data.Items...)
@@ -414,7 +432,7 @@ type AddBoardResult struct {
 }
 
 // AddBoard adds a board block to document and returns the whiteboard ID
-func AddBoard(documentID string, parentID string, index int) (*AddBoardResult, error) {
+func AddBoard(ctx context.Context, documentID string, parentID string, index int) (*AddBoardResult, error) {
 	if parentID == "" {
 		parentID = documentID
 	}
@@ -427,7 +445,7 @@ func AddBoard(documentID string, parentID string, index int) (*AddBoardResult, e
 	}
 
 	// 创建画板块
-	createdBlocks, err := CreateBlock(documentID, parentID, []*larkdocx.Block{boardBlock}, index)
+	createdBlocks, err := CreateBlock(ctx, documentID, parentID, []*larkdocx.Block{boardBlock}, index)
 	if err != nil {
 		return nil, fmt.Errorf("创建画板块失败: %w", err)
 	}
@@ -451,7 +469,7 @@ func AddBoard(documentID string, parentID string, index int) (*AddBoardResult, e
 // contents: cell content strings (in row-major order)
 // Note: Feishu API automatically creates an empty text block in each cell when creating a table,
 // so we need to update the existing block instead of creating a new one to avoid duplicate rows.
-func FillTableCells(documentID string, cellIDs []string, contents []string) error {
+func FillTableCells(ctx context.Context, documentID string, cellIDs []string, contents []string) error {
 	if len(cellIDs) == 0 || len(contents) == 0 {
 		return nil
 	}
@@ -469,14 +487,14 @@ func FillTableCells(documentID string, cellIDs []string, contents []string) erro
 		}
 	}
 
-	return fillTableCellsInternal(documentID, cellIDs[:cellCount], cellElements)
+	return fillTableCellsInternal(ctx, documentID, cellIDs[:cellCount], cellElements)
 }
 
 // FillTableCellsRich fills table cells with rich text elements (preserving links, styles, etc.)
 // cellIDs: cell block IDs from the created table
 // cellElements: each cell's text elements (in row-major order)
 // fallbackContents: plain text fallback for cells without elements
-func FillTableCellsRich(documentID string, cellIDs []string, cellElements [][]*larkdocx.TextElement, fallbackContents []string) error {
+func FillTableCellsRich(ctx context.Context, documentID string, cellIDs []string, cellElements [][]*larkdocx.TextElement, fallbackContents []string) error {
 	if len(cellIDs) == 0 {
 		return nil
 	}
@@ -494,13 +512,12 @@ func FillTableCellsRich(documentID string, cellIDs []string, cellElements [][]*l
 		}
 	}
 
-	return fillTableCellsInternal(documentID, cellIDs, merged)
+	return fillTableCellsInternal(ctx, documentID, cellIDs, merged)
 }
 
 // fillTableCellsInternal 是 FillTableCells 和 FillTableCellsRich 的统一实现
-func fillTableCellsInternal(documentID string, cellIDs []string, cellElements [][]*larkdocx.TextElement) error {
-	const maxRetries = 5
-
+// 限流和重试由客户端的传输层统一处理
+func fillTableCellsInternal(ctx context.Context, documentID string, cellIDs []string, cellElements [][]*larkdocx.TextElement) error {
 	for i, cellID := range cellIDs {
 		var elements []*larkdocx.TextElement
 		if i < len(cellElements) {
@@ -515,39 +532,28 @@ func fillTableCellsInternal(documentID string, cellIDs []string, cellElements []
 		var err error
 		if len(groups) > 1 {
 			// 多块：删除已有空块后创建多个正确类型的块（支持标题、列表等）
-			err = fillCellMultiBlocks(documentID, cellID, groups, maxRetries)
+			err = fillCellMultiBlocks(ctx, documentID, cellID, groups)
 		} else {
 			// 单块：更新已有空块（飞书创建表格时自动生成）
-			err = fillCellSingleBlock(documentID, cellID, elements, maxRetries)
+			err = fillCellSingleBlock(ctx, documentID, cellID, elements)
 		}
 		if err != nil {
 			return fmt.Errorf("填充单元格 %d 失败: %w", i, err)
 		}
-
-		throttlePer5(i)
 	}
 
 	return nil
 }
 
 // fillCellSingleBlock 用单个文本块填充单元格（优先更新已有空块）
-func fillCellSingleBlock(documentID, cellID string, elements []*larkdocx.TextElement, maxRetries int) error {
+func fillCellSingleBlock(ctx context.Context, documentID, cellID string, elements []*larkdocx.TextElement) error {
 	// 尝试更新已有子块（飞书创建表格时自动生成空文本块）
-	children, childErr := GetBlockChildren(documentID, cellID)
+	children, childErr := GetBlockChildren(ctx, documentID, cellID)
 	if childErr == nil && len(children) > 0 {
 		existingBlockID := StringVal(children[0].BlockId)
 		if existingBlockID != "" {
-			updateContent := buildCellUpdateContent(elements)
-			for attempt := 0; attempt < maxRetries; attempt++ {
-				err := UpdateBlock(documentID, existingBlockID, updateContent)
-				if err == nil {
-					return nil
-				}
-				if IsRateLimitError(err) {
-					time.Sleep(time.Duration(2+attempt*2) * time.Second)
-					continue
-				}
-				break
+			if err := UpdateBlock(ctx, documentID, existingBlockID, buildCellUpdateContent(elements)); err == nil {
+				return nil
 			}
 		}
 	}
@@ -558,40 +564,20 @@ func fillCellSingleBlock(documentID, cellID string, elements []*larkdocx.TextEle
 		BlockType: &blockType,
 		Text:      &larkdocx.Text{Elements: elements},
 	}
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		_, err := CreateBlock(documentID, cellID, []*larkdocx.Block{textBlock}, 0)
-		if err == nil {
-			return nil
-		}
-		if IsRateLimitError(err) {
-			time.Sleep(time.Duration(2+attempt*2) * time.Second)
-			continue
-		}
-		return err
-	}
-	return fmt.Errorf("rate limit: 填充单元格重试 %d 次后仍失败", maxRetries)
+	_, err := CreateBlock(ctx, documentID, cellID, []*larkdocx.Block{textBlock}, 0)
+	return err
 }
 
 // fillCellMultiBlocks 用多个块填充单元格（支持 bullet/heading/text 混合）
-func fillCellMultiBlocks(documentID, cellID string, groups []cellBlockGroup, maxRetries int) error {
+func fillCellMultiBlocks(ctx context.Context, documentID, cellID string, groups []cellBlockGroup) error {
 	// 获取飞书自动创建的空文本块，更新其内容以避免留下空块
 	startIdx := 0
-	children, childErr := GetBlockChildren(documentID, cellID)
+	children, childErr := GetBlockChildren(ctx, documentID, cellID)
 	if childErr == nil && len(children) > 0 && len(groups) > 0 && len(groups[0].elements) > 0 {
 		existingBlockID := StringVal(children[0].BlockId)
 		if existingBlockID != "" {
-			updateContent := buildCellUpdateContent(groups[0].elements)
-			for attempt := 0; attempt < maxRetries; attempt++ {
-				err := UpdateBlock(documentID, existingBlockID, updateContent)
-				if err == nil {
-					startIdx = 1 // 第一组已通过更新处理
-					break
-				}
-				if IsRateLimitError(err) {
-					time.Sleep(time.Duration(2+attempt*2) * time.Second)
-					continue
-				}
-				break
+			if err := UpdateBlock(ctx, documentID, existingBlockID, buildCellUpdateContent(groups[0].elements)); err == nil {
+				startIdx = 1 // 第一组已通过更新处理
 			}
 		}
 	}
@@ -602,23 +588,9 @@ func fillCellMultiBlocks(documentID, cellID string, groups []cellBlockGroup, max
 		if len(group.elements) == 0 {
 			continue
 		}
-		block := buildCellBlock(group)
-		created := false
-		for attempt := 0; attempt < maxRetries; attempt++ {
-			_, err := CreateBlock(documentID, cellID, []*larkdocx.Block{block}, -1)
-			if err == nil {
-				created = true
-				break
-			}
-			if IsRateLimitError(err) {
-				time.Sleep(time.Duration(2+attempt*2) * time.Second)
-				continue
-			}
+		if _, err := CreateBlock(ctx, documentID, cellID, []*larkdocx.Block{buildCellBlock(group)}, -1); err != nil {
 			return err
 		}
-		if !created {
-			return fmt.Errorf("rate limit: 创建单元格块重试 %d 次后仍失败", maxRetries)
-		}
 	}
 	return nil
 }
@@ -763,13 +735,6 @@ func buildCellUpdateContent(elements []*larkdocx.TextElement) map[string]any {
 	}
 }
 
-// throttlePer3 每 3 个单元格暂停 500ms，避免触发频率限制
-func throttlePer5(index int) {
-	if index%3 == 2 {
-		time.Sleep(500 * time.Millisecond)
-	}
-}
-
 // buildElementsJSON 将 TextElement 转换为 UpdateBlock API 所需的 JSON 格式
 func buildElementsJSON(elements []*larkdocx.TextElement) []map[string]any {
 	var result []map[string]any
@@ -817,8 +782,8 @@ func buildElementsJSON(elements []*larkdocx.TextElement) []map[string]any {
 }
 
 // GetTableCellIDs retrieves cell block IDs from a table block
-func GetTableCellIDs(documentID string, tableBlockID string) ([]string, error) {
-	block, err := GetBlock(documentID, tableBlockID)
+func GetTableCellIDs(ctx context.Context, documentID string, tableBlockID string) ([]string, error) {
+	block, err := GetBlock(ctx, documentID, tableBlockID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,7 +20,7 @@ const maxDownloadSize = 100 * 1024 * 1024
 const downloadTimeout = 5 * time.Minute
 
 // UploadMedia uploads a file to Feishu drive
-func UploadMedia(filePath string, parentType string, parentNode string, fileName string) (string, error) {
+func UploadMedia(ctx context.Context, filePath string, parentType string, parentNode string, fileName string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -51,13 +52,13 @@ func UploadMedia(filePath string, parentType string, parentNode string, fileName
 			Build()).
 		Build()
 
-	resp, err := client.Drive.Media.UploadAll(Context(), req)
+	resp, err := client.Drive.Media.UploadAll(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("上传素材失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("上传素材失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("上传素材失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data.FileToken == nil {
@@ -68,7 +69,7 @@ func UploadMedia(filePath string, parentType string, parentNode string, fileName
 }
 
 // DownloadMedia downloads a file from Feishu drive
-func DownloadMedia(fileToken string, outputPath string) error {
+func DownloadMedia(ctx context.Context, fileToken string, outputPath string) error {
 	if err := validatePath(outputPath); err != nil {
 		return err
 	}
@@ -82,20 +83,22 @@ func DownloadMedia(fileToken string, outputPath string) error {
 		FileToken(fileToken).
 		Build()
 
-	resp, err := client.Drive.Media.Download(ContextWithTimeout(downloadTimeout), req)
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+	resp, err := client.Drive.Media.Download(ctx, req)
 	if err != nil {
 		return fmt.Errorf("下载素材失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("下载素材失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("下载素材失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return saveToFile(resp.File, outputPath)
 }
 
 // GetMediaTempURL gets a temporary download URL for a media file
-func GetMediaTempURL(fileToken string) (string, error) {
+func GetMediaTempURL(ctx context.Context, fileToken string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -105,13 +108,13 @@ func GetMediaTempURL(fileToken string) (string, error) {
 		FileTokens([]string{fileToken}).
 		Build()
 
-	resp, err := client.Drive.Media.BatchGetTmpDownloadUrl(Context(), req)
+	resp, err := client.Drive.Media.BatchGetTmpDownloadUrl(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("获取临时下载链接失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("获取临时下载链接失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("获取临时下载链接失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if len(resp.Data.TmpDownloadUrls) == 0 {
@@ -126,7 +129,7 @@ func GetMediaTempURL(fileToken string) (string, error) {
 }
 
 // DownloadFromURL downloads a file from a URL with size limit
-func DownloadFromURL(url string, outputPath string) error {
+func DownloadFromURL(ctx context.Context, url string, outputPath string) error {
 	if err := validatePath(outputPath); err != nil {
 		return err
 	}
@@ -135,7 +138,11 @@ func DownloadFromURL(url string, outputPath string) error {
 		Timeout: downloadTimeout,
 	}
 
-	resp, err := httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("从 URL 下载失败: %w", err)
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("从 URL 下载失败: %w", err)
 	}
@@ -200,7 +207,7 @@ type DriveFile struct {
 }
 
 // ListFiles 列出文件夹中的文件
-func ListFiles(folderToken string, pageSize int, pageToken string) ([]*DriveFile, string, bool, error) {
+func ListFiles(ctx context.Context, folderToken string, pageSize int, pageToken string) ([]*DriveFile, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -217,13 +224,13 @@ func ListFiles(folderToken string, pageSize int, pageToken string) ([]*DriveFile
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Drive.File.List(Context(), reqBuilder.Build())
+	resp, err := client.Drive.File.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取文件列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取文件列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取文件列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var files []*DriveFile
@@ -252,8 +259,70 @@ func ListFiles(folderToken string, pageSize int, pageToken string) ([]*DriveFile
 	return files, nextPageToken, hasMore, nil
 }
 
+// FileMeta 云文档元数据
+type FileMeta struct {
+	DocToken         string `json:"doc_token"`
+	DocType          string `json:"doc_type"`
+	Title            string `json:"title"`
+	OwnerID          string `json:"owner_id,omitempty"`
+	CreateTime       string `json:"create_time,omitempty"`
+	LatestModifyUser string `json:"latest_modify_user,omitempty"`
+	LatestModifyTime string `json:"latest_modify_time,omitempty"`
+	URL              string `json:"url,omitempty"`
+}
+
+// GetFileMeta 获取单个云文档的元数据（所有者 ID 为 open_id）
+func GetFileMeta(ctx context.Context, docToken string, docType string) (*FileMeta, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := larkdrive.NewBatchQueryMetaReqBuilder().
+		UserIdType("open_id").
+		MetaRequest(larkdrive.NewMetaRequestBuilder().
+			RequestDocs([]*larkdrive.RequestDoc{
+				larkdrive.NewRequestDocBuilder().
+					DocToken(docToken).
+					DocType(docType).
+					Build(),
+			}).
+			WithUrl(true).
+			Build()).
+		Build()
+
+	resp, err := client.Drive.Meta.BatchQuery(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("获取文档元数据失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return nil, newAPIError("获取文档元数据失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	if resp.Data == nil || len(resp.Data.Metas) == 0 {
+		if resp.Data != nil && len(resp.Data.FailedList) > 0 {
+			// 单个文档失败时接口整体返回成功，错误码在 failed_list 中
+			return nil, newAPIError("获取文档元数据失败", IntVal(resp.Data.FailedList[0].Code), "failed_list", resp.ApiResp)
+		}
+		return nil, fmt.Errorf("获取文档元数据失败: 未返回数据")
+	}
+
+	m := resp.Data.Metas[0]
+	return &FileMeta{
+		DocToken:         StringVal(m.DocToken),
+		DocType:          StringVal(m.DocType),
+		Title:            StringVal(m.Title),
+		OwnerID:          StringVal(m.OwnerId),
+		CreateTime:       StringVal(m.CreateTime),
+		LatestModifyUser: StringVal(m.LatestModifyUser),
+		LatestModifyTime: StringVal(m.LatestModifyTime),
+		URL:              StringVal(m.Url),
+	}, nil
+}
+
 // CreateFolder 创建文件夹
-func CreateFolder(name string, folderToken string) (string, string, error) {
+func CreateFolder(ctx context.Context, name string, folderToken string) (string, string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", "", err
@@ -266,13 +335,13 @@ func CreateFolder(name string, folderToken string) (string, string, error) {
 			Build()).
 		Build()
 
-	resp, err := client.Drive.File.CreateFolder(Context(), req)
+	resp, err := client.Drive.File.CreateFolder(ctx, req)
 	if err != nil {
 		return "", "", fmt.Errorf("创建文件夹失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", "", fmt.Errorf("创建文件夹失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", "", newAPIError("创建文件夹失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var token, url string
@@ -285,7 +354,7 @@ func CreateFolder(name string, folderToken string) (string, string, error) {
 }
 
 // MoveFile 移动文件或文件夹
-func MoveFile(fileToken string, targetFolderToken string, fileType string) (string, error) {
+func MoveFile(ctx context.Context, fileToken string, targetFolderToken string, fileType string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -299,13 +368,13 @@ func MoveFile(fileToken string, targetFolderToken string, fileType string) (stri
 		FileToken(fileToken).
 		Build()
 
-	resp, err := client.Drive.File.Move(Context(), req)
+	resp, err := client.Drive.File.Move(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("移动文件失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("移动文件失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("移动文件失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data != nil && resp.Data.TaskId != nil {
@@ -316,7 +385,7 @@ func MoveFile(fileToken string, targetFolderToken string, fileType string) (stri
 }
 
 // CopyFile 复制文件
-func CopyFile(fileToken string, targetFolderToken string, name string, fileType string) (string, string, error) {
+func CopyFile(ctx context.Context, fileToken string, targetFolderToken string, name string, fileType string) (string, string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", "", err
@@ -335,13 +404,13 @@ func CopyFile(fileToken string, targetFolderToken string, name string, fileType
 		Body(reqBuilder.Build()).
 		Build()
 
-	resp, err := client.Drive.File.Copy(Context(), req)
+	resp, err := client.Drive.File.Copy(ctx, req)
 	if err != nil {
 		return "", "", fmt.Errorf("复制文件失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", "", fmt.Errorf("复制文件失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", "", newAPIError("复制文件失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var token, url string
@@ -354,7 +423,7 @@ func CopyFile(fileToken string, targetFolderToken string, name string, fileType
 }
 
 // DeleteFile 删除文件或文件夹
-func DeleteFile(fileToken string, fileType string) (string, error) {
+func DeleteFile(ctx context.Context, fileToken string, fileType string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -365,13 +434,13 @@ func DeleteFile(fileToken string, fileType string) (string, error) {
 		Type(fileType).
 		Build()
 
-	resp, err := client.Drive.File.Delete(Context(), req)
+	resp, err := client.Drive.File.Delete(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("删除文件失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("删除文件失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("删除文件失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data != nil && resp.Data.TaskId != nil {
@@ -390,7 +459,7 @@ type ShortcutInfo struct {
 }
 
 // CreateShortcut 创建文件快捷方式
-func CreateShortcut(parentToken string, targetFileToken string, targetType string) (*ShortcutInfo, error) {
+func CreateShortcut(ctx context.Context, parentToken string, targetFileToken string, targetType string) (*ShortcutInfo, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -406,13 +475,13 @@ func CreateShortcut(parentToken string, targetFileToken string, targetType strin
 			Build()).
 		Build()
 
-	resp, err := client.Drive.File.CreateShortcut(Context(), req)
+	resp, err := client.Drive.File.CreateShortcut(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("创建快捷方式失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建快捷方式失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建快捷方式失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	info := &ShortcutInfo{
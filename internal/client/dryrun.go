@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrDryRun dry-run 模式下拦截写请求时返回的错误，命令因此停止而不会继续发送依赖该结果的请求
+var ErrDryRun = errors.New("dry-run 模式，请求未发送")
+
+// DryRunRequest dry-run 模式下拦截的请求，敏感字段已脱敏
+type DryRunRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   any    `json:"body,omitempty"` // JSON 请求体，其他类型的请求体只记录类型和大小
+}
+
+// dryRunReadOnlyPaths 使用 POST 的只读接口，dry-run 时照常发送
+var dryRunReadOnlyPaths = []string{
+	"/open-apis/auth/",   // 获取 tenant_access_token / app_access_token
+	"/open-apis/authen/", // 用户身份
+	"/open-apis/search/", // 消息、应用搜索
+	"/open-apis/drive/v1/metas/batch_query",
+}
+
+// dryRunSecretKeys 请求体和查询参数中需要脱敏的字段
+var dryRunSecretKeys = map[string]bool{
+	"app_secret":          true,
+	"client_secret":       true,
+	"password":            true,
+	"access_token":        true,
+	"refresh_token":       true,
+	"user_access_token":   true,
+	"tenant_access_token": true,
+	"app_access_token":    true,
+	"app_ticket":          true,
+}
+
+const redacted = "***"
+
+var (
+	dryRunMu       sync.Mutex
+	dryRunRequests []DryRunRequest
+)
+
+// DryRunRequests 返回 dry-run 模式下拦截的请求
+func DryRunRequests() []DryRunRequest {
+	dryRunMu.Lock()
+	defer dryRunMu.Unlock()
+	return append([]DryRunRequest{}, dryRunRequests...)
+}
+
+// dryRunTransport 记录写请求而不发送，读请求交给 base 处理
+type dryRunTransport struct {
+	base http.RoundTripper
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isWriteRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	record, err := newDryRunRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	dryRunMu.Lock()
+	dryRunRequests = append(dryRunRequests, record)
+	dryRunMu.Unlock()
+	return nil, fmt.Errorf("%w: %s %s", ErrDryRun, req.Method, req.URL.Path)
+}
+
+// isWriteRequest 判断请求是否会修改数据：GET、HEAD 和已知的只读 POST 接口除外
+func isWriteRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	path := req.URL.Path
+	for _, prefix := range dryRunReadOnlyPaths {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	// 电子表格查找单元格
+	return !(req.Method == http.MethodPost && strings.HasPrefix(path, "/open-apis/sheets/") && strings.HasSuffix(path, "/find"))
+}
+
+// newDryRunRequest 读取请求内容并脱敏，不记录请求头（包含访问凭证）
+func newDryRunRequest(req *http.Request) (DryRunRequest, error) {
+	u := *req.URL
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if dryRunSecretKeys[key] {
+				query.Set(key, redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	record := DryRunRequest{Method: req.Method, URL: u.String()}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return record, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return record, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	if len(body) == 0 {
+		return record, nil
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if strings.Contains(contentType, "json") && json.Valid(body) {
+		record.Body = redactJSON(body)
+	} else {
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		record.Body = fmt.Sprintf("<%s, %d 字节>", contentType, len(body))
+	}
+	return record, nil
+}
+
+// redactJSON 将敏感字段替换为 ***，没有敏感字段时保持原样（包括字段顺序）
+func redactJSON(body []byte) json.RawMessage {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if dec.Decode(&v) != nil || !redactValue(v) {
+		return json.RawMessage(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage(body)
+	}
+	return json.RawMessage(data)
+}
+
+// redactValue 递归脱敏，返回是否修改了内容
+func redactValue(v any) bool {
+	changed := false
+	switch val := v.(type) {
+	case map[string]any:
+		for key, item := range val {
+			if dryRunSecretKeys[key] {
+				val[key] = redacted
+				changed = true
+			} else if redactValue(item) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if redactValue(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
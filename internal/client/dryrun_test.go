@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dryRunRequests = nil
+	defer func() { dryRunRequests = nil }()
+	httpClient := &http.Client{Transport: &dryRunTransport{base: http.DefaultTransport}}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantSent bool
+	}{
+		{"GET 照常发送", http.MethodGet, "/open-apis/docx/v1/documents/doc1", "", true},
+		{"获取 token 照常发送", http.MethodPost, "/open-apis/auth/v3/tenant_access_token/internal", `{"app_id":"a","app_secret":"s"}`, true},
+		{"搜索照常发送", http.MethodPost, "/open-apis/search/v2/message", `{"query":"x"}`, true},
+		{"创建被拦截", http.MethodPost, "/open-apis/docx/v1/documents", `{"title":"t","folder_token":"f"}`, false},
+		{"删除被拦截", http.MethodDelete, "/open-apis/drive/v1/files/f1?type=docx", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := atomic.LoadInt32(&calls)
+			req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			resp, err := httpClient.Do(req)
+			if resp != nil {
+				resp.Body.Close()
+			}
+			sent := atomic.LoadInt32(&calls) > before
+			if sent != tt.wantSent {
+				t.Fatalf("请求是否发送 = %v, 期望 %v", sent, tt.wantSent)
+			}
+			if !tt.wantSent && !errors.Is(err, ErrDryRun) {
+				t.Errorf("被拦截的请求应返回 ErrDryRun，实际: %v", err)
+			}
+		})
+	}
+
+	requests := DryRunRequests()
+	if len(requests) != 2 {
+		t.Fatalf("记录了 %d 个请求, 期望 2", len(requests))
+	}
+	if got := string(requests[0].Body.(json.RawMessage)); got != `{"title":"t","folder_token":"f"}` {
+		t.Errorf("请求体 = %s", got)
+	}
+	if requests[1].Method != http.MethodDelete || !strings.HasSuffix(requests[1].URL, "/open-apis/drive/v1/files/f1?type=docx") {
+		t.Errorf("记录的请求 = %+v", requests[1])
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"没有敏感字段时保持原样", `{"b":1,"a":{"x":"y"}}`, `{"b":1,"a":{"x":"y"}}`},
+		{"顶层字段", `{"app_id":"a","app_secret":"s"}`, `{"app_id":"a","app_secret":"***"}`},
+		{"嵌套字段", `{"items":[{"refresh_token":"r","n":12345678901234567890}]}`, `{"items":[{"n":12345678901234567890,"refresh_token":"***"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.body))); got != tt.want {
+				t.Errorf("redactJSON() = %s, 期望 %s", got, tt.want)
+			}
+		})
+	}
+}
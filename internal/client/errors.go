@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// ErrorKind 错误类别，自动化脚本可据此区分"文档不存在"和"被限流"等情况
+type ErrorKind string
+
+const (
+	ErrorKindAuth       ErrorKind = "auth"       // 凭证无效或 token 过期
+	ErrorKindPermission ErrorKind = "permission" // 缺少 scope 或无权访问资源
+	ErrorKindNotFound   ErrorKind = "not_found"  // 资源不存在或已删除
+	ErrorKindRateLimit  ErrorKind = "rate_limit" // 触发频率限制
+	ErrorKindConflict   ErrorKind = "conflict"   // 版本冲突
+	ErrorKindValidation ErrorKind = "validation" // 参数错误
+	ErrorKindNetwork    ErrorKind = "network"    // 网络不通或连接中断
+	ErrorKindServer     ErrorKind = "server"     // 服务端内部错误
+	ErrorKindUnknown    ErrorKind = "unknown"
+)
+
+// errorCodeKinds 常见业务错误码的类别，未列出的错误码按 HTTP 状态码和错误信息判断
+var errorCodeKinds = map[int]ErrorKind{
+	99991661: ErrorKindAuth, // 缺少 access token
+	99991663: ErrorKindAuth, // tenant_access_token 无效
+	99991664: ErrorKindAuth, // app_access_token 无效
+	99991668: ErrorKindAuth, // user_access_token 无效
+	99991677: ErrorKindAuth, // user_access_token 过期
+
+	99991672: ErrorKindPermission, // 应用未开通 scope
+	99991679: ErrorKindPermission, // 用户未授权 scope
+	1770032:  ErrorKindPermission, // 无文档权限
+	131006:   ErrorKindPermission, // 无知识库权限
+	91403:    ErrorKindPermission, // 无表格权限
+	1061004:  ErrorKindPermission, // 无云空间文件权限
+	970003:   ErrorKindPermission, // 无权访问文档元数据（failed_list）
+
+	1770002: ErrorKindNotFound, // 文档不存在
+	131005:  ErrorKindNotFound, // 知识库节点不存在
+	91402:   ErrorKindNotFound, // 表格不存在
+	1061007: ErrorKindNotFound, // 文件已删除
+	970005:  ErrorKindNotFound, // 文档不存在或已删除（failed_list）
+
+	codeRateLimited: ErrorKindRateLimit,
+
+	1770001:  ErrorKindValidation, // 参数错误
+	99992402: ErrorKindValidation, // 字段校验失败
+}
+
+// APIError 开放平台接口返回的错误
+type APIError struct {
+	Op         string // 失败的操作，如 "创建文档失败"
+	Code       int    // 业务错误码
+	Msg        string // 错误信息
+	LogID      string // 请求的 log_id，反馈问题时提供给开放平台排查
+	HTTPStatus int    // HTTP 状态码，未知时为 0
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: code=%d, msg=%s", e.Op, e.Code, e.Msg)
+	if e.HTTPStatus != 0 && e.HTTPStatus != http.StatusOK {
+		msg += fmt.Sprintf(", http_status=%d", e.HTTPStatus)
+	}
+	if e.LogID != "" {
+		msg += ", log_id=" + e.LogID
+	}
+	return msg
+}
+
+// Kind 返回错误类别
+func (e *APIError) Kind() ErrorKind {
+	if kind, ok := errorCodeKinds[e.Code]; ok {
+		return kind
+	}
+
+	switch e.HTTPStatus {
+	case http.StatusUnauthorized:
+		return ErrorKindAuth
+	case http.StatusForbidden:
+		return ErrorKindPermission
+	case http.StatusNotFound:
+		return ErrorKindNotFound
+	case http.StatusConflict:
+		return ErrorKindConflict
+	case http.StatusTooManyRequests:
+		return ErrorKindRateLimit
+	}
+	if e.HTTPStatus >= 500 {
+		return ErrorKindServer
+	}
+
+	msg := strings.ToLower(e.Msg)
+	switch {
+	case strings.Contains(msg, "internal error"):
+		return ErrorKindServer
+	case strings.Contains(msg, "not found"), strings.Contains(msg, "not exist"), strings.Contains(msg, "deleted"):
+		return ErrorKindNotFound
+	case strings.Contains(msg, "permission"), strings.Contains(msg, "forbidden"), strings.Contains(msg, "no access"):
+		return ErrorKindPermission
+	case strings.Contains(msg, "frequency limit"), strings.Contains(msg, "rate limit"), strings.Contains(msg, "too many"):
+		return ErrorKindRateLimit
+	case strings.Contains(msg, "invalid param"), strings.Contains(msg, "validation"):
+		return ErrorKindValidation
+	}
+	if e.HTTPStatus == http.StatusBadRequest {
+		return ErrorKindValidation
+	}
+	return ErrorKindUnknown
+}
+
+// Retryable 判断稍后重试是否可能成功
+func (e *APIError) Retryable() bool {
+	kind := e.Kind()
+	return kind == ErrorKindRateLimit || kind == ErrorKindServer
+}
+
+// newAPIError 根据接口响应创建 APIError，resp 为空时不记录 log_id 和 HTTP 状态码
+func newAPIError(op string, code int, msg string, resp *larkcore.ApiResp) *APIError {
+	e := &APIError{Op: op, Code: code, Msg: msg}
+	if resp != nil {
+		e.LogID = resp.RequestId()
+		e.HTTPStatus = resp.StatusCode
+	}
+	return e
+}
+
+// newAPIErrorFromHTTP 根据 net/http 响应创建 APIError，用于不经过 SDK 的请求
+func newAPIErrorFromHTTP(op string, code int, msg string, resp *http.Response) *APIError {
+	logID := resp.Header.Get(larkcore.HttpHeaderKeyLogId)
+	if logID == "" {
+		logID = resp.Header.Get(larkcore.HttpHeaderKeyRequestId)
+	}
+	return &APIError{Op: op, Code: code, Msg: msg, LogID: logID, HTTPStatus: resp.StatusCode}
+}
+
+// newHTTPError 根据非 200 的 HTTP 响应创建 APIError，尽量从 JSON 响应体中读取错误码
+func newHTTPError(op string, resp *larkcore.ApiResp) *APIError {
+	var body struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if json.Unmarshal(resp.RawBody, &body) != nil || body.Msg == "" {
+		body.Msg = strings.TrimSpace(string(resp.RawBody))
+		if len(body.Msg) > 200 {
+			body.Msg = body.Msg[:200] + "..."
+		}
+	}
+	return newAPIError(op, body.Code, body.Msg, resp)
+}
+
+// ErrUserAuthRequired 没有可用的 User Access Token（未登录或 refresh_token 已失效）
+var ErrUserAuthRequired = errors.New("请先运行 'feishu-cli auth login' 进行授权")
+
+// ErrorKindOf 返回错误链中的错误类别
+func ErrorKindOf(err error) ErrorKind {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, ErrUserAuthRequired) {
+		return ErrorKindAuth
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind()
+	}
+	var conflict *RevisionConflictError
+	if errors.As(err, &conflict) {
+		return ErrorKindConflict
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorKindUnknown
+	}
+	// SDK 将连接失败和超时转换为自己的错误类型
+	var dialErr *larkcore.DialFailedError
+	var timeoutErr *larkcore.ClientTimeoutError
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &dialErr) || errors.As(err, &timeoutErr) || errors.As(err, &netErr) || errors.As(err, &urlErr) {
+		return ErrorKindNetwork
+	}
+	var serverTimeout *larkcore.ServerTimeoutError
+	if errors.As(err, &serverTimeout) {
+		return ErrorKindServer
+	}
+	var paramErr *larkcore.IllegalParamError
+	if errors.As(err, &paramErr) {
+		return ErrorKindValidation
+	}
+	return ErrorKindUnknown
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+func TestAPIErrorKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want ErrorKind
+	}{
+		{"已知错误码", &APIError{Code: 1770002, HTTPStatus: 200}, ErrorKindNotFound},
+		{"限流", &APIError{Code: codeRateLimited}, ErrorKindRateLimit},
+		{"按 HTTP 状态码", &APIError{Code: 12345, HTTPStatus: 403}, ErrorKindPermission},
+		{"5xx", &APIError{Code: 0, HTTPStatus: 503}, ErrorKindServer},
+		{"按错误信息", &APIError{Code: 12345, Msg: "node not exist"}, ErrorKindNotFound},
+		{"400 兜底为参数错误", &APIError{Code: 12345, Msg: "bad", HTTPStatus: 400}, ErrorKindValidation},
+		{"未知", &APIError{Code: 12345, Msg: "bad"}, ErrorKindUnknown},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Kind(); got != tt.want {
+			t.Errorf("%s: Kind() = %s, 期望 %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	err := &APIError{Op: "获取文档失败", Code: 1770002, Msg: "not found", LogID: "abc", HTTPStatus: 404}
+	want := "获取文档失败: code=1770002, msg=not found, http_status=404, log_id=abc"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, 期望 %q", got, want)
+	}
+	if err.Retryable() {
+		t.Error("文档不存在不应可重试")
+	}
+	if !(&APIError{Code: codeRateLimited}).Retryable() {
+		t.Error("限流应可重试")
+	}
+}
+
+func TestErrorKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"包装的 APIError", fmt.Errorf("导出失败: %w", &APIError{Code: 99991672}), ErrorKindPermission},
+		{"未登录", fmt.Errorf("获取 token 失败: %w", ErrUserAuthRequired), ErrorKindAuth},
+		{"版本冲突", &RevisionConflictError{}, ErrorKindConflict},
+		{"连接失败", &larkcore.DialFailedError{}, ErrorKindNetwork},
+		{"普通错误", errors.New("失败"), ErrorKindUnknown},
+	}
+	for _, tt := range tests {
+		if got := ErrorKindOf(tt.err); got != tt.want {
+			t.Errorf("%s: ErrorKindOf = %s, 期望 %s", tt.name, got, tt.want)
+		}
+	}
+}
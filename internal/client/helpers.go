@@ -34,33 +34,6 @@ func Int64Val(p *int64) int64 {
 	return *p
 }
 
-// IsRateLimitError 判断错误是否为频率限制错误
-func IsRateLimitError(err error) bool {
-	if err == nil {
-		return false
-	}
-	msg := err.Error()
-	return strings.Contains(msg, "429") ||
-		strings.Contains(msg, "99991400") ||
-		strings.Contains(msg, "frequency limit") ||
-		strings.Contains(msg, "rate limit")
-}
-
-// IsRetryableError 判断错误是否可重试（服务端临时错误）
-func IsRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-	msg := err.Error()
-	return strings.Contains(msg, "500") ||
-		strings.Contains(msg, "502") ||
-		strings.Contains(msg, "503") ||
-		strings.Contains(msg, "429") ||
-		strings.Contains(msg, "internal error") ||
-		strings.Contains(msg, "rate limit") ||
-		strings.Contains(msg, "frequency limit")
-}
-
 // IsPermanentError 判断错误是否为永久性错误（不应重试）
 func IsPermanentError(err error) bool {
 	if err == nil {
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -8,7 +9,7 @@ import (
 )
 
 // SendMessage sends a message to a user or chat
-func SendMessage(receiveIDType string, receiveID string, msgType string, content string) (string, error) {
+func SendMessage(ctx context.Context, receiveIDType string, receiveID string, msgType string, content string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -23,13 +24,13 @@ func SendMessage(receiveIDType string, receiveID string, msgType string, content
 			Build()).
 		Build()
 
-	resp, err := client.Im.Message.Create(Context(), req)
+	resp, err := client.Im.Message.Create(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("发送消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("发送消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("发送消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data.MessageId == nil {
@@ -40,7 +41,7 @@ func SendMessage(receiveIDType string, receiveID string, msgType string, content
 }
 
 // ReplyMessage replies to a message
-func ReplyMessage(messageID string, msgType string, content string) (string, error) {
+func ReplyMessage(ctx context.Context, messageID string, msgType string, content string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -54,13 +55,13 @@ func ReplyMessage(messageID string, msgType string, content string) (string, err
 			Build()).
 		Build()
 
-	resp, err := client.Im.Message.Reply(Context(), req)
+	resp, err := client.Im.Message.Reply(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("回复消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("回复消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("回复消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data.MessageId == nil {
@@ -71,7 +72,7 @@ func ReplyMessage(messageID string, msgType string, content string) (string, err
 }
 
 // UpdateMessage updates a message content
-func UpdateMessage(messageID string, content string) error {
+func UpdateMessage(ctx context.Context, messageID string, content string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -84,13 +85,13 @@ func UpdateMessage(messageID string, content string) error {
 			Build()).
 		Build()
 
-	resp, err := client.Im.Message.Patch(Context(), req)
+	resp, err := client.Im.Message.Patch(ctx, req)
 	if err != nil {
 		return fmt.Errorf("更新消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("更新消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -123,7 +124,7 @@ func CreateInteractiveCardContent(card map[string]any) string {
 }
 
 // DeleteMessage deletes a message by message ID
-func DeleteMessage(messageID string) error {
+func DeleteMessage(ctx context.Context, messageID string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -133,13 +134,13 @@ func DeleteMessage(messageID string) error {
 		MessageId(messageID).
 		Build()
 
-	resp, err := client.Im.Message.Delete(Context(), req)
+	resp, err := client.Im.Message.Delete(ctx, req)
 	if err != nil {
 		return fmt.Errorf("删除消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("删除消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -163,7 +164,7 @@ type ListMessagesResult struct {
 }
 
 // ListMessages lists messages in a container (chat)
-func ListMessages(containerID string, opts ListMessagesOptions) (*ListMessagesResult, error) {
+func ListMessages(ctx context.Context, containerID string, opts ListMessagesOptions) (*ListMessagesResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -189,13 +190,13 @@ func ListMessages(containerID string, opts ListMessagesOptions) (*ListMessagesRe
 		reqBuilder.PageToken(opts.PageToken)
 	}
 
-	resp, err := client.Im.Message.List(Context(), reqBuilder.Build())
+	resp, err := client.Im.Message.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, fmt.Errorf("获取消息列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取消息列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取消息列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return &ListMessagesResult{
@@ -211,7 +212,7 @@ type GetMessageResult struct {
 }
 
 // GetMessage gets a message by message ID
-func GetMessage(messageID string) (*GetMessageResult, error) {
+func GetMessage(ctx context.Context, messageID string) (*GetMessageResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -221,13 +222,13 @@ func GetMessage(messageID string) (*GetMessageResult, error) {
 		MessageId(messageID).
 		Build()
 
-	resp, err := client.Im.Message.Get(Context(), req)
+	resp, err := client.Im.Message.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取消息详情失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取消息详情失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取消息详情失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if len(resp.Data.Items) == 0 {
@@ -240,7 +241,7 @@ func GetMessage(messageID string) (*GetMessageResult, error) {
 }
 
 // ForwardMessage forwards a message to another recipient
-func ForwardMessage(messageID string, receiveID string, receiveIDType string) (string, error) {
+func ForwardMessage(ctx context.Context, messageID string, receiveID string, receiveIDType string) (string, error) {
 	client, err := GetClient()
 	if err != nil {
 		return "", err
@@ -254,13 +255,13 @@ func ForwardMessage(messageID string, receiveID string, receiveIDType string) (s
 			Build()).
 		Build()
 
-	resp, err := client.Im.Message.Forward(Context(), req)
+	resp, err := client.Im.Message.Forward(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("转发消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return "", fmt.Errorf("转发消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return "", newAPIError("转发消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data.MessageId == nil {
@@ -310,7 +311,7 @@ type SearchChatsResult struct {
 }
 
 // SearchChats searches for chats
-func SearchChats(opts SearchChatsOptions) (*SearchChatsResult, error) {
+func SearchChats(ctx context.Context, opts SearchChatsOptions) (*SearchChatsResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -330,13 +331,13 @@ func SearchChats(opts SearchChatsOptions) (*SearchChatsResult, error) {
 		reqBuilder.PageToken(opts.PageToken)
 	}
 
-	resp, err := client.Im.Chat.List(Context(), reqBuilder.Build())
+	resp, err := client.Im.Chat.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, fmt.Errorf("搜索群聊失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("搜索群聊失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("搜索群聊失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &SearchChatsResult{
@@ -398,7 +399,7 @@ func equalIgnoreCase(a, b string) bool {
 }
 
 // GetReadUsers gets the list of users who have read a message
-func GetReadUsers(messageID string, userIDType string, pageSize int, pageToken string) (*ReadUsersResult, error) {
+func GetReadUsers(ctx context.Context, messageID string, userIDType string, pageSize int, pageToken string) (*ReadUsersResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -415,13 +416,13 @@ func GetReadUsers(messageID string, userIDType string, pageSize int, pageToken s
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Im.Message.ReadUsers(Context(), reqBuilder.Build())
+	resp, err := client.Im.Message.ReadUsers(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, fmt.Errorf("查询消息已读用户失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("查询消息已读用户失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("查询消息已读用户失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &ReadUsersResult{
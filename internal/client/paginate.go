@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+)
+
+// PageFunc 获取一页数据，返回本页记录、下一页的 page_token 和是否还有更多
+type PageFunc[T any] func(ctx context.Context, pageToken string) (items []T, nextPageToken string, hasMore bool, err error)
+
+// Paginate 从 pageToken 开始依次获取每一页，并将每条记录交给 fn 处理。
+// limit > 0 时最多处理 limit 条记录。返回已处理的记录数
+//
+// 记录逐页处理，不在内存中保留所有页，fn 返回错误时停止翻页并返回该错误
+func Paginate[T any](ctx context.Context, pageToken string, limit int, fetch PageFunc[T], fn func(T) error) (int, error) {
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		items, next, hasMore, err := fetch(ctx, pageToken)
+		if err != nil {
+			return count, err
+		}
+		for _, item := range items {
+			if limit > 0 && count >= limit {
+				return count, nil
+			}
+			if err := fn(item); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if !hasMore || next == "" || (limit > 0 && count >= limit) {
+			return count, nil
+		}
+		// 避免接口返回相同的 page_token 导致死循环
+		if next == pageToken {
+			return count, fmt.Errorf("分页 token 未变化，停止翻页: %s", next)
+		}
+		pageToken = next
+	}
+}
+
+// 以下函数将返回结果结构体的列表接口适配为 PageFunc，opts 中的 PageToken 由 Paginate 设置
+
+// ListMessagesPages 分页获取会话消息
+func ListMessagesPages(containerID string, opts ListMessagesOptions) PageFunc[*larkim.Message] {
+	return func(ctx context.Context, pageToken string) ([]*larkim.Message, string, bool, error) {
+		opts.PageToken = pageToken
+		result, err := ListMessages(ctx, containerID, opts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.Items, result.PageToken, result.HasMore, nil
+	}
+}
+
+// SearchChatsPages 分页搜索群聊
+func SearchChatsPages(opts SearchChatsOptions) PageFunc[*ChatInfo] {
+	return func(ctx context.Context, pageToken string) ([]*ChatInfo, string, bool, error) {
+		opts.PageToken = pageToken
+		result, err := SearchChats(ctx, opts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.Items, result.PageToken, result.HasMore, nil
+	}
+}
+
+// ReadUsersPages 分页获取消息已读用户
+func ReadUsersPages(messageID, userIDType string, pageSize int) PageFunc[*ReadUser] {
+	return func(ctx context.Context, pageToken string) ([]*ReadUser, string, bool, error) {
+		result, err := GetReadUsers(ctx, messageID, userIDType, pageSize, pageToken)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.Items, result.PageToken, result.HasMore, nil
+	}
+}
+
+// ListTasksPages 分页获取任务
+func ListTasksPages(pageSize int, completed *bool) PageFunc[*TaskInfo] {
+	return func(ctx context.Context, pageToken string) ([]*TaskInfo, string, bool, error) {
+		result, err := ListTasks(ctx, pageSize, pageToken, completed)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.Tasks, result.PageToken, result.HasMore, nil
+	}
+}
+
+// SearchMessagesPages 分页搜索消息，返回消息 ID
+func SearchMessagesPages(opts SearchMessagesOptions, userAccessToken string) PageFunc[string] {
+	return func(ctx context.Context, pageToken string) ([]string, string, bool, error) {
+		opts.PageToken = pageToken
+		result, err := SearchMessages(ctx, opts, userAccessToken)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.MessageIDs, result.PageToken, result.HasMore, nil
+	}
+}
+
+// SearchAppsPages 分页搜索应用，返回应用 ID
+func SearchAppsPages(opts SearchAppsOptions, userAccessToken string) PageFunc[string] {
+	return func(ctx context.Context, pageToken string) ([]string, string, bool, error) {
+		opts.PageToken = pageToken
+		result, err := SearchApps(ctx, opts, userAccessToken)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.AppIDs, result.PageToken, result.HasMore, nil
+	}
+}
+
+// SearchDocsPages 分页搜索文档
+func SearchDocsPages(opts SearchDocsOptions, userAccessToken string) PageFunc[*DocInfo] {
+	return func(ctx context.Context, pageToken string) ([]*DocInfo, string, bool, error) {
+		opts.PageToken = pageToken
+		result, err := SearchDocs(ctx, opts, userAccessToken)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return result.Docs, result.PageToken, result.HasMore, nil
+	}
+}
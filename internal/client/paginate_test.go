@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	// 三页数据，每页两条
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {[]int{1, 2}, "p2"},
+		"p2": {[]int{3, 4}, "p3"},
+		"p3": {[]int{5, 6}, ""},
+	}
+	var requested []string
+	fetch := func(ctx context.Context, pageToken string) ([]int, string, bool, error) {
+		requested = append(requested, pageToken)
+		p := pages[pageToken]
+		return p.items, p.next, p.next != "", nil
+	}
+
+	tests := []struct {
+		name      string
+		start     string
+		limit     int
+		want      []int
+		wantPages int
+	}{
+		{name: "全部", want: []int{1, 2, 3, 4, 5, 6}, wantPages: 3},
+		{name: "限制条数时不请求多余的页", limit: 3, want: []int{1, 2, 3}, wantPages: 2},
+		{name: "限制条数恰好为整页", limit: 4, want: []int{1, 2, 3, 4}, wantPages: 2},
+		{name: "从指定页开始", start: "p3", want: []int{5, 6}, wantPages: 1},
+	}
+	for _, tt := range tests {
+		requested = nil
+		var got []int
+		n, err := Paginate(context.Background(), tt.start, tt.limit, fetch, func(v int) error {
+			got = append(got, v)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: 返回错误: %v", tt.name, err)
+			continue
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || n != len(tt.want) {
+			t.Errorf("%s: 得到 %v (n=%d), 期望 %v", tt.name, got, n, tt.want)
+		}
+		if len(requested) != tt.wantPages {
+			t.Errorf("%s: 请求了 %d 页, 期望 %d 页", tt.name, len(requested), tt.wantPages)
+		}
+	}
+}
+
+func TestPaginate_StopsOnRepeatedToken(t *testing.T) {
+	fetch := func(ctx context.Context, pageToken string) ([]int, string, bool, error) {
+		return []int{1}, "same", true, nil
+	}
+	n, err := Paginate(context.Background(), "", 0, fetch, func(int) error { return nil })
+	if err == nil {
+		t.Fatal("page_token 不变时应返回错误")
+	}
+	if n != 2 {
+		t.Errorf("已处理 %d 条, 期望 2 条", n)
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
@@ -14,7 +15,7 @@ type PermissionMember struct {
 }
 
 // AddPermission adds permission to a document
-func AddPermission(docToken string, docType string, member PermissionMember, notify bool) error {
+func AddPermission(ctx context.Context, docToken string, docType string, member PermissionMember, notify bool) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -33,20 +34,20 @@ func AddPermission(docToken string, docType string, member PermissionMember, not
 		BaseMember(memberObj).
 		Build()
 
-	resp, err := client.Drive.PermissionMember.Create(Context(), req)
+	resp, err := client.Drive.PermissionMember.Create(ctx, req)
 	if err != nil {
 		return fmt.Errorf("添加权限失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("添加权限失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("添加权限失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
 }
 
 // ListPermission lists all permissions for a document
-func ListPermission(docToken string, docType string) ([]*larkdrive.Member, error) {
+func ListPermission(ctx context.Context, docToken string, docType string) ([]*larkdrive.Member, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -57,20 +58,20 @@ func ListPermission(docToken string, docType string) ([]*larkdrive.Member, error
 		Type(docType).
 		Build()
 
-	resp, err := client.Drive.PermissionMember.List(Context(), req)
+	resp, err := client.Drive.PermissionMember.List(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取权限列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取权限列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取权限列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return resp.Data.Items, nil
 }
 
 // DeletePermission removes permission from a document
-func DeletePermission(docToken string, docType string, memberType string, memberID string) error {
+func DeletePermission(ctx context.Context, docToken string, docType string, memberType string, memberID string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -83,20 +84,20 @@ func DeletePermission(docToken string, docType string, memberType string, member
 		MemberType(memberType).
 		Build()
 
-	resp, err := client.Drive.PermissionMember.Delete(Context(), req)
+	resp, err := client.Drive.PermissionMember.Delete(ctx, req)
 	if err != nil {
 		return fmt.Errorf("删除权限失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除权限失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("删除权限失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
 }
 
 // UpdatePublicPermission updates public sharing settings
-func UpdatePublicPermission(docToken string, docType string, externalAccess bool, linkShareEntity string) error {
+func UpdatePublicPermission(ctx context.Context, docToken string, docType string, externalAccess bool, linkShareEntity string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -113,20 +114,20 @@ func UpdatePublicPermission(docToken string, docType string, externalAccess bool
 		PermissionPublicRequest(permPublic).
 		Build()
 
-	resp, err := client.Drive.PermissionPublic.Patch(Context(), req)
+	resp, err := client.Drive.PermissionPublic.Patch(ctx, req)
 	if err != nil {
 		return fmt.Errorf("更新公开权限失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新公开权限失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("更新公开权限失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
 }
 
 // UpdatePermission 更新协作者权限
-func UpdatePermission(docToken string, docType string, memberID string, memberType string, perm string) error {
+func UpdatePermission(ctx context.Context, docToken string, docType string, memberID string, memberType string, perm string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -143,13 +144,44 @@ func UpdatePermission(docToken string, docType string, memberID string, memberTy
 			Build()).
 		Build()
 
-	resp, err := client.Drive.PermissionMember.Update(Context(), req)
+	resp, err := client.Drive.PermissionMember.Update(ctx, req)
 	if err != nil {
 		return fmt.Errorf("更新权限失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新权限失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("更新权限失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	return nil
+}
+
+// TransferOwner 转移文档所有者
+// removeOldOwner 为 false 时原所有者保留 full_access 权限
+func TransferOwner(ctx context.Context, docToken string, docType string, memberType string, memberID string, removeOldOwner bool) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	req := larkdrive.NewTransferOwnerPermissionMemberReqBuilder().
+		Token(docToken).
+		Type(docType).
+		NeedNotification(false).
+		RemoveOldOwner(removeOldOwner).
+		Owner(larkdrive.NewOwnerBuilder().
+			MemberType(memberType).
+			MemberId(memberID).
+			Build()).
+		Build()
+
+	resp, err := client.Drive.PermissionMember.TransferOwner(ctx, req)
+	if err != nil {
+		return fmt.Errorf("转移所有者失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return newAPIError("转移所有者失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+// RevisionConflictError 表示文档版本与预期不一致（文档在读取后已被其他人修改）
+type RevisionConflictError struct {
+	DocumentID string
+	Expected   int
+	Actual     int
+	Reason     string
+}
+
+func (e *RevisionConflictError) Error() string {
+	msg := fmt.Sprintf("文档版本冲突: %s 期望版本 %d, 当前版本 %d", e.DocumentID, e.Expected, e.Actual)
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+// GetDocumentRevision 获取文档当前版本号
+func GetDocumentRevision(ctx context.Context, documentID string) (int, error) {
+	doc, err := GetDocument(ctx, documentID)
+	if err != nil {
+		return 0, err
+	}
+	if doc == nil || doc.RevisionId == nil {
+		return 0, fmt.Errorf("获取文档版本失败: 未返回版本号")
+	}
+	return *doc.RevisionId, nil
+}
+
+// GetBlockAtRevision 获取指定文档版本中的块（-1 表示最新版本）
+// 查询历史版本需要文档的编辑权限
+func GetBlockAtRevision(ctx context.Context, documentID string, blockID string, revision int) (*larkdocx.Block, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := larkdocx.NewGetDocumentBlockReqBuilder().
+		DocumentId(documentID).
+		BlockId(blockID).
+		DocumentRevisionId(revision).
+		Build()
+
+	resp, err := client.Docx.DocumentBlock.Get(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("获取块失败: %w", err)
+	}
+
+	if !resp.Success() {
+		return nil, newAPIError("获取块失败", resp.Code, resp.Msg, resp.ApiResp)
+	}
+
+	return resp.Data.Block, nil
+}
+
+// BlocksUnchangedSince 判断指定块在 revision 版本之后是否未被修改
+// 父块的子块列表也属于块内容，因此插入或删除子块同样会被视为修改
+func BlocksUnchangedSince(ctx context.Context, documentID string, blockIDs []string, revision int) (bool, error) {
+	for _, blockID := range blockIDs {
+		before, err := GetBlockAtRevision(ctx, documentID, blockID, revision)
+		if err != nil {
+			return false, err
+		}
+		after, err := GetBlockAtRevision(ctx, documentID, blockID, -1)
+		if err != nil {
+			return false, err
+		}
+
+		beforeJSON, err := json.Marshal(before)
+		if err != nil {
+			return false, fmt.Errorf("序列化块失败: %w", err)
+		}
+		afterJSON, err := json.Marshal(after)
+		if err != nil {
+			return false, fmt.Errorf("序列化块失败: %w", err)
+		}
+		if !bytes.Equal(beforeJSON, afterJSON) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
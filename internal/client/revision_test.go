@@ -0,0 +1,30 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRevisionConflictError(t *testing.T) {
+	err := fmt.Errorf("更新失败: %w", &RevisionConflictError{
+		DocumentID: "doxcn123",
+		Expected:   5,
+		Actual:     7,
+		Reason:     "目标块已被修改",
+	})
+
+	var conflict *RevisionConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatal("包装后的错误应能通过 errors.As 识别为版本冲突")
+	}
+	if conflict.Expected != 5 || conflict.Actual != 7 {
+		t.Errorf("版本号 = (%d, %d), 期望 (5, 7)", conflict.Expected, conflict.Actual)
+	}
+	for _, want := range []string{"doxcn123", "期望版本 5", "当前版本 7", "目标块已被修改"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("错误信息 %q 缺少 %q", err.Error(), want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,7 +38,7 @@ type SearchMessagesResult struct {
 
 // SearchMessages 搜索消息
 // 注意：此 API 需要 User Access Token
-func SearchMessages(opts SearchMessagesOptions, userAccessToken string) (*SearchMessagesResult, error) {
+func SearchMessages(ctx context.Context, opts SearchMessagesOptions, userAccessToken string) (*SearchMessagesResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -85,14 +86,14 @@ func SearchMessages(opts SearchMessagesOptions, userAccessToken string) (*Search
 	}
 
 	// 使用 User Access Token 调用 API
-	resp, err := client.Search.Message.Create(Context(), reqBuilder.Build(),
+	resp, err := client.Search.Message.Create(ctx, reqBuilder.Build(),
 		larkcore.WithUserAccessToken(userAccessToken))
 	if err != nil {
 		return nil, fmt.Errorf("搜索消息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("搜索消息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("搜索消息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &SearchMessagesResult{
@@ -121,7 +122,7 @@ type SearchAppsResult struct {
 
 // SearchApps 搜索应用
 // 注意：此 API 需要 User Access Token
-func SearchApps(opts SearchAppsOptions, userAccessToken string) (*SearchAppsResult, error) {
+func SearchApps(ctx context.Context, opts SearchAppsOptions, userAccessToken string) (*SearchAppsResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -143,14 +144,14 @@ func SearchApps(opts SearchAppsOptions, userAccessToken string) (*SearchAppsResu
 	}
 
 	// 使用 User Access Token 调用 API
-	resp, err := client.Search.App.Create(Context(), reqBuilder.Build(),
+	resp, err := client.Search.App.Create(ctx, reqBuilder.Build(),
 		larkcore.WithUserAccessToken(userAccessToken))
 	if err != nil {
 		return nil, fmt.Errorf("搜索应用失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("搜索应用失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("搜索应用失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &SearchAppsResult{
@@ -197,7 +198,7 @@ type SearchDocsResult struct {
 
 // SearchDocs 搜索文档
 // 注意：此 API 需要 User Access Token 和 search:docs:read 权限
-func SearchDocs(opts SearchDocsOptions, userAccessToken string) (*SearchDocsResult, error) {
+func SearchDocs(ctx context.Context, opts SearchDocsOptions, userAccessToken string) (*SearchDocsResult, error) {
 	httpClient := &http.Client{Timeout: 30 * time.Second}
 
 	// 构建请求体
@@ -240,7 +241,7 @@ func SearchDocs(opts SearchDocsOptions, userAccessToken string) (*SearchDocsResu
 	// 构建 URL - 使用云文档搜索 API
 	apiURL := "https://open.feishu.cn/open-apis/suite/docs-api/search/object"
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -270,7 +271,7 @@ func SearchDocs(opts SearchDocsOptions, userAccessToken string) (*SearchDocsResu
 	code, _ := result["code"].(float64)
 	if code != 0 {
 		msg, _ := result["msg"].(string)
-		return nil, fmt.Errorf("搜索文档失败: code=%d, msg=%s", int(code), msg)
+		return nil, newAPIErrorFromHTTP("搜索文档失败", int(code), msg, resp)
 	}
 
 	// 解析数据
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	lark "github.com/larksuite/oapi-sdk-go/v3"
-	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larksheets "github.com/larksuite/oapi-sdk-go/v3/service/sheets/v3"
 )
 
@@ -213,7 +212,7 @@ func CreateSpreadsheet(ctx context.Context, title string, folderToken string) (*
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建电子表格失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建电子表格失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return &SpreadsheetInfo{
@@ -240,7 +239,7 @@ func GetSpreadsheet(ctx context.Context, spreadsheetToken string) (*SpreadsheetI
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取电子表格信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取电子表格信息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	info := &SpreadsheetInfo{
@@ -279,7 +278,7 @@ func UpdateSpreadsheetTitle(ctx context.Context, spreadsheetToken, title string)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新表格标题失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("更新表格标题失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -302,7 +301,7 @@ func QuerySheets(ctx context.Context, spreadsheetToken string) ([]*SheetInfo, er
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("查询工作表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("查询工作表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var sheets []*SheetInfo
@@ -355,7 +354,7 @@ func GetSheet(ctx context.Context, spreadsheetToken, sheetID string) (*SheetInfo
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取工作表信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取工作表信息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	s := resp.Data.Sheet
@@ -417,7 +416,7 @@ func FindCells(ctx context.Context, spreadsheetToken, sheetID string, findStr st
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("查找单元格失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("查找单元格失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &FindReplaceResult{}
@@ -468,7 +467,7 @@ func ReplaceCells(ctx context.Context, spreadsheetToken, sheetID string, findStr
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("替换单元格失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("替换单元格失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &FindReplaceResult{}
@@ -487,30 +486,18 @@ func ReplaceCells(ctx context.Context, spreadsheetToken, sheetID string, findStr
 
 // v2APICall 封装 V2 API 调用
 func v2APICall(client *lark.Client, ctx context.Context, method, path string, body any) ([]byte, error) {
-	var resp *larkcore.ApiResp
-	var err error
-
-	// 注意: SDK 的 Post/Put/Delete 方法接收 any 并在内部进行 JSON 序列化
+	// 注意: SDK 接收 any 并在内部进行 JSON 序列化
 	// 不要在这里预先序列化，否则会导致双重序列化
-	switch method {
-	case "GET":
-		resp, err = client.Get(ctx, path, nil, larkcore.AccessTokenTypeTenant)
-	case "POST":
-		resp, err = client.Post(ctx, path, body, larkcore.AccessTokenTypeTenant)
-	case "PUT":
-		resp, err = client.Put(ctx, path, body, larkcore.AccessTokenTypeTenant)
-	case "DELETE":
-		resp, err = client.Delete(ctx, path, body, larkcore.AccessTokenTypeTenant)
-	default:
-		return nil, fmt.Errorf("不支持的 HTTP 方法: %s", method)
+	if method == "GET" {
+		body = nil
 	}
-
+	resp, err := doAPI(ctx, client, APIRequest{Method: method, Path: path, Body: body})
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API 调用失败: HTTP %d, body: %s", resp.StatusCode, string(resp.RawBody))
+		return nil, newHTTPError("API 调用失败", resp)
 	}
 
 	return resp.RawBody, nil
@@ -558,7 +545,7 @@ func ReadCells(ctx context.Context, spreadsheetToken, rangeStr string, valueRend
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("读取单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("读取单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return apiResp.Data.ValueRange, nil
@@ -604,7 +591,7 @@ func ReadCellsBatch(ctx context.Context, spreadsheetToken string, ranges []strin
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("批量读取单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("批量读取单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return apiResp.Data.ValueRanges, nil
@@ -668,7 +655,7 @@ func WriteCells(ctx context.Context, spreadsheetToken, rangeStr string, values [
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("写入单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("写入单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return &CellRange{
@@ -705,7 +692,7 @@ func WriteCellsBatch(ctx context.Context, spreadsheetToken string, valueRanges [
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("批量写入单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("批量写入单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -774,7 +761,7 @@ func AppendCells(ctx context.Context, spreadsheetToken, rangeStr string, values
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("追加数据失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("追加数据失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return &CellRange{
@@ -819,7 +806,7 @@ func PrependCells(ctx context.Context, spreadsheetToken, rangeStr string, values
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("前置插入数据失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("前置插入数据失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return &CellRange{
@@ -859,7 +846,7 @@ func BatchUpdateSheets(ctx context.Context, spreadsheetToken string, requests []
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("批量更新工作表失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("批量更新工作表失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return apiResp.Data.Replies, nil
@@ -988,7 +975,7 @@ func AddDimension(ctx context.Context, spreadsheetToken, sheetID string, majorDi
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("添加行/列失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("添加行/列失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1031,7 +1018,7 @@ func InsertDimension(ctx context.Context, spreadsheetToken, sheetID string, majo
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("插入行/列失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("插入行/列失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1070,7 +1057,7 @@ func DeleteDimension(ctx context.Context, spreadsheetToken, sheetID string, majo
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("删除行/列失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("删除行/列失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1120,7 +1107,7 @@ func UpdateDimension(ctx context.Context, spreadsheetToken, sheetID string, majo
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("更新行/列属性失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("更新行/列属性失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1155,7 +1142,7 @@ func MergeCells(ctx context.Context, spreadsheetToken, rangeStr, mergeType strin
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("合并单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("合并单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1189,7 +1176,7 @@ func UnmergeCells(ctx context.Context, spreadsheetToken, rangeStr string) error
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("取消合并单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("取消合并单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1263,7 +1250,7 @@ func SetCellStyle(ctx context.Context, spreadsheetToken, rangeStr string, style
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("设置单元格样式失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("设置单元格样式失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1297,7 +1284,7 @@ func SetCellStyleBatch(ctx context.Context, spreadsheetToken string, styles []ma
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("批量设置样式失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("批量设置样式失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1331,7 +1318,7 @@ func GetSpreadsheetMeta(ctx context.Context, spreadsheetToken string, extFields
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("获取表格元信息失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("获取表格元信息失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return apiResp.Data, nil
@@ -1366,7 +1353,7 @@ func CreateFilter(ctx context.Context, spreadsheetToken, sheetID, rangeStr strin
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("创建筛选失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("创建筛选失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -1390,7 +1377,7 @@ func GetFilter(ctx context.Context, spreadsheetToken, sheetID string) (*FilterIn
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取筛选信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取筛选信息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	info := &FilterInfo{}
@@ -1422,7 +1409,7 @@ func DeleteFilter(ctx context.Context, spreadsheetToken, sheetID string) error {
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除筛选失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("删除筛选失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -1462,7 +1449,7 @@ func CreateFloatImage(ctx context.Context, spreadsheetToken, sheetID string, ima
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建浮动图片失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建浮动图片失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &FloatImage{}
@@ -1503,7 +1490,7 @@ func DeleteFloatImage(ctx context.Context, spreadsheetToken, sheetID, floatImage
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("删除浮动图片失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("删除浮动图片失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -1527,7 +1514,7 @@ func QueryFloatImages(ctx context.Context, spreadsheetToken, sheetID string) ([]
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("查询浮动图片失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("查询浮动图片失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var images []*FloatImage
@@ -1611,7 +1598,7 @@ func CreateProtectedRange(ctx context.Context, spreadsheetToken string, ranges [
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("创建保护范围失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("创建保护范围失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	var protectIDs []string
@@ -1650,7 +1637,7 @@ func DeleteProtectedRange(ctx context.Context, spreadsheetToken string, protectI
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("删除保护范围失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("删除保护范围失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1800,7 +1787,7 @@ func WriteCellsV3(ctx context.Context, spreadsheetToken, sheetID string, valueRa
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("V3 写入单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("V3 写入单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1839,7 +1826,7 @@ func InsertCellsV3(ctx context.Context, spreadsheetToken, sheetID, rangeStr stri
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("V3 插入数据失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("V3 插入数据失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1878,7 +1865,7 @@ func AppendCellsV3(ctx context.Context, spreadsheetToken, sheetID, rangeStr stri
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("V3 追加数据失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("V3 追加数据失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
@@ -1920,7 +1907,7 @@ func ReadCellsPlainV3(ctx context.Context, spreadsheetToken, sheetID string, ran
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("V3 获取纯文本失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("V3 获取纯文本失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	var result []*CellRange
@@ -1990,7 +1977,7 @@ func ReadCellsRichV3(ctx context.Context, spreadsheetToken, sheetID string, rang
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("V3 获取富文本失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return nil, newAPIError("V3 获取富文本失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return apiResp.Data.ValueRanges, nil
@@ -2026,7 +2013,7 @@ func ClearCellsV3(ctx context.Context, spreadsheetToken, sheetID string, ranges
 	}
 
 	if apiResp.Code != 0 {
-		return fmt.Errorf("V3 清除单元格失败: code=%d, msg=%s", apiResp.Code, apiResp.Msg)
+		return newAPIError("V3 清除单元格失败", apiResp.Code, apiResp.Msg, nil)
 	}
 
 	return nil
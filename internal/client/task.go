@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -39,7 +40,7 @@ type UpdateTaskOptions struct {
 }
 
 // CreateTask creates a new task
-func CreateTask(opts CreateTaskOptions) (*TaskInfo, error) {
+func CreateTask(ctx context.Context, opts CreateTaskOptions) (*TaskInfo, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -86,7 +87,7 @@ func CreateTask(opts CreateTaskOptions) (*TaskInfo, error) {
 		InputTask(taskBuilder.Build()).
 		Build()
 
-	resp, err := client.Task.V2.Task.Create(Context(), req)
+	resp, err := client.Task.V2.Task.Create(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("创建任务失败: %w", err)
 	}
@@ -99,7 +100,7 @@ func CreateTask(opts CreateTaskOptions) (*TaskInfo, error) {
 }
 
 // GetTask retrieves task details by ID
-func GetTask(taskGuid string) (*TaskInfo, error) {
+func GetTask(ctx context.Context, taskGuid string) (*TaskInfo, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -110,7 +111,7 @@ func GetTask(taskGuid string) (*TaskInfo, error) {
 		UserIdType("open_id").
 		Build()
 
-	resp, err := client.Task.V2.Task.Get(Context(), req)
+	resp, err := client.Task.V2.Task.Get(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取任务失败: %w", err)
 	}
@@ -130,7 +131,7 @@ type ListTasksResult struct {
 }
 
 // ListTasks retrieves a list of tasks
-func ListTasks(pageSize int, pageToken string, completed *bool) (*ListTasksResult, error) {
+func ListTasks(ctx context.Context, pageSize int, pageToken string, completed *bool) (*ListTasksResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -153,7 +154,7 @@ func ListTasks(pageSize int, pageToken string, completed *bool) (*ListTasksResul
 
 	req := reqBuilder.Build()
 
-	resp, err := client.Task.V2.Task.List(Context(), req)
+	resp, err := client.Task.V2.Task.List(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取任务列表失败: %w", err)
 	}
@@ -176,7 +177,7 @@ func ListTasks(pageSize int, pageToken string, completed *bool) (*ListTasksResul
 }
 
 // UpdateTask updates an existing task
-func UpdateTask(taskGuid string, opts UpdateTaskOptions) (*TaskInfo, error) {
+func UpdateTask(ctx context.Context, taskGuid string, opts UpdateTaskOptions) (*TaskInfo, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -229,7 +230,7 @@ func UpdateTask(taskGuid string, opts UpdateTaskOptions) (*TaskInfo, error) {
 		Body(body).
 		Build()
 
-	resp, err := client.Task.V2.Task.Patch(Context(), req)
+	resp, err := client.Task.V2.Task.Patch(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("更新任务失败: %w", err)
 	}
@@ -242,7 +243,7 @@ func UpdateTask(taskGuid string, opts UpdateTaskOptions) (*TaskInfo, error) {
 }
 
 // DeleteTask deletes a task by ID
-func DeleteTask(taskGuid string) error {
+func DeleteTask(ctx context.Context, taskGuid string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -252,7 +253,7 @@ func DeleteTask(taskGuid string) error {
 		TaskGuid(taskGuid).
 		Build()
 
-	resp, err := client.Task.V2.Task.Delete(Context(), req)
+	resp, err := client.Task.V2.Task.Delete(ctx, req)
 	if err != nil {
 		return fmt.Errorf("删除任务失败: %w", err)
 	}
@@ -265,8 +266,8 @@ func DeleteTask(taskGuid string) error {
 }
 
 // CompleteTask marks a task as completed
-func CompleteTask(taskGuid string) (*TaskInfo, error) {
-	return UpdateTask(taskGuid, UpdateTaskOptions{
+func CompleteTask(ctx context.Context, taskGuid string) (*TaskInfo, error) {
+	return UpdateTask(ctx, taskGuid, UpdateTaskOptions{
 		Completed: true,
 	})
 }
@@ -0,0 +1,334 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+)
+
+// 重试退避参数
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+	// 响应头建议的等待时间上限，避免异常值导致长时间阻塞
+	retryMaxHeaderDelay = 60 * time.Second
+	// 检查业务错误码时最多读取的响应体大小
+	maxPeekBodySize = 4 << 20
+)
+
+// codeRateLimited 飞书开放平台通用的频率限制错误码
+const codeRateLimited = 99991400
+
+// defaultFamilyQPS 各类接口的默认 QPS，参考飞书开放平台公布的频率限制，取同类接口中较保守的值
+// 键为 /open-apis/ 之后的第一段路径，写操作单独限流时在键后加 ":write"
+var defaultFamilyQPS = map[string]float64{
+	"docx:write": 3, // 文档块创建、更新、删除：单应用 3 次/秒
+	"docx":       5,
+	"board":      3,
+	"drive":      5,
+	"wiki":       5,
+	"sheets":     5,
+	"bitable":    10,
+	"im":         20,
+	"calendar":   10,
+	"task":       10,
+	"contact":    10,
+	"search":     5,
+}
+
+// defaultQPS 未列出的接口使用的 QPS
+const defaultQPS = 10
+
+// retryTransport 为所有 API 请求提供统一的限流和重试
+// 按接口类别使用令牌桶限流；遇到 429、5xx 或频率限制错误码时按指数退避（带随机抖动）重试，
+// 并优先使用响应头中建议的等待时间。
+// 5xx 和网络错误只对可安全重复的请求重试，见 isIdempotent
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	qps        float64 // 大于 0 时覆盖所有接口类别的默认 QPS
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// newRetryTransport 创建限流重试层，maxRetries 为 0 表示不重试
+func newRetryTransport(base http.RoundTripper, maxRetries int, qps float64) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		base:       base,
+		maxRetries: max(0, maxRetries),
+		qps:        qps,
+		buckets:    map[string]*tokenBucket{},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	family := apiFamily(req.Method, req.URL.Path)
+	bucket := t.bucket(family)
+
+	for attempt := 0; ; attempt++ {
+		if err := bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 {
+			var err error
+			if r, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		retry, delay, reason := t.classify(ctx, resp, err, attempt, isIdempotent(req))
+		if !retry || attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if reason == "rate_limited" {
+			// 同一类别的其他并发请求也一起等待，避免继续触发限流
+			bucket.pause(delay)
+		}
+		debugf("[重试] %s %s: %s, %v 后第 %d/%d 次重试\n", req.Method, req.URL.Path, reason, delay.Round(time.Millisecond), attempt+1, t.maxRetries)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// classify 判断请求是否需要重试，返回等待时间和原因
+//
+// 限流时服务端没有执行请求，总是可以重试；5xx、网络错误和内部错误码时写请求可能已经生效，
+// 只在 idempotent 为 true 时重试
+func (t *retryTransport) classify(ctx context.Context, resp *http.Response, err error, attempt int, idempotent bool) (bool, time.Duration, string) {
+	if err != nil {
+		// 调用方取消或超时不重试，其他网络错误视为临时错误
+		if ctx.Err() != nil || !idempotent {
+			return false, 0, ""
+		}
+		return true, backoffDelay(attempt), "网络错误: " + err.Error()
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true, max(backoffDelay(attempt), headerDelay(resp.Header)), "rate_limited"
+	case resp.StatusCode == http.StatusInternalServerError, resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		if !idempotent {
+			return false, 0, ""
+		}
+		return true, max(backoffDelay(attempt), headerDelay(resp.Header)), fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+
+	code, msg := peekErrorCode(resp)
+	switch {
+	case code == codeRateLimited:
+		return true, max(backoffDelay(attempt), headerDelay(resp.Header)), "rate_limited"
+	case code != 0 && idempotent && strings.Contains(strings.ToLower(msg), "internal error"):
+		return true, backoffDelay(attempt), fmt.Sprintf("code=%d, msg=%s", code, msg)
+	}
+	return false, 0, ""
+}
+
+// isIdempotent 判断请求重复发送是否安全：只读请求，或带 client_token 的写请求（服务端据此去重）。
+// 其他写请求（创建块、发送消息、添加评论等）重复发送会产生重复数据
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return req.URL.Query().Get("client_token") != ""
+}
+
+// bucket 返回接口类别对应的令牌桶
+func (t *retryTransport) bucket(family string) *tokenBucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if b, ok := t.buckets[family]; ok {
+		return b
+	}
+	qps := t.qps
+	if qps <= 0 {
+		qps = familyQPS(family)
+	}
+	b := newTokenBucket(qps)
+	t.buckets[family] = b
+	return b
+}
+
+// apiFamily 根据请求路径返回接口类别，如 /open-apis/docx/v1/... 的写操作返回 "docx:write"
+func apiFamily(method, path string) string {
+	path = strings.TrimPrefix(path, "/open-apis/")
+	family, _, _ := strings.Cut(path, "/")
+	if method != http.MethodGet {
+		if _, ok := defaultFamilyQPS[family+":write"]; ok {
+			return family + ":write"
+		}
+	}
+	return family
+}
+
+// familyQPS 返回接口类别的默认 QPS
+func familyQPS(family string) float64 {
+	if qps, ok := defaultFamilyQPS[family]; ok {
+		return qps
+	}
+	return defaultQPS
+}
+
+// backoffDelay 返回第 attempt 次重试前的等待时间：指数增长，带 50%~100% 的随机抖动
+func backoffDelay(attempt int) time.Duration {
+	d := retryMaxDelay
+	if attempt < 16 {
+		d = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// headerDelay 读取响应头中建议的等待时间（x-ogw-ratelimit-reset 或 Retry-After，单位秒）
+func headerDelay(h http.Header) time.Duration {
+	for _, key := range []string{"x-ogw-ratelimit-reset", "Retry-After"} {
+		if v := h.Get(key); v != "" {
+			if sec, err := strconv.ParseFloat(v, 64); err == nil && sec > 0 {
+				return min(time.Duration(sec*float64(time.Second)), retryMaxHeaderDelay)
+			}
+		}
+	}
+	return 0
+}
+
+// peekErrorCode 读取 JSON 响应中的业务错误码，读取后恢复响应体供调用方使用
+// 未声明长度（chunked）的响应超过 maxPeekBodySize 时，已读取的部分与剩余部分拼接后放回，不会截断
+func peekErrorCode(resp *http.Response) (int, string) {
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") || resp.ContentLength > maxPeekBodySize {
+		return 0, ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPeekBodySize+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil || len(body) > maxPeekBodySize {
+		return 0, ""
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if json.Unmarshal(body, &result) != nil {
+		return 0, ""
+	}
+	return result.Code, result.Msg
+}
+
+// rewindRequest 复制请求并重新生成请求体，用于重试
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.Body == nil || req.GetBody == nil {
+		return r, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = body
+	return r, nil
+}
+
+// sleepContext 等待指定时间，ctx 结束时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// debugf 调试模式下输出到标准错误
+func debugf(format string, args ...any) {
+	if config.Get().Debug {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// tokenBucket 令牌桶限流器，容量等于每秒速率（至少为 1）
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // 每秒生成的令牌数
+	capacity float64
+	tokens   float64
+	last     time.Time
+	until    time.Time // 暂停到该时间（收到限流响应后）
+}
+
+func newTokenBucket(qps float64) *tokenBucket {
+	capacity := max(1, qps)
+	return &tokenBucket{rate: qps, capacity: capacity, tokens: capacity, last: time.Now()}
+}
+
+// wait 取得一个令牌，令牌不足时等待
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		if now.Before(b.until) {
+			d := b.until.Sub(now)
+			b.mu.Unlock()
+			if err := sleepContext(ctx, d); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// pause 在 d 时间内暂停发放令牌
+func (b *tokenBucket) pause(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until := time.Now().Add(d); until.After(b.until) {
+		b.until = until
+	}
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		maxRetries int
+		responses  []func(w http.ResponseWriter)
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "429 后按响应头等待并重试成功",
+			method:     http.MethodPost,
+			path:       "/open-apis/docx/v1/documents",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("x-ogw-ratelimit-reset", "0.1")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "频率限制错误码重试成功",
+			method:     http.MethodPost,
+			path:       "/open-apis/docx/v1/documents",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = io.WriteString(w, `{"code":99991400,"msg":"request trigger frequency limit"}`)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "5xx 重试次数耗尽后返回最后的响应",
+			method:     http.MethodGet,
+			path:       "/open-apis/docx/v1/documents/doc1",
+			maxRetries: 1,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+			},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  2,
+		},
+		{
+			name:       "写请求 5xx 不重试，避免重复写入",
+			method:     http.MethodPost,
+			path:       "/open-apis/im/v1/messages",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+		{
+			name:       "写请求内部错误码不重试",
+			method:     http.MethodPost,
+			path:       "/open-apis/docx/v1/documents/doc1/blocks/doc1/children",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(w, `{"code":1770001,"msg":"internal error"}`)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "带 client_token 的写请求 5xx 重试",
+			method:     http.MethodPost,
+			path:       "/open-apis/docx/v1/documents/doc1/blocks/doc1/children?client_token=t1",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "普通业务错误不重试",
+			method:     http.MethodGet,
+			path:       "/open-apis/docx/v1/documents/doc1",
+			maxRetries: 3,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = io.WriteString(w, `{"code":1770002,"msg":"not found"}`)
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("第 %d 次请求体 = %q, 期望 payload", n, body)
+				}
+				tt.responses[min(int(n), len(tt.responses))-1](w)
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: newRetryTransport(nil, tt.maxRetries, 100)}
+			req, _ := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader("payload"))
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Fatalf("请求返回错误: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("状态码 = %d, 期望 %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("请求次数 = %d, 期望 %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_PreservesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"code":0,"msg":"success","data":{}}`)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newRetryTransport(nil, 3, 100)}
+	resp, err := httpClient.Get(server.URL + "/open-apis/wiki/v2/spaces")
+	if err != nil {
+		t.Fatalf("请求返回错误: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"code":0,"msg":"success","data":{}}` {
+		t.Errorf("检查错误码后响应体被改变: %s", body)
+	}
+}
+
+func TestRetryTransport_PreservesLargeChunkedBody(t *testing.T) {
+	want := `{"code":0,"msg":"success","data":"` + strings.Repeat("a", maxPeekBodySize+1024) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// 未声明 Content-Length，响应以 chunked 编码发送
+		_, _ = io.WriteString(w, want[:1024])
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, want[1024:])
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newRetryTransport(nil, 3, 100)}
+	resp, err := httpClient.Get(server.URL + "/open-apis/wiki/v2/spaces")
+	if err != nil {
+		t.Fatalf("请求返回错误: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.ContentLength != -1 {
+		t.Fatalf("ContentLength = %d, 期望 chunked 响应", resp.ContentLength)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("读取响应体失败: %v", err)
+	}
+	if len(body) != len(want) || string(body) != want {
+		t.Errorf("响应体长度 = %d, 期望 %d", len(body), len(want))
+	}
+}
+
+func TestApiFamily(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/open-apis/docx/v1/documents/abc/blocks", "docx"},
+		{http.MethodPost, "/open-apis/docx/v1/documents/abc/blocks/abc/children", "docx:write"},
+		{http.MethodPatch, "/open-apis/docx/v1/documents/abc/blocks/xyz", "docx:write"},
+		{http.MethodPost, "/open-apis/drive/v1/files/create_folder", "drive"},
+		{http.MethodGet, "/open-apis/wiki/v2/spaces", "wiki"},
+	}
+	for _, tt := range tests {
+		if got := apiFamily(tt.method, tt.path); got != tt.want {
+			t.Errorf("apiFamily(%s, %s) = %q, 期望 %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"无响应头", http.Header{}, 0},
+		{"x-ogw-ratelimit-reset", http.Header{"X-Ogw-Ratelimit-Reset": {"2"}}, 2 * time.Second},
+		{"Retry-After", http.Header{"Retry-After": {"1.5"}}, 1500 * time.Millisecond},
+		{"超过上限", http.Header{"Retry-After": {"3600"}}, retryMaxHeaderDelay},
+		{"无法解析", http.Header{"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"}}, 0},
+	}
+	for _, tt := range tests {
+		if got := headerDelay(tt.header); got != tt.want {
+			t.Errorf("%s: headerDelay = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	for attempt := 0; attempt < 20; attempt++ {
+		d := backoffDelay(attempt)
+		upper := retryMaxDelay
+		if attempt < 16 {
+			upper = min(retryBaseDelay<<attempt, retryMaxDelay)
+		}
+		if d < upper/2 || d > upper {
+			t.Errorf("第 %d 次重试等待 %v, 期望在 [%v, %v] 范围内", attempt, d, upper/2, upper)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10)
+	ctx := context.Background()
+
+	start := time.Now()
+	// 容量为 10，前 10 次立即返回，之后每次约等待 100ms
+	for i := 0; i < 12; i++ {
+		if err := b.wait(ctx); err != nil {
+			t.Fatalf("wait 返回错误: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("超出容量后未限流, 耗时 %v", elapsed)
+	}
+
+	b.pause(time.Hour)
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx); err == nil {
+		t.Error("暂停期间 wait 应在 ctx 结束时返回错误")
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	larkcontact "github.com/larksuite/oapi-sdk-go/v3/service/contact/v3"
@@ -36,7 +37,7 @@ type GetUserInfoOptions struct {
 }
 
 // GetUserInfo retrieves user information by user ID
-func GetUserInfo(userID string, opts GetUserInfoOptions) (*UserInfo, error) {
+func GetUserInfo(ctx context.Context, userID string, opts GetUserInfoOptions) (*UserInfo, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -54,13 +55,13 @@ func GetUserInfo(userID string, opts GetUserInfoOptions) (*UserInfo, error) {
 		reqBuilder.DepartmentIdType(opts.DepartmentIDType)
 	}
 
-	resp, err := client.Contact.User.Get(Context(), reqBuilder.Build())
+	resp, err := client.Contact.User.Get(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, fmt.Errorf("获取用户信息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取用户信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取用户信息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	user := resp.Data.User
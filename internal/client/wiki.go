@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	larkwiki "github.com/larksuite/oapi-sdk-go/v3/service/wiki/v2"
@@ -32,7 +33,7 @@ type WikiSpace struct {
 }
 
 // GetWikiNode 获取知识库节点信息
-func GetWikiNode(token string) (*WikiNode, error) {
+func GetWikiNode(ctx context.Context, token string) (*WikiNode, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -42,13 +43,13 @@ func GetWikiNode(token string) (*WikiNode, error) {
 		Token(token).
 		Build()
 
-	resp, err := client.Wiki.Space.GetNode(Context(), req)
+	resp, err := client.Wiki.Space.GetNode(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("获取节点信息失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("获取节点信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("获取节点信息失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	node := resp.Data.Node
@@ -73,7 +74,7 @@ func GetWikiNode(token string) (*WikiNode, error) {
 }
 
 // ListWikiSpaces 获取知识空间列表
-func ListWikiSpaces(pageSize int, pageToken string) ([]*WikiSpace, string, bool, error) {
+func ListWikiSpaces(ctx context.Context, pageSize int, pageToken string) ([]*WikiSpace, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -87,13 +88,13 @@ func ListWikiSpaces(pageSize int, pageToken string) ([]*WikiSpace, string, bool,
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Wiki.Space.List(Context(), reqBuilder.Build())
+	resp, err := client.Wiki.Space.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取知识空间列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取知识空间列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取知识空间列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var spaces []*WikiSpace
@@ -120,7 +121,7 @@ func ListWikiSpaces(pageSize int, pageToken string) ([]*WikiSpace, string, bool,
 }
 
 // ListWikiNodes 获取知识空间下的节点列表
-func ListWikiNodes(spaceID string, parentNodeToken string, pageSize int, pageToken string) ([]*WikiNode, string, bool, error) {
+func ListWikiNodes(ctx context.Context, spaceID string, parentNodeToken string, pageSize int, pageToken string) ([]*WikiNode, string, bool, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, "", false, err
@@ -139,13 +140,13 @@ func ListWikiNodes(spaceID string, parentNodeToken string, pageSize int, pageTok
 		reqBuilder.PageToken(pageToken)
 	}
 
-	resp, err := client.Wiki.SpaceNode.List(Context(), reqBuilder.Build())
+	resp, err := client.Wiki.SpaceNode.List(ctx, reqBuilder.Build())
 	if err != nil {
 		return nil, "", false, fmt.Errorf("获取节点列表失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("获取节点列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, "", false, newAPIError("获取节点列表失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	var nodes []*WikiNode
@@ -185,7 +186,7 @@ type CreateWikiNodeResult struct {
 }
 
 // CreateWikiNode 在知识空间中创建节点
-func CreateWikiNode(spaceID, title, parentNode, nodeType string) (*CreateWikiNodeResult, error) {
+func CreateWikiNode(ctx context.Context, spaceID, title, parentNode, nodeType string) (*CreateWikiNodeResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -208,13 +209,13 @@ func CreateWikiNode(spaceID, title, parentNode, nodeType string) (*CreateWikiNod
 		Node(nodeBuilder.Build()).
 		Build()
 
-	resp, err := client.Wiki.SpaceNode.Create(Context(), req)
+	resp, err := client.Wiki.SpaceNode.Create(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("创建知识库节点失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("创建知识库节点失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("创建知识库节点失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	if resp.Data == nil || resp.Data.Node == nil {
@@ -231,7 +232,7 @@ func CreateWikiNode(spaceID, title, parentNode, nodeType string) (*CreateWikiNod
 }
 
 // UpdateWikiNode 更新知识库节点标题
-func UpdateWikiNode(spaceID, nodeToken, title string) error {
+func UpdateWikiNode(ctx context.Context, spaceID, nodeToken, title string) error {
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -247,13 +248,13 @@ func UpdateWikiNode(spaceID, nodeToken, title string) error {
 		Body(body).
 		Build()
 
-	resp, err := client.Wiki.SpaceNode.UpdateTitle(Context(), req)
+	resp, err := client.Wiki.SpaceNode.UpdateTitle(ctx, req)
 	if err != nil {
 		return fmt.Errorf("更新知识库节点标题失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return fmt.Errorf("更新知识库节点标题失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return newAPIError("更新知识库节点标题失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	return nil
@@ -265,7 +266,7 @@ type MoveWikiNodeResult struct {
 }
 
 // MoveWikiNode 移动知识库节点
-func MoveWikiNode(spaceID, nodeToken, targetSpaceID, targetParent string) (*MoveWikiNodeResult, error) {
+func MoveWikiNode(ctx context.Context, spaceID, nodeToken, targetSpaceID, targetParent string) (*MoveWikiNodeResult, error) {
 	client, err := GetClient()
 	if err != nil {
 		return nil, err
@@ -284,13 +285,13 @@ func MoveWikiNode(spaceID, nodeToken, targetSpaceID, targetParent string) (*Move
 		Body(bodyBuilder.Build()).
 		Build()
 
-	resp, err := client.Wiki.SpaceNode.Move(Context(), req)
+	resp, err := client.Wiki.SpaceNode.Move(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("移动知识库节点失败: %w", err)
 	}
 
 	if !resp.Success() {
-		return nil, fmt.Errorf("移动知识库节点失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, newAPIError("移动知识库节点失败", resp.Code, resp.Msg, resp.ApiResp)
 	}
 
 	result := &MoveWikiNodeResult{}
@@ -1,20 +1,31 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/riba2534/feishu-cli/internal/secret"
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
+	Profile         string       `mapstructure:"-"` // 当前使用的配置档案名，为空表示顶层默认配置
 	AppID           string       `mapstructure:"app_id"`
-	AppSecret       string       `mapstructure:"app_secret"`
+	AppSecret       string       `mapstructure:"app_secret"`     // 以 keyring: 开头时从安全存储读取
+	AppSecretCmd    string       `mapstructure:"app_secret_cmd"` // 未设置 app_secret 时执行该命令获取，如 pass show feishu
+	SecretStore     string       `mapstructure:"secret_store"`   // 密钥和用户 Token 的存储后端: auto, keyring, file, plaintext
 	UserAccessToken string       `mapstructure:"user_access_token"`
 	BaseURL         string       `mapstructure:"base_url"`
 	Debug           bool         `mapstructure:"debug"`
+	MaxRetries      int          `mapstructure:"max_retries"` // API 请求失败（限流、5xx）时的最大重试次数
+	QPS             float64      `mapstructure:"qps"`         // 全局 QPS 上限，0 表示按接口类别使用默认值
+	DryRun          bool         `mapstructure:"-"`           // 只记录写请求而不发送，只能通过 --dry-run 开启
 	Export          ExportConfig `mapstructure:"export"`
 	Import          ImportConfig `mapstructure:"import"`
 }
@@ -30,11 +41,21 @@ type ImportConfig struct {
 	UploadImages bool `mapstructure:"upload_images"`
 }
 
+// DefaultMaxRetries API 请求的默认最大重试次数
+const DefaultMaxRetries = 5
+
 var cfg *Config
 
 // Init initializes the configuration from file and environment
 // 配置优先级: 环境变量 > 配置文件 > 默认值
 func Init(cfgFile string) error {
+	return InitWithProfile(cfgFile, "")
+}
+
+// InitWithProfile 初始化配置并叠加指定的配置档案
+// profile 为空时依次使用环境变量 FEISHU_PROFILE、配置文件中的 current_profile，都未设置时只使用顶层配置
+// 配置优先级: 环境变量 > 配置档案 > 顶层配置 > 默认值
+func InitWithProfile(cfgFile, profile string) error {
 	// 1. 设置配置文件路径
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
@@ -54,6 +75,9 @@ func Init(cfgFile string) error {
 	// 2. 设置默认值
 	viper.SetDefault("base_url", "https://open.feishu.cn")
 	viper.SetDefault("debug", false)
+	viper.SetDefault("max_retries", DefaultMaxRetries)
+	viper.SetDefault("qps", 0)
+	viper.SetDefault("secret_store", secret.BackendAuto)
 	viper.SetDefault("export.download_images", false)
 	viper.SetDefault("export.assets_dir", "./assets")
 	viper.SetDefault("import.upload_images", true)
@@ -68,6 +92,9 @@ func Init(cfgFile string) error {
 	_ = viper.BindEnv("user_access_token", "FEISHU_USER_ACCESS_TOKEN")
 	_ = viper.BindEnv("base_url", "FEISHU_BASE_URL")
 	_ = viper.BindEnv("debug", "FEISHU_DEBUG")
+	_ = viper.BindEnv("max_retries", "FEISHU_MAX_RETRIES")
+	_ = viper.BindEnv("qps", "FEISHU_QPS")
+	_ = viper.BindEnv("secret_store", "FEISHU_SECRET_STORE")
 
 	// 4. 读取配置文件
 	if err := viper.ReadInConfig(); err != nil {
@@ -76,19 +103,113 @@ func Init(cfgFile string) error {
 		}
 	}
 
+	// 5. 叠加配置档案，档案中的配置覆盖顶层配置，环境变量仍然优先
+	// 凭证不沿用顶层配置，避免档案的 app_id 搭配顶层应用的密钥或用户 Token
+	if profile == "" {
+		profile = os.Getenv("FEISHU_PROFILE")
+	}
+	if profile == "" {
+		profile = viper.GetString("current_profile")
+	}
+	if profile == DefaultProfile {
+		profile = ""
+	}
+	if profile != "" {
+		if !viper.IsSet("profiles." + profile) {
+			return fmt.Errorf("配置档案不存在: %s（可通过 feishu-cli config profile list 查看）", profile)
+		}
+		profileConfig := viper.GetStringMap("profiles." + profile)
+		for _, key := range profileCredentialKeys {
+			if _, ok := profileConfig[key]; !ok {
+				profileConfig[key] = ""
+			}
+		}
+		if err := viper.MergeConfigMap(profileConfig); err != nil {
+			return fmt.Errorf("加载配置档案 %s 失败: %w", profile, err)
+		}
+	}
+
 	cfg = &Config{}
 	if err := viper.Unmarshal(cfg); err != nil {
 		return fmt.Errorf("解析配置失败: %w", err)
 	}
+	cfg.Profile = profile
+
+	// 6. 解析间接配置的 app_secret
+	if err := resolveAppSecret(cfg); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// profileCredentialKeys 使用配置档案时只从档案（或环境变量）读取的凭证配置
+var profileCredentialKeys = []string{"app_secret", "app_secret_cmd", "user_access_token"}
+
+// SecretRefPrefix app_secret 以该前缀开头时从安全存储读取，前缀后为密钥名（为空时按档案生成）
+const SecretRefPrefix = "keyring:"
+
+// resolveAppSecret 处理 app_secret: keyring: 和 app_secret_cmd 两种间接配置
+func resolveAppSecret(c *Config) error {
+	switch {
+	case strings.HasPrefix(c.AppSecret, SecretRefPrefix):
+		key := strings.TrimPrefix(c.AppSecret, SecretRefPrefix)
+		if key == "" {
+			key = secret.Key(c.Profile, "app_secret")
+		}
+		store, err := secret.Open(c.SecretStore)
+		if errors.Is(err, secret.ErrNoStore) {
+			return fmt.Errorf("app_secret 配置为 %s，但没有可用的安全存储（secret_store=%s）", c.AppSecret, c.SecretStore)
+		}
+		if err != nil {
+			return err
+		}
+		value, err := store.Get(key)
+		if errors.Is(err, secret.ErrNotFound) {
+			return fmt.Errorf("安全存储中没有 %s，请运行 feishu-cli config secret set-app-secret 写入", key)
+		}
+		if err != nil {
+			return fmt.Errorf("读取 app_secret 失败: %w", err)
+		}
+		c.AppSecret = value
+	case c.AppSecret == "" && c.AppSecretCmd != "":
+		value, err := runSecretCmd(c.AppSecretCmd)
+		if err != nil {
+			return err
+		}
+		c.AppSecret = value
+	}
+	return nil
+}
+
+// runSecretCmd 通过 shell 执行命令，取标准输出的第一行作为密钥
+func runSecretCmd(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("执行 app_secret_cmd 失败: %w", err)
+	}
+	value, _, _ := strings.Cut(string(out), "\n")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("app_secret_cmd 没有输出")
+	}
+	return value, nil
+}
+
 // Get returns the current configuration
 func Get() *Config {
 	if cfg == nil {
 		return &Config{
-			BaseURL: "https://open.feishu.cn",
+			BaseURL:     "https://open.feishu.cn",
+			MaxRetries:  DefaultMaxRetries,
+			SecretStore: secret.BackendAuto,
 			Export: ExportConfig{
 				AssetsDir: "./assets",
 			},
@@ -146,6 +267,19 @@ app_secret: ""
 base_url: "https://open.feishu.cn"
 debug: false
 
+# 密钥存储: 应用密钥和用户 Token 的保存位置（也可通过环境变量 FEISHU_SECRET_STORE 指定）
+#   auto      有系统密钥环时使用密钥环，否则设置了 FEISHU_SECRET_PASSPHRASE 时使用加密文件，都没有时使用明文文件
+#   keyring   系统密钥环（Linux Secret Service / macOS 钥匙串）
+#   file      口令加密文件 ~/.feishu-cli/secrets.enc，口令来自 FEISHU_SECRET_PASSPHRASE 或 FEISHU_SECRET_PASSPHRASE_FILE
+#   plaintext 明文文件（旧版本行为）
+# app_secret 可写为 "keyring:" 表示从上述存储读取（通过 feishu-cli config secret set-app-secret 写入），
+# 或删除 app_secret 并设置 app_secret_cmd，如 app_secret_cmd: "pass show feishu"
+secret_store: auto
+
+# 限流与重试（所有命令生效，也可通过 --max-retries / --qps 临时指定）
+max_retries: 5             # 遇到限流或服务端错误时的最大重试次数，0 表示不重试
+qps: 0                     # 全局 QPS 上限，0 表示按接口类别使用默认限额
+
 # 导出配置
 export:
   download_images: true    # 导出时下载图片到本地
@@ -154,6 +288,18 @@ export:
 # 导入配置
 import:
   upload_images: true      # 导入时上传本地图片
+
+# 配置档案（可选），用于在多个应用或租户之间切换
+# 档案中的配置覆盖上面的顶层配置，每个档案使用独立的用户 Token 文件
+# app_secret、app_secret_cmd 和 user_access_token 不沿用顶层配置，需要在档案中单独设置
+# 切换方式: --profile <name>、环境变量 FEISHU_PROFILE 或 feishu-cli config profile use <name>
+#
+# current_profile: lark
+# profiles:
+#   lark:
+#     app_id: "cli_xxx"
+#     app_secret: "xxx"
+#     base_url: "https://open.larksuite.com"   # Lark 国际版
 `
 
 	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
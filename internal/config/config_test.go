@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/riba2534/feishu-cli/internal/secret"
 	"github.com/spf13/viper"
 )
 
@@ -382,3 +384,181 @@ func TestInit_DebugFromEnv(t *testing.T) {
 		t.Errorf("Debug = %v, 期望 %v", c.Debug, true)
 	}
 }
+
+func TestInitWithProfile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `app_id: "top_app_id"
+app_secret: "top_app_secret"
+user_access_token: "top_user_token"
+current_profile: lark
+export:
+  assets_dir: "./top_assets"
+profiles:
+  lark:
+    app_id: "lark_app_id"
+    base_url: "https://open.larksuite.com"
+    export:
+      download_images: true
+  team:
+    app_id: "team_app_id"
+    app_secret_cmd: "echo team_secret"
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("创建配置文件失败: %v", err)
+	}
+	os.Unsetenv("FEISHU_APP_ID")
+	os.Unsetenv("FEISHU_APP_SECRET")
+	os.Unsetenv("FEISHU_BASE_URL")
+	os.Unsetenv("FEISHU_USER_ACCESS_TOKEN")
+
+	tests := []struct {
+		name        string
+		profile     string
+		env         string
+		wantProfile string
+		wantAppID   string
+		wantBaseURL string
+		wantSecret  string
+	}{
+		{"使用 current_profile", "", "", "lark", "lark_app_id", "https://open.larksuite.com", ""},
+		{"环境变量覆盖 current_profile", "", "team", "team", "team_app_id", "https://open.feishu.cn", "team_secret"},
+		{"参数覆盖环境变量", "lark", "team", "lark", "lark_app_id", "https://open.larksuite.com", ""},
+		{"default 表示顶层配置", "default", "", "", "top_app_id", "https://open.feishu.cn", "top_app_secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetConfig()
+			t.Setenv("FEISHU_PROFILE", tt.env)
+
+			if err := InitWithProfile(configFile, tt.profile); err != nil {
+				t.Fatalf("InitWithProfile() 返回错误: %v", err)
+			}
+			c := Get()
+			if c.Profile != tt.wantProfile || c.AppID != tt.wantAppID || c.BaseURL != tt.wantBaseURL {
+				t.Errorf("配置 = (%q, %q, %q), 期望 (%q, %q, %q)", c.Profile, c.AppID, c.BaseURL, tt.wantProfile, tt.wantAppID, tt.wantBaseURL)
+			}
+			// 档案未设置的项沿用顶层配置
+			if c.Export.AssetsDir != "./top_assets" {
+				t.Errorf("未沿用顶层配置: assets_dir=%q", c.Export.AssetsDir)
+			}
+			// 凭证不沿用顶层配置，档案的 app_secret_cmd 不会被顶层 app_secret 覆盖
+			if c.AppSecret != tt.wantSecret {
+				t.Errorf("AppSecret = %q, 期望 %q", c.AppSecret, tt.wantSecret)
+			}
+			if wantToken := map[string]string{"": "top_user_token"}[tt.wantProfile]; c.UserAccessToken != wantToken {
+				t.Errorf("UserAccessToken = %q, 期望 %q", c.UserAccessToken, wantToken)
+			}
+		})
+	}
+
+	resetConfig()
+	if err := InitWithProfile(configFile, "missing"); err == nil {
+		t.Error("档案不存在时应返回错误")
+	}
+}
+
+func TestSaveAndUseProfile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `# 飞书 CLI 配置文件
+app_id: "top_app_id"
+debug: false # 调试模式
+`
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatalf("创建配置文件失败: %v", err)
+	}
+
+	created, err := SaveProfile(configFile, &Profile{Name: "lark", AppID: "a1", AppSecret: "s1", BaseURL: LarkBaseURL})
+	if err != nil || !created {
+		t.Fatalf("SaveProfile() = %v, %v, 期望新建成功", created, err)
+	}
+	// 更新时只覆盖指定的字段
+	if created, err := SaveProfile(configFile, &Profile{Name: "lark", AppID: "a2"}); err != nil || created {
+		t.Fatalf("SaveProfile() = %v, %v, 期望更新成功", created, err)
+	}
+	if err := UseProfile(configFile, "lark"); err != nil {
+		t.Fatalf("UseProfile() 返回错误: %v", err)
+	}
+	if err := UseProfile(configFile, "missing"); err == nil {
+		t.Error("切换到不存在的档案应返回错误")
+	}
+	if _, err := SaveProfile(configFile, &Profile{Name: DefaultProfile}); err == nil {
+		t.Error("default 为保留名称，应返回错误")
+	}
+
+	data, _ := os.ReadFile(configFile)
+	for _, want := range []string{"# 飞书 CLI 配置文件", "# 调试模式", "current_profile: lark"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("配置文件缺少 %q:\n%s", want, data)
+		}
+	}
+
+	profiles, err := ListProfiles(configFile)
+	if err != nil {
+		t.Fatalf("ListProfiles() 返回错误: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != DefaultProfile || profiles[0].Current {
+		t.Fatalf("档案列表错误: %+v", profiles)
+	}
+	if p := profiles[1]; p.Name != "lark" || p.AppID != "a2" || p.BaseURL != LarkBaseURL || !p.Current {
+		t.Errorf("lark 档案 = %+v", p)
+	}
+
+	if err := UseProfile(configFile, DefaultProfile); err != nil {
+		t.Fatalf("UseProfile(default) 返回错误: %v", err)
+	}
+	if data, _ := os.ReadFile(configFile); strings.Contains(string(data), "current_profile") {
+		t.Errorf("切回 default 后应删除 current_profile:\n%s", data)
+	}
+}
+
+func TestInit_AppSecretIndirection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("FEISHU_APP_SECRET", "")
+	t.Setenv("FEISHU_SECRET_STORE", "file")
+	t.Setenv("FEISHU_SECRET_PASSPHRASE", "test-passphrase")
+
+	store, err := secret.Open(secret.BackendFile)
+	if err != nil {
+		t.Fatalf("打开加密文件存储失败: %v", err)
+	}
+	if err := store.Set("default/app_secret", "from_store"); err != nil {
+		t.Fatalf("写入密钥失败: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"keyring: 引用当前档案", "app_secret: \"keyring:\"\n", "from_store", false},
+		{"keyring: 指定密钥名", "app_secret: \"keyring:default/app_secret\"\n", "from_store", false},
+		{"密钥不存在", "app_secret: \"keyring:missing\"\n", "", true},
+		{"app_secret_cmd", "app_secret_cmd: \"echo from_cmd\"\n", "from_cmd", false},
+		{"app_secret 优先于 app_secret_cmd", "app_secret: plain\napp_secret_cmd: \"echo from_cmd\"\n", "plain", false},
+		{"命令执行失败", "app_secret_cmd: \"exit 1\"\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetConfig()
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("创建配置文件失败: %v", err)
+			}
+
+			err := Init(configFile)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("期望返回错误, 实际 app_secret = %q", Get().AppSecret)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Init() 返回错误: %v", err)
+			}
+			if got := Get().AppSecret; got != tt.want {
+				t.Errorf("AppSecret = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+}
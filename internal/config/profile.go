@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile 顶层配置对应的档案名
+const DefaultProfile = "default"
+
+// LarkBaseURL Lark 国际版开放平台地址
+const LarkBaseURL = "https://open.larksuite.com"
+
+// profileNamePattern 档案名只允许小写字母、数字、下划线和连字符（同时用作 Token 文件名）
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// Profile 配置档案中的应用凭证
+type Profile struct {
+	Name      string `json:"name"`
+	AppID     string `json:"app_id,omitempty"`
+	AppSecret string `json:"-"`
+	BaseURL   string `json:"base_url,omitempty"`
+	Current   bool   `json:"current"`
+}
+
+// ValidateProfileName 校验档案名
+func ValidateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("档案名 %s 为保留名称，表示顶层配置", DefaultProfile)
+	}
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("档案名无效: %q，只允许小写字母、数字、下划线和连字符", name)
+	}
+	return nil
+}
+
+// FilePath 返回配置文件路径，cfgFile 为空时使用 ~/.feishu-cli/config.yaml
+func FilePath(cfgFile string) (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户目录失败: %w", err)
+	}
+	return filepath.Join(home, ".feishu-cli", "config.yaml"), nil
+}
+
+// ListProfiles 读取配置文件中的所有档案，第一项为顶层默认配置
+func ListProfiles(path string) ([]*Profile, error) {
+	doc, err := loadYAML(path)
+	if err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+
+	current := scalarValue(root, "current_profile")
+	if current == "" {
+		current = DefaultProfile
+	}
+
+	profiles := []*Profile{{
+		Name:    DefaultProfile,
+		AppID:   scalarValue(root, "app_id"),
+		BaseURL: scalarValue(root, "base_url"),
+		Current: current == DefaultProfile,
+	}}
+
+	var named []*Profile
+	if node := mappingValue(root, "profiles"); node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i].Value, node.Content[i+1]
+			named = append(named, &Profile{
+				Name:    name,
+				AppID:   scalarValue(value, "app_id"),
+				BaseURL: scalarValue(value, "base_url"),
+				Current: current == name,
+			})
+		}
+	}
+	sort.Slice(named, func(i, j int) bool { return named[i].Name < named[j].Name })
+	return append(profiles, named...), nil
+}
+
+// SaveProfile 新增或更新档案，只写入非空字段，保留配置文件中的其他内容和注释
+// 返回值表示档案是否为新建
+func SaveProfile(path string, p *Profile) (bool, error) {
+	if err := ValidateProfileName(p.Name); err != nil {
+		return false, err
+	}
+	doc, err := loadYAML(path)
+	if err != nil {
+		return false, err
+	}
+	root := doc.Content[0]
+
+	profiles := mappingValue(root, "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		profiles = &yaml.Node{Kind: yaml.MappingNode}
+		setMappingValue(root, "profiles", profiles)
+	}
+	node := mappingValue(profiles, p.Name)
+	created := node == nil
+	if created || node.Kind != yaml.MappingNode {
+		node = &yaml.Node{Kind: yaml.MappingNode}
+		setMappingValue(profiles, p.Name, node)
+	}
+
+	for _, field := range []struct{ key, value string }{
+		{"app_id", p.AppID},
+		{"app_secret", p.AppSecret},
+		{"base_url", p.BaseURL},
+	} {
+		if field.value != "" {
+			setMappingValue(node, field.key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: field.value})
+		}
+	}
+
+	return created, saveYAML(path, doc)
+}
+
+// SetValue 设置档案中的配置项，profile 为空或 default 时设置顶层配置
+func SetValue(path, profile, key, value string) error {
+	doc, err := loadYAML(path)
+	if err != nil {
+		return err
+	}
+	node := doc.Content[0]
+	if profile != "" && profile != DefaultProfile {
+		if node = mappingValue(mappingValue(node, "profiles"), profile); node == nil || node.Kind != yaml.MappingNode {
+			return fmt.Errorf("配置档案不存在: %s", profile)
+		}
+	}
+	setMappingValue(node, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	return saveYAML(path, doc)
+}
+
+// UseProfile 设置默认使用的档案，name 为 default 时切回顶层配置
+func UseProfile(path, name string) error {
+	doc, err := loadYAML(path)
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+
+	if name == DefaultProfile {
+		deleteMappingKey(root, "current_profile")
+		return saveYAML(path, doc)
+	}
+	if profiles := mappingValue(root, "profiles"); profiles == nil || mappingValue(profiles, name) == nil {
+		return fmt.Errorf("配置档案不存在: %s", name)
+	}
+	setMappingValue(root, "current_profile", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+	return saveYAML(path, doc)
+}
+
+// loadYAML 读取配置文件为 YAML 节点树，文件不存在或为空时返回空文档
+func loadYAML(path string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("解析配置文件失败: %w", err)
+		}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("配置文件格式错误: %s 顶层不是映射", path)
+	}
+	return doc, nil
+}
+
+// saveYAML 写回配置文件，使用 0600 权限保护凭证
+func saveYAML(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("创建配置目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("写入配置文件失败: %w", err)
+	}
+	return nil
+}
+
+// mappingValue 返回映射节点中 key 对应的值节点
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue 返回映射节点中 key 对应的标量值
+func scalarValue(node *yaml.Node, key string) string {
+	if v := mappingValue(node, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// setMappingValue 设置映射节点中 key 的值，已存在时原地替换以保留注释
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value.LineComment = node.Content[i+1].LineComment
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// deleteMappingKey 删除映射节点中的 key
+func deleteMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -103,10 +104,58 @@ func isListBlockType(bt BlockType) bool {
 
 // Convert converts all blocks to Markdown
 func (c *BlockToMarkdown) Convert() (string, error) {
+	sections, err := c.ConvertTopLevel()
+	if err != nil {
+		return "", err
+	}
+	return joinSections(sections), nil
+}
+
+// joinSections 拼接顶层块的 Markdown，处理列表切换时的空行并规范化连续空行
+func joinSections(sections []BlockMarkdown) string {
 	var sb strings.Builder
 
 	var prevBlockType BlockType
 
+	for _, section := range sections {
+		currentBlockType := section.BlockType
+
+		// 列表类型切换时插入额外空行
+		if prevBlockType != 0 {
+			prevIsList := isListBlockType(prevBlockType)
+			currIsList := isListBlockType(currentBlockType)
+			if (prevIsList && !currIsList) || (!prevIsList && currIsList) {
+				sb.WriteString("\n")
+			} else if prevIsList && currIsList && prevBlockType != currentBlockType {
+				// Bullet → Ordered 或 Ordered → Bullet 切换
+				sb.WriteString("\n")
+			}
+		}
+
+		sb.WriteString(section.Markdown)
+		sb.WriteString("\n")
+		prevBlockType = currentBlockType
+	}
+
+	output := strings.TrimRight(sb.String(), "\n") + "\n"
+
+	// 规范化连续空行（最多保留一个空行，即两个换行符）
+	reBlankLines := regexp.MustCompile(`\n{3,}`)
+	return reBlankLines.ReplaceAllString(output, "\n\n")
+}
+
+// BlockMarkdown 表示一个顶层块及其转换得到的 Markdown
+type BlockMarkdown struct {
+	BlockID   string
+	BlockType BlockType
+	Markdown  string
+}
+
+// ConvertTopLevel 按顺序转换所有顶层块，返回每个块各自的 Markdown（不含空结果）
+// 嵌套在容器块、列表和表格中的子块包含在其父块的结果中
+func (c *BlockToMarkdown) ConvertTopLevel() ([]BlockMarkdown, error) {
+	var sections []BlockMarkdown
+
 	// Process blocks in order
 	for _, block := range c.blocks {
 		if block.BlockType == nil {
@@ -123,38 +172,20 @@ func (c *BlockToMarkdown) Convert() (string, error) {
 			continue
 		}
 
-		currentBlockType := BlockType(*block.BlockType)
-
-		// 列表类型切换时插入额外空行
-		if prevBlockType != 0 {
-			prevIsList := isListBlockType(prevBlockType)
-			currIsList := isListBlockType(currentBlockType)
-			if (prevIsList && !currIsList) || (!prevIsList && currIsList) {
-				sb.WriteString("\n")
-			} else if prevIsList && currIsList && prevBlockType != currentBlockType {
-				// Bullet → Ordered 或 Ordered → Bullet 切换
-				sb.WriteString("\n")
-			}
-		}
-
 		md, err := c.convertBlock(block, 0)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if md != "" {
-			sb.WriteString(md)
-			sb.WriteString("\n")
-			prevBlockType = currentBlockType
+			sections = append(sections, BlockMarkdown{
+				BlockID:   client.StringVal(block.BlockId),
+				BlockType: BlockType(*block.BlockType),
+				Markdown:  md,
+			})
 		}
 	}
 
-	output := strings.TrimRight(sb.String(), "\n") + "\n"
-
-	// 规范化连续空行（最多保留一个空行，即两个换行符）
-	reBlankLines := regexp.MustCompile(`\n{3,}`)
-	output = reBlankLines.ReplaceAllString(output, "\n\n")
-
-	return output, nil
+	return sections, nil
 }
 
 func (c *BlockToMarkdown) convertBlock(block *larkdocx.Block, indent int) (string, error) {
@@ -472,19 +503,9 @@ func (c *BlockToMarkdown) convertImage(block *larkdocx.Block) (string, error) {
 		token = *block.Image.Token
 	}
 
-	// 提取 alt 文本（从子块中获取）
-	alt := "image"
-	if block.Children != nil {
-		for _, childID := range block.Children {
-			childBlock := c.blockMap[childID]
-			if childBlock != nil && childBlock.Text != nil {
-				extracted := c.convertTextElementsRaw(childBlock.Text.Elements)
-				if extracted != "" {
-					alt = extracted
-				}
-				break
-			}
-		}
+	alt := c.imageAlt(block)
+	if alt == "" {
+		alt = "image"
 	}
 
 	if token == "" {
@@ -503,15 +524,16 @@ func (c *BlockToMarkdown) convertImage(block *larkdocx.Block) (string, error) {
 		localPath := filepath.Join(c.options.AssetsDir, filename)
 
 		// 方式一：获取临时 URL 后下载
-		tmpURL, urlErr := client.GetMediaTempURL(token)
+		ctx := c.context()
+		tmpURL, urlErr := client.GetMediaTempURL(ctx, token)
 		if urlErr == nil {
-			if dlErr := client.DownloadFromURL(tmpURL, localPath); dlErr == nil {
+			if dlErr := client.DownloadFromURL(ctx, tmpURL, localPath); dlErr == nil {
 				return fmt.Sprintf("![%s](%s)\n", alt, localPath), nil
 			}
 		}
 
 		// 方式二：SDK 直接下载
-		if sdkErr := client.DownloadMedia(token, localPath); sdkErr == nil {
+		if sdkErr := client.DownloadMedia(ctx, token, localPath); sdkErr == nil {
 			return fmt.Sprintf("![%s](%s)\n", alt, localPath), nil
 		}
 
@@ -523,6 +545,25 @@ func (c *BlockToMarkdown) convertImage(block *larkdocx.Block) (string, error) {
 	return fmt.Sprintf("![%s](feishu://media/%s)\n", alt, token), nil
 }
 
+// context 返回 API 调用使用的上下文
+func (c *BlockToMarkdown) context() context.Context {
+	if c.options.Context != nil {
+		return c.options.Context
+	}
+	return context.Background()
+}
+
+// imageAlt 提取图片的 alt 文本（从第一个文本子块中获取），没有时返回空字符串
+func (c *BlockToMarkdown) imageAlt(block *larkdocx.Block) string {
+	for _, childID := range block.Children {
+		childBlock := c.blockMap[childID]
+		if childBlock != nil && childBlock.Text != nil {
+			return c.convertTextElementsRaw(childBlock.Text.Elements)
+		}
+	}
+	return ""
+}
+
 func (c *BlockToMarkdown) convertTable(block *larkdocx.Block) (string, error) {
 	if block.Table == nil || block.Table.Cells == nil {
 		return "", nil
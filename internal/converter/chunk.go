@@ -0,0 +1,317 @@
+package converter
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+// Chunk 表示按标题层级切分出的一段文档内容
+type Chunk struct {
+	Index       int      `json:"index"`
+	Text        string   `json:"text"`         // Markdown 文本
+	HeadingPath []string `json:"heading_path"` // 所属章节的标题路径（面包屑）
+	BlockIDs    []string `json:"block_ids"`    // 来源顶层块 ID
+	Tokens      int      `json:"tokens"`       // 估算的 token 数
+}
+
+// BuildChunks 沿标题层级将文档切分为语义连贯的片段，每个片段的估算 token 数不超过 maxTokens
+// 遇到标题时开始新片段；表格、代码块等结构化内容不会被拆开，单个超长时独立成片段；
+// 超长的高亮块、引用容器和列表在子块边界处拆分，超长的段落按行拆分
+func BuildChunks(blocks []*larkdocx.Block, options ConvertOptions, maxTokens int) ([]Chunk, error) {
+	// 标题编号状态保存在转换器中，大纲和正文分别使用独立的转换器
+	titles := map[string]OutlineEntry{}
+	for _, e := range NewBlockToMarkdown(blocks, options).Outline() {
+		titles[e.BlockID] = e
+	}
+
+	conv := NewBlockToMarkdown(blocks, options)
+	sections, err := conv.ConvertTopLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &chunkBuilder{maxTokens: maxTokens}
+	for _, s := range sections {
+		if entry, ok := titles[s.BlockID]; ok && s.BlockType >= BlockTypeHeading1 && s.BlockType <= BlockTypeHeading9 {
+			b.heading(entry, s)
+			continue
+		}
+
+		text := strings.TrimRight(s.Markdown, "\n")
+		if EstimateTokens(text) <= maxTokens || isAtomicBlockType(s.BlockType) {
+			b.add(s.BlockID, text, false)
+			continue
+		}
+		for _, piece := range conv.splitContainer(s, maxTokens) {
+			b.add(s.BlockID, piece, false)
+		}
+	}
+	b.flush()
+
+	return b.chunks, nil
+}
+
+// isAtomicBlockType 判断块是否不可拆分（表格、代码、公式、图表等结构化内容）
+func isAtomicBlockType(bt BlockType) bool {
+	switch bt {
+	case BlockTypeTable, BlockTypeCode, BlockTypeEquation, BlockTypeDiagram,
+		BlockTypeBoard, BlockTypeSheet, BlockTypeBitable, BlockTypeGrid:
+		return true
+	}
+	return false
+}
+
+// splitContainer 在子块边界处拆分超长的容器块（高亮块、引用容器、列表）
+// 代码、表格等结构化子块保持完整；高亮块的每个片段都重复类型行，使其仍是完整的高亮块。
+// 不是容器的块和超长的普通子块按行拆分
+func (c *BlockToMarkdown) splitContainer(s BlockMarkdown, maxTokens int) []string {
+	text := strings.TrimRight(s.Markdown, "\n")
+	block := c.blockMap[s.BlockID]
+	if block == nil || len(block.Children) == 0 {
+		return splitByLines(text, maxTokens)
+	}
+
+	// 与 convertCallout、convertQuoteContainer、convertBullet 的输出格式保持一致
+	var header, linePrefix string
+	indent := 0
+	switch s.BlockType {
+	case BlockTypeCallout:
+		header, _, _ = strings.Cut(text, "\n")
+		linePrefix = "> "
+	case BlockTypeQuoteContainer:
+		linePrefix = "> "
+	case BlockTypeBullet, BlockTypeOrdered:
+		indent = 1
+	default:
+		return splitByLines(text, maxTokens)
+	}
+
+	type unit struct {
+		text   string
+		atomic bool
+	}
+	var units []unit
+	var children strings.Builder
+	for _, childID := range block.Children {
+		child := c.blockMap[childID]
+		if child == nil || child.BlockType == nil {
+			continue
+		}
+		md, _ := c.convertBlockWithDepth(child, indent, 1)
+		children.WriteString(md)
+		md = strings.TrimRight(md, "\n")
+		if md == "" {
+			continue
+		}
+		if linePrefix != "" {
+			md = linePrefix + strings.ReplaceAll(md, "\n", "\n"+linePrefix)
+		}
+		units = append(units, unit{text: md, atomic: isAtomicBlockType(BlockType(*child.BlockType))})
+	}
+	if s.BlockType == BlockTypeBullet || s.BlockType == BlockTypeOrdered {
+		// 列表项自身的文本在子块之前
+		item, ok := strings.CutSuffix(s.Markdown, children.String())
+		if !ok {
+			return splitByLines(text, maxTokens)
+		}
+		units = append([]unit{{text: strings.TrimRight(item, "\n")}}, units...)
+	}
+
+	budget := maxTokens
+	if header != "" {
+		budget -= EstimateTokens(header)
+	}
+	var pieces, current []string
+	tokens := 0
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		if header != "" {
+			current = append([]string{header}, current...)
+		}
+		pieces = append(pieces, strings.Join(current, "\n"))
+		current = nil
+		tokens = 0
+	}
+	for _, u := range units {
+		unitTokens := EstimateTokens(u.text)
+		if unitTokens > budget && !u.atomic {
+			flush()
+			for _, piece := range splitByLines(u.text, budget) {
+				current = []string{piece}
+				flush()
+			}
+			continue
+		}
+		if len(current) > 0 && tokens+unitTokens > budget {
+			flush()
+		}
+		current = append(current, u.text)
+		tokens += unitTokens
+	}
+	flush()
+	return pieces
+}
+
+// chunkBuilder 累积块内容并在适当位置切分
+type chunkBuilder struct {
+	maxTokens int
+	chunks    []Chunk
+
+	path       []OutlineEntry // 当前标题路径
+	parts      []string
+	blockIDs   []string
+	tokens     int
+	hasContent bool // 当前片段是否包含标题以外的内容
+}
+
+// heading 处理标题块：结束已有内容的片段并更新标题路径
+// 连续的标题（如章节标题紧跟子标题）合并到同一片段中
+func (b *chunkBuilder) heading(entry OutlineEntry, s BlockMarkdown) {
+	if b.hasContent {
+		b.flush()
+	}
+	for len(b.path) > 0 && b.path[len(b.path)-1].Level >= entry.Level {
+		b.path = b.path[:len(b.path)-1]
+	}
+	b.path = append(b.path, entry)
+	b.add(s.BlockID, strings.TrimRight(s.Markdown, "\n"), true)
+}
+
+// add 向当前片段追加内容，超出 token 上限时先结束当前片段
+func (b *chunkBuilder) add(blockID, text string, isHeading bool) {
+	tokens := EstimateTokens(text)
+	if b.hasContent && b.tokens+tokens > b.maxTokens {
+		b.flush()
+	}
+
+	b.parts = append(b.parts, text)
+	if len(b.blockIDs) == 0 || b.blockIDs[len(b.blockIDs)-1] != blockID {
+		b.blockIDs = append(b.blockIDs, blockID)
+	}
+	b.tokens += tokens
+	if !isHeading {
+		b.hasContent = true
+	}
+}
+
+// flush 结束当前片段
+func (b *chunkBuilder) flush() {
+	if len(b.parts) == 0 {
+		return
+	}
+
+	path := make([]string, len(b.path))
+	for i, e := range b.path {
+		path[i] = e.Title
+		if e.Seq != "" {
+			path[i] = e.Seq + ". " + e.Title
+		}
+	}
+
+	text := strings.Join(b.parts, "\n\n")
+	b.chunks = append(b.chunks, Chunk{
+		Index:       len(b.chunks),
+		Text:        text,
+		HeadingPath: path,
+		BlockIDs:    b.blockIDs,
+		Tokens:      EstimateTokens(text),
+	})
+
+	b.parts = nil
+	b.blockIDs = nil
+	b.tokens = 0
+	b.hasContent = false
+}
+
+// splitByLines 将超长文本按行分组，每组不超过 maxTokens（单行超长时独立成组）
+// 围栏代码块和表格的各行作为整体，不会被拆开
+func splitByLines(text string, maxTokens int) []string {
+	var pieces []string
+	var current []string
+	tokens := 0
+
+	for _, line := range lineUnits(text) {
+		lineTokens := EstimateTokens(line)
+		if len(current) > 0 && tokens+lineTokens > maxTokens {
+			pieces = append(pieces, strings.Join(current, "\n"))
+			current = nil
+			tokens = 0
+		}
+		current = append(current, line)
+		tokens += lineTokens
+	}
+	if len(current) > 0 {
+		pieces = append(pieces, strings.Join(current, "\n"))
+	}
+	return pieces
+}
+
+// lineUnits 将文本按行切分，围栏代码块和连续的表格行合并为一个单元
+// 识别时忽略行首的缩进和引用标记，因此引用和列表中的代码块、表格同样保持完整
+func lineUnits(text string) []string {
+	var units, group []string
+	fence := ""
+	flushGroup := func() {
+		if len(group) > 0 {
+			units = append(units, strings.Join(group, "\n"))
+			group = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		bare := strings.TrimLeft(line, " \t>")
+		if fence != "" {
+			group = append(group, line)
+			if m := fenceMarker(bare); m != "" && m[0] == fence[0] && len(m) >= len(fence) && strings.TrimSpace(bare) == m {
+				flushGroup()
+				fence = ""
+			}
+			continue
+		}
+
+		isTableRow := strings.HasPrefix(bare, "|")
+		if !isTableRow {
+			flushGroup()
+		}
+		if m := fenceMarker(bare); m != "" {
+			group = append(group, line)
+			fence = m
+			continue
+		}
+		if isTableRow {
+			group = append(group, line)
+			continue
+		}
+		units = append(units, line)
+	}
+	flushGroup()
+	return units
+}
+
+// fenceMarker 返回行首的代码围栏标记（至少 3 个 ` 或 ~），不是围栏时返回空字符串
+func fenceMarker(line string) string {
+	for _, ch := range []string{"`", "~"} {
+		if n := len(line) - len(strings.TrimLeft(line, ch)); n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// EstimateTokens 粗略估算文本的 token 数
+// 中日韩文字按每字 1 个 token，其余字符按每 4 字节 1 个 token 计算
+func EstimateTokens(text string) int {
+	cjk := 0
+	other := 0
+	for _, r := range text {
+		if isCJK(r) {
+			cjk++
+		} else {
+			other += utf8.RuneLen(r)
+		}
+	}
+	return cjk + (other+3)/4
+}
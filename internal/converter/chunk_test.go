@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func chunksFromMarkdown(t *testing.T, markdown string, maxTokens int) []Chunk {
+	t.Helper()
+	chunks, err := BuildChunks(blocksFromMarkdown(t, markdown), ConvertOptions{}, maxTokens)
+	if err != nil {
+		t.Fatalf("BuildChunks() 返回错误: %v", err)
+	}
+	return chunks
+}
+
+func TestBuildChunks_HeadingPath(t *testing.T) {
+	md := "# 概述\n\n## 背景\n\n背景内容\n\n## 目标\n\n目标内容\n\n# 设计\n\n设计内容"
+	chunks := chunksFromMarkdown(t, md, 800)
+
+	want := [][]string{
+		{"概述", "背景"},
+		{"概述", "目标"},
+		{"设计"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("片段数 = %d, 期望 %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		if !reflect.DeepEqual(chunks[i].HeadingPath, w) {
+			t.Errorf("第 %d 个片段标题路径 = %v, 期望 %v", i+1, chunks[i].HeadingPath, w)
+		}
+		if chunks[i].Index != i {
+			t.Errorf("第 %d 个片段序号 = %d", i+1, chunks[i].Index)
+		}
+	}
+
+	// 连续标题合并到同一片段
+	if !strings.HasPrefix(chunks[0].Text, "# 概述\n\n## 背景") || len(chunks[0].BlockIDs) != 3 {
+		t.Errorf("第 1 个片段 = %+v, 期望包含两个标题和正文", chunks[0])
+	}
+}
+
+func TestBuildChunks_MaxTokens(t *testing.T) {
+	var paragraphs []string
+	for i := 0; i < 6; i++ {
+		paragraphs = append(paragraphs, strings.Repeat("字", 30))
+	}
+	chunks := chunksFromMarkdown(t, "# 标题\n\n"+strings.Join(paragraphs, "\n\n"), 100)
+
+	for _, c := range chunks {
+		if c.Tokens > 100 {
+			t.Errorf("片段超出上限: %d tokens", c.Tokens)
+		}
+		if !reflect.DeepEqual(c.HeadingPath, []string{"标题"}) {
+			t.Errorf("拆分后的片段应保留标题路径: %v", c.HeadingPath)
+		}
+	}
+	if len(chunks) < 2 {
+		t.Errorf("片段数 = %d, 期望按上限拆分为多个片段", len(chunks))
+	}
+}
+
+func TestBuildChunks_AtomicCode(t *testing.T) {
+	code := "```go\n" + strings.Repeat("fmt.Println(\"hello world\")\n", 40) + "```"
+	chunks := chunksFromMarkdown(t, "# 示例\n\n"+code, 50)
+
+	found := false
+	for _, c := range chunks {
+		if strings.Contains(c.Text, "```go") {
+			found = true
+			if !strings.HasSuffix(strings.TrimSpace(c.Text), "```") {
+				t.Error("代码块不应被拆分")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("未找到代码块片段")
+	}
+}
+
+func TestBuildChunks_CalloutWithCode(t *testing.T) {
+	var lines []string
+	lines = append(lines, "> [!WARNING]")
+	for i := 0; i < 4; i++ {
+		lines = append(lines, "> "+strings.Repeat("注意", 20), ">")
+	}
+	lines = append(lines, "> ```go")
+	for i := 0; i < 10; i++ {
+		lines = append(lines, "> fmt.Println(\"hello world\")")
+	}
+	lines = append(lines, "> ```", ">", "> "+strings.Repeat("结尾", 20))
+	chunks := chunksFromMarkdown(t, "# 示例\n\n"+strings.Join(lines, "\n"), 60)
+
+	if len(chunks) < 3 {
+		t.Fatalf("片段数 = %d, 期望超长高亮块被拆分: %+v", len(chunks), chunks)
+	}
+	found := false
+	for i, c := range chunks {
+		body := strings.TrimPrefix(c.Text, "# 示例\n\n")
+		if !strings.HasPrefix(body, "> [!WARNING]\n") {
+			t.Errorf("第 %d 个片段应以高亮块类型行开头: %q", i+1, c.Text)
+		}
+		if strings.Count(c.Text, "```")%2 != 0 {
+			t.Errorf("第 %d 个片段拆开了代码块: %q", i+1, c.Text)
+		}
+		if strings.Contains(c.Text, "```go") {
+			found = true
+			if strings.Count(c.Text, "hello world") != 10 {
+				t.Errorf("代码块应完整保留在同一片段中: %q", c.Text)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("未找到代码块片段")
+	}
+}
+
+func TestSplitByLines_KeepsFenceAndTable(t *testing.T) {
+	text := "a\n```\nx\ny\n```\n| h |\n| --- |\n| v |\nb"
+	want := []string{"a", "```\nx\ny\n```", "| h |\n| --- |\n| v |", "b"}
+	if got := splitByLines(text, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("splitByLines() = %q, 期望 %q", got, want)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("你好"); got != 2 {
+		t.Errorf("EstimateTokens(你好) = %d, 期望 2", got)
+	}
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("EstimateTokens(abcdefgh) = %d, 期望 2", got)
+	}
+}
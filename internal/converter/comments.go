@@ -0,0 +1,258 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+// 评论在导出 Markdown 中的渲染方式
+const (
+	CommentStyleFootnote = "footnote" // Markdown 脚注
+	CommentStyleHTML     = "html"     // <!-- comment --> 注释块
+)
+
+// AnchoredComment 表示定位到文档块的评论
+type AnchoredComment struct {
+	*client.Comment
+	BlockID string `json:"block_id,omitempty"` // 引用文本所在的块，全文评论或无法定位时为空
+
+	topLevelID string // BlockID 所属的顶层块
+}
+
+// AnchorComments 根据局部评论的引用文本定位评论所在的块
+// 优先匹配包含引用文本的第一个块；引用跨越多个子块时匹配包含它的顶层块
+func (c *BlockToMarkdown) AnchorComments(comments []*client.Comment) []AnchoredComment {
+	type blockText struct {
+		id, topLevelID, text string
+	}
+
+	var texts []blockText
+	subtree := map[string]*strings.Builder{} // 顶层块 -> 整个子树的文本
+	var topLevelOrder []string
+	ancestors := c.topLevelAncestors()
+	for _, block := range c.blocks {
+		id := client.StringVal(block.BlockId)
+		topLevelID, ok := ancestors[id]
+		if !ok {
+			continue
+		}
+		text := BlockPlainText(block)
+		texts = append(texts, blockText{id: id, topLevelID: topLevelID, text: text})
+		if subtree[topLevelID] == nil {
+			subtree[topLevelID] = &strings.Builder{}
+			topLevelOrder = append(topLevelOrder, topLevelID)
+		}
+		subtree[topLevelID].WriteString(text)
+	}
+
+	anchored := make([]AnchoredComment, len(comments))
+	for i, comment := range comments {
+		anchored[i] = AnchoredComment{Comment: comment}
+		quote := strings.TrimSpace(comment.Quote)
+		if comment.IsWhole || quote == "" {
+			continue
+		}
+		for _, t := range texts {
+			if strings.Contains(t.text, quote) {
+				anchored[i].BlockID, anchored[i].topLevelID = t.id, t.topLevelID
+				break
+			}
+		}
+		if anchored[i].topLevelID != "" {
+			continue
+		}
+		for _, id := range topLevelOrder {
+			if strings.Contains(subtree[id].String(), quote) {
+				anchored[i].BlockID, anchored[i].topLevelID = id, id
+				break
+			}
+		}
+	}
+	return anchored
+}
+
+// topLevelAncestors 返回每个块所属的顶层块（页面块的直接子块或没有父块的块）
+// 父子关系取自 Children 列表，页面块本身不在结果中
+func (c *BlockToMarkdown) topLevelAncestors() map[string]string {
+	parent := map[string]string{}
+	for _, block := range c.blocks {
+		if block.BlockType != nil && BlockType(*block.BlockType) == BlockTypePage {
+			continue
+		}
+		for _, childID := range block.Children {
+			parent[childID] = client.StringVal(block.BlockId)
+		}
+	}
+
+	result := map[string]string{}
+	for _, block := range c.blocks {
+		if block.BlockType != nil && BlockType(*block.BlockType) == BlockTypePage {
+			continue
+		}
+		id := client.StringVal(block.BlockId)
+		top := id
+		for depth := 0; depth < 100; depth++ {
+			p, ok := parent[top]
+			if !ok {
+				break
+			}
+			top = p
+		}
+		result[id] = top
+	}
+	return result
+}
+
+// ConvertWithComments 转换为 Markdown，并将评论渲染在所定位的顶层块之后
+// 脚注样式在块末尾添加脚注引用、在文末输出脚注定义；注释样式在块之后输出 <!-- comment --> 块。
+// 全文评论和无法定位的评论放在文末
+func (c *BlockToMarkdown) ConvertWithComments(comments []AnchoredComment, style string) (string, error) {
+	if style != CommentStyleFootnote && style != CommentStyleHTML {
+		return "", fmt.Errorf("不支持的评论样式: %s（可选 %s/%s）", style, CommentStyleFootnote, CommentStyleHTML)
+	}
+
+	sections, err := c.ConvertTopLevel()
+	if err != nil {
+		return "", err
+	}
+
+	byBlock := map[string][]int{}
+	var unanchored []int
+	for i, comment := range comments {
+		if comment.topLevelID == "" {
+			unanchored = append(unanchored, i)
+			continue
+		}
+		byBlock[comment.topLevelID] = append(byBlock[comment.topLevelID], i)
+	}
+
+	// 脚注按在文中出现的顺序编号
+	labels := map[int]string{}
+	var order []int
+	label := func(i int) string {
+		if l, ok := labels[i]; ok {
+			return l
+		}
+		labels[i] = fmt.Sprintf("[^comment-%d]", len(order)+1)
+		order = append(order, i)
+		return labels[i]
+	}
+
+	for s := range sections {
+		indexes := byBlock[sections[s].BlockID]
+		if len(indexes) == 0 {
+			continue
+		}
+		body := strings.TrimRight(sections[s].Markdown, "\n")
+		suffix := sections[s].Markdown[len(body):]
+
+		var sb strings.Builder
+		sb.WriteString(body)
+		switch style {
+		case CommentStyleFootnote:
+			if isAtomicBlockType(sections[s].BlockType) {
+				sb.WriteString("\n\n")
+			} else {
+				sb.WriteString(" ")
+			}
+			for _, i := range indexes {
+				sb.WriteString(label(i))
+			}
+		case CommentStyleHTML:
+			for _, i := range indexes {
+				sb.WriteString("\n\n")
+				sb.WriteString(renderCommentHTML(comments[i]))
+			}
+		}
+		sb.WriteString(suffix)
+		sections[s].Markdown = sb.String()
+	}
+
+	output := joinSections(sections)
+	if len(unanchored) == 0 && len(order) == 0 {
+		return output, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(output)
+	switch style {
+	case CommentStyleFootnote:
+		if len(unanchored) > 0 {
+			sb.WriteString("\n全文评论")
+			for _, i := range unanchored {
+				sb.WriteString(label(i))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+		for _, i := range order {
+			sb.WriteString(renderCommentFootnote(labels[i], comments[i]))
+		}
+	case CommentStyleHTML:
+		for _, i := range unanchored {
+			sb.WriteString("\n")
+			sb.WriteString(renderCommentHTML(comments[i]))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// commentHeader 返回评论的概要（ID、状态和引用文本）
+func commentHeader(comment AnchoredComment) string {
+	status := "未解决"
+	if comment.IsSolved {
+		status = "已解决"
+	}
+	header := fmt.Sprintf("评论 %s (%s)", comment.CommentID, status)
+	if comment.Quote != "" && !comment.IsWhole {
+		header += fmt.Sprintf(" 引用: \"%s\"", singleLine(comment.Quote))
+	}
+	return header
+}
+
+// commentReplyLine 返回一条回复的单行文本
+func commentReplyLine(reply *client.CommentReply) string {
+	line := reply.UserID
+	if reply.CreateTime > 0 {
+		line += " " + time.Unix(int64(reply.CreateTime), 0).Format("2006-01-02 15:04")
+	}
+	return line + ": " + singleLine(reply.Text)
+}
+
+// renderCommentFootnote 将评论渲染为脚注定义，回复作为脚注内的列表
+func renderCommentFootnote(label string, comment AnchoredComment) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s\n", label, commentHeader(comment))
+	for _, reply := range comment.Replies {
+		fmt.Fprintf(&sb, "    - %s\n", commentReplyLine(reply))
+	}
+	return sb.String()
+}
+
+// renderCommentHTML 将评论渲染为 HTML 注释块
+func renderCommentHTML(comment AnchoredComment) string {
+	var sb strings.Builder
+	sb.WriteString("<!-- comment\n")
+	sb.WriteString(escapeHTMLComment(commentHeader(comment)))
+	sb.WriteString("\n")
+	for _, reply := range comment.Replies {
+		sb.WriteString(escapeHTMLComment(commentReplyLine(reply)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("-->")
+	return sb.String()
+}
+
+// singleLine 将多行文本合并为一行
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// escapeHTMLComment 避免评论内容提前结束 HTML 注释
+func escapeHTMLComment(text string) string {
+	return strings.ReplaceAll(text, "--", "- -")
+}
@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+func TestConvertWithComments(t *testing.T) {
+	md := "# 设计\n\n第一段包含有问题的句子。\n\n- 列表项\n  - 嵌套的待确认内容\n"
+	blocks := blocksFromMarkdown(t, md)
+
+	comments := []*client.Comment{
+		{CommentID: "c1", Quote: "有问题的句子", Replies: []*client.CommentReply{{UserID: "ou_a", Text: "这里需要修改"}}},
+		{CommentID: "c2", Quote: "待确认", IsSolved: true, Replies: []*client.CommentReply{{UserID: "ou_b", Text: "已确认 --> 没问题"}}},
+		{CommentID: "c3", IsWhole: true, Replies: []*client.CommentReply{{UserID: "ou_c", Text: "整体不错"}}},
+	}
+
+	conv := NewBlockToMarkdown(blocks, ConvertOptions{})
+	anchored := conv.AnchorComments(comments)
+	if anchored[0].BlockID == "" || anchored[1].BlockID == "" || anchored[2].BlockID != "" {
+		t.Fatalf("评论定位错误: %+v", anchored)
+	}
+	if anchored[1].topLevelID == anchored[1].BlockID {
+		t.Errorf("嵌套块中的评论应定位到其顶层块")
+	}
+
+	footnote, err := NewBlockToMarkdown(blocks, ConvertOptions{}).ConvertWithComments(anchored, CommentStyleFootnote)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	for _, want := range []string{
+		"第一段包含有问题的句子。 [^comment-1]",
+		"全文评论[^comment-3]",
+		"[^comment-2]: 评论 c2 (已解决) 引用: \"待确认\"\n    - ou_b: 已确认 --> 没问题\n",
+	} {
+		if !strings.Contains(footnote, want) {
+			t.Errorf("脚注输出缺少 %q:\n%s", want, footnote)
+		}
+	}
+
+	html, err := NewBlockToMarkdown(blocks, ConvertOptions{}).ConvertWithComments(anchored, CommentStyleHTML)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	if !strings.Contains(html, "<!-- comment\n评论 c1 (未解决) 引用: \"有问题的句子\"\nou_a: 这里需要修改\n-->") {
+		t.Errorf("注释输出错误:\n%s", html)
+	}
+	if strings.Count(html, "-->") != 3 {
+		t.Errorf("评论内容中的 --> 应被转义:\n%s", html)
+	}
+}
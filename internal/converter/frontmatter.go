@@ -0,0 +1,131 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter 表示 Markdown 文件头部的 YAML front matter
+// 用于携带文档的发布信息（标题、目标位置、权限等），导入和导出使用同一组字段
+type FrontMatter struct {
+	Title       string                  `yaml:"title,omitempty"`
+	DocumentID  string                  `yaml:"document_id,omitempty"`
+	Folder      string                  `yaml:"folder,omitempty"`
+	WikiParent  string                  `yaml:"wiki_parent,omitempty"`
+	Owner       *FrontMatterOwner       `yaml:"owner,omitempty"`
+	Permissions []FrontMatterPermission `yaml:"permissions,omitempty"`
+}
+
+// FrontMatterOwner 表示文档所有者
+// 支持简写为字符串（如 "user@example.com"、"ou_xxx"），此时根据 ID 格式推断成员类型
+type FrontMatterOwner struct {
+	MemberType string `yaml:"member_type"`
+	MemberID   string `yaml:"member_id"`
+}
+
+// UnmarshalYAML 同时支持字符串和 {member_type, member_id} 两种写法
+func (o *FrontMatterOwner) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.MemberID = node.Value
+		o.MemberType = InferMemberType(node.Value)
+		return nil
+	}
+
+	type plain FrontMatterOwner
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	if p.MemberType == "" {
+		p.MemberType = InferMemberType(p.MemberID)
+	}
+	*o = FrontMatterOwner(p)
+	return nil
+}
+
+// InferMemberType 根据成员 ID 的格式推断权限接口使用的成员类型
+func InferMemberType(memberID string) string {
+	switch {
+	case strings.Contains(memberID, "@"):
+		return "email"
+	case strings.HasPrefix(memberID, "ou_"):
+		return "openid"
+	case strings.HasPrefix(memberID, "on_"):
+		return "unionid"
+	case strings.HasPrefix(memberID, "oc_"):
+		return "openchat"
+	default:
+		return "userid"
+	}
+}
+
+// FrontMatterPermission 表示 front matter 中声明的一条协作者权限
+type FrontMatterPermission struct {
+	MemberType string `yaml:"member_type"`
+	MemberID   string `yaml:"member_id"`
+	Perm       string `yaml:"perm"`
+}
+
+// ParseFrontMatter 解析 Markdown 开头的 YAML front matter
+// 返回解析出的 front matter（不存在时为 nil）和去除 front matter 后的正文
+func ParseFrontMatter(markdown string) (*FrontMatter, string, error) {
+	raw, body, ok := splitFrontMatter(markdown)
+	if !ok {
+		return nil, markdown, nil
+	}
+
+	fm := &FrontMatter{}
+	if err := yaml.Unmarshal([]byte(raw), fm); err != nil {
+		return nil, markdown, fmt.Errorf("解析 front matter 失败: %w", err)
+	}
+	for i, p := range fm.Permissions {
+		if p.MemberType == "" || p.MemberID == "" || p.Perm == "" {
+			return nil, markdown, fmt.Errorf("front matter 第 %d 条权限缺少 member_type/member_id/perm", i+1)
+		}
+	}
+
+	if fm.Owner != nil && fm.Owner.MemberID == "" {
+		return nil, markdown, fmt.Errorf("front matter 中 owner 缺少 member_id")
+	}
+
+	return fm, body, nil
+}
+
+// Render 将 front matter 序列化为 "---" 包裹的 YAML 块，可直接拼接在正文之前
+func (fm *FrontMatter) Render() (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return "", fmt.Errorf("序列化 front matter 失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("序列化 front matter 失败: %w", err)
+	}
+	return "---\n" + buf.String() + "---\n\n", nil
+}
+
+// splitFrontMatter 将 Markdown 拆分为 front matter 原文和正文
+// front matter 必须位于文件第一行，以 "---" 开始并以 "---" 或 "..." 结束
+func splitFrontMatter(markdown string) (string, string, bool) {
+	text := strings.TrimPrefix(markdown, "\ufeff")
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r\n") != "---" {
+		return "", markdown, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+		if line == "---" || line == "..." {
+			raw := strings.Join(lines[1:i], "")
+			body := strings.Join(lines[i+1:], "")
+			return raw, strings.TrimLeft(body, "\r\n"), true
+		}
+	}
+
+	// 未闭合的 front matter 视为普通正文（如以分割线开头的文档）
+	return "", markdown, false
+}
@@ -0,0 +1,121 @@
+package converter
+
+import (
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	input := "---\ntitle: \"周报 2024-01-01\"\nfolder: fldcnXXX\npermissions:\n  - member_type: email\n    member_id: a@example.com\n    perm: edit\n---\n\n# 正文\n"
+
+	fm, body, err := ParseFrontMatter(input)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter() 返回错误: %v", err)
+	}
+	if fm == nil {
+		t.Fatal("ParseFrontMatter() 未解析出 front matter")
+	}
+	if fm.Title != "周报 2024-01-01" {
+		t.Errorf("Title = %q, 期望 %q", fm.Title, "周报 2024-01-01")
+	}
+	if fm.Folder != "fldcnXXX" {
+		t.Errorf("Folder = %q, 期望 %q", fm.Folder, "fldcnXXX")
+	}
+	if len(fm.Permissions) != 1 || fm.Permissions[0].MemberID != "a@example.com" || fm.Permissions[0].Perm != "edit" {
+		t.Errorf("Permissions = %+v", fm.Permissions)
+	}
+	if body != "# 正文\n" {
+		t.Errorf("body = %q, 期望 %q", body, "# 正文\n")
+	}
+}
+
+func TestParseFrontMatter_NoFrontMatter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"普通文档", "# 标题\n\n内容\n"},
+		{"以分割线开头且未闭合", "---\n\n内容\n"},
+		{"分割线不在首行", "内容\n---\ntitle: x\n---\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, body, err := ParseFrontMatter(tt.input)
+			if err != nil {
+				t.Fatalf("ParseFrontMatter() 返回错误: %v", err)
+			}
+			if fm != nil {
+				t.Errorf("不应解析出 front matter: %+v", fm)
+			}
+			if body != tt.input {
+				t.Errorf("body = %q, 期望原文 %q", body, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseFrontMatter_InvalidPermission(t *testing.T) {
+	input := "---\npermissions:\n  - member_type: email\n    perm: view\n---\n正文\n"
+	if _, _, err := ParseFrontMatter(input); err == nil {
+		t.Error("缺少 member_id 的权限应返回错误")
+	}
+}
+
+func TestParseFrontMatter_Owner(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		memberType string
+		memberID   string
+	}{
+		{"邮箱简写", "---\nowner: a@example.com\n---\n", "email", "a@example.com"},
+		{"open_id 简写", "---\nowner: ou_123\n---\n", "openid", "ou_123"},
+		{"完整写法", "---\nowner:\n  member_type: userid\n  member_id: u1\n---\n", "userid", "u1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, _, err := ParseFrontMatter(tt.input)
+			if err != nil {
+				t.Fatalf("ParseFrontMatter() 返回错误: %v", err)
+			}
+			if fm.Owner == nil || fm.Owner.MemberType != tt.memberType || fm.Owner.MemberID != tt.memberID {
+				t.Errorf("Owner = %+v, 期望 {%s %s}", fm.Owner, tt.memberType, tt.memberID)
+			}
+		})
+	}
+}
+
+func TestFrontMatterRender_RoundTrip(t *testing.T) {
+	original := &FrontMatter{
+		Title:      "设计文档: v2",
+		DocumentID: "doxcnABC",
+		WikiParent: "wikcnXYZ",
+		Owner:      &FrontMatterOwner{MemberType: "openid", MemberID: "ou_1"},
+		Permissions: []FrontMatterPermission{
+			{MemberType: "email", MemberID: "b@example.com", Perm: "view"},
+		},
+	}
+
+	rendered, err := original.Render()
+	if err != nil {
+		t.Fatalf("Render() 返回错误: %v", err)
+	}
+
+	fm, body, err := ParseFrontMatter(rendered + "# 正文\n")
+	if err != nil {
+		t.Fatalf("ParseFrontMatter() 返回错误: %v", err)
+	}
+	if body != "# 正文\n" {
+		t.Errorf("body = %q", body)
+	}
+	if fm.Title != original.Title || fm.DocumentID != original.DocumentID || fm.WikiParent != original.WikiParent {
+		t.Errorf("往返后字段不一致: %+v", fm)
+	}
+	if fm.Owner == nil || *fm.Owner != *original.Owner {
+		t.Errorf("Owner = %+v, 期望 %+v", fm.Owner, original.Owner)
+	}
+	if len(fm.Permissions) != 1 || fm.Permissions[0] != original.Permissions[0] {
+		t.Errorf("Permissions = %+v", fm.Permissions)
+	}
+}
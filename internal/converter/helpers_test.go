@@ -0,0 +1,17 @@
+package converter
+
+import (
+	"testing"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+// blocksFromMarkdown 将 Markdown 转换为文档块列表，用作测试数据
+func blocksFromMarkdown(t *testing.T, markdown string) []*larkdocx.Block {
+	t.Helper()
+	result, err := NewMarkdownToBlock([]byte(markdown), ConvertOptions{}, "").ConvertWithTableData()
+	if err != nil {
+		t.Fatalf("Markdown → Block 失败: %v", err)
+	}
+	return BuildDocumentBlocks(result)
+}
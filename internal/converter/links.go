@@ -0,0 +1,67 @@
+package converter
+
+import (
+	"net/url"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+// 文档中的链接类型
+const (
+	LinkKindURL     = "link"    // 文本超链接
+	LinkKindMention = "mention" // @文档
+)
+
+// LinkRef 表示文档中的一个链接
+type LinkRef struct {
+	BlockID string `json:"block_id"`
+	Kind    string `json:"kind"`
+	Text    string `json:"text,omitempty"` // 链接文字或 @文档 标题
+	URL     string `json:"url,omitempty"`
+	Token   string `json:"token,omitempty"`    // @文档 的文档 token
+	ObjType int    `json:"obj_type,omitempty"` // @文档 的云文档类型
+}
+
+// CollectLinks 收集所有文本类块中的超链接和 @文档 引用
+// 同一块中相邻且指向同一地址的文本片段（如部分加粗的链接）合并为一个链接
+func CollectLinks(blocks []*larkdocx.Block) []LinkRef {
+	var links []LinkRef
+	for _, block := range blocks {
+		blockID := client.StringVal(block.BlockId)
+		last := -1 // 上一个超链接在 links 中的下标
+		for _, elem := range BlockTextElements(block) {
+			if elem == nil {
+				continue
+			}
+			switch {
+			case elem.TextRun != nil && elem.TextRun.TextElementStyle != nil &&
+				elem.TextRun.TextElementStyle.Link != nil && elem.TextRun.TextElementStyle.Link.Url != nil:
+				linkURL := *elem.TextRun.TextElementStyle.Link.Url
+				if decoded, err := url.QueryUnescape(linkURL); err == nil {
+					linkURL = decoded
+				}
+				text := client.StringVal(elem.TextRun.Content)
+				if last >= 0 && links[last].URL == linkURL {
+					links[last].Text += text
+					continue
+				}
+				links = append(links, LinkRef{BlockID: blockID, Kind: LinkKindURL, Text: text, URL: linkURL})
+				last = len(links) - 1
+			case elem.MentionDoc != nil:
+				links = append(links, LinkRef{
+					BlockID: blockID,
+					Kind:    LinkKindMention,
+					Text:    client.StringVal(elem.MentionDoc.Title),
+					URL:     client.StringVal(elem.MentionDoc.Url),
+					Token:   client.StringVal(elem.MentionDoc.Token),
+					ObjType: client.IntVal(elem.MentionDoc.ObjType),
+				})
+				last = -1
+			default:
+				last = -1
+			}
+		}
+	}
+	return links
+}
@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"testing"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+func TestCollectLinks(t *testing.T) {
+	str := func(s string) *string { return &s }
+	run := func(content, link string) *larkdocx.TextElement {
+		elem := &larkdocx.TextElement{TextRun: &larkdocx.TextRun{Content: str(content)}}
+		if link != "" {
+			elem.TextRun.TextElementStyle = &larkdocx.TextElementStyle{Link: &larkdocx.Link{Url: str(link)}}
+		}
+		return elem
+	}
+	blockType := int(BlockTypeText)
+
+	blocks := []*larkdocx.Block{{
+		BlockId:   str("b1"),
+		BlockType: &blockType,
+		Text: &larkdocx.Text{Elements: []*larkdocx.TextElement{
+			run("参见 ", ""),
+			run("官方", "https%3A%2F%2Fexample.com%2Fdocs"),
+			run("文档", "https%3A%2F%2Fexample.com%2Fdocs"),
+			{MentionDoc: &larkdocx.MentionDoc{Token: str("doxcnABC"), Title: str("设计")}},
+		}},
+	}}
+
+	links := CollectLinks(blocks)
+	if len(links) != 2 {
+		t.Fatalf("链接数量 = %d, 期望 2: %+v", len(links), links)
+	}
+	if links[0].URL != "https://example.com/docs" || links[0].Text != "官方文档" || links[0].BlockID != "b1" {
+		t.Errorf("超链接解析错误: %+v", links[0])
+	}
+	if links[1].Kind != LinkKindMention || links[1].Token != "doxcnABC" {
+		t.Errorf("@文档 解析错误: %+v", links[1])
+	}
+}
@@ -0,0 +1,133 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+// Location 表示文档中定位到的文本
+type Location struct {
+	BlockID string `json:"block_id"`
+	Quote   string `json:"quote"` // 实际引用的文本
+}
+
+// Locate 在文档中定位引用文本
+// headingPath 非空时只在该标题的章节（含子章节）内查找，路径可以只给出末尾几级标题；
+// quote 为空时引用标题本身。
+// 局部评论只记录引用文本而不记录位置，因此引用文本在整个文档中出现多次时返回错误，
+// 即使 headingPath 已将其限定在某个章节内
+func (c *BlockToMarkdown) Locate(headingPath []string, quote string) (*Location, error) {
+	start, end := 0, len(c.blocks)
+	if len(headingPath) > 0 {
+		var err error
+		start, end, err = c.sectionRange(headingPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quote = strings.TrimSpace(quote)
+	if quote == "" {
+		if len(headingPath) == 0 {
+			return nil, fmt.Errorf("未指定引用文本或标题路径")
+		}
+		block := c.blocks[start]
+		return c.uniqueLocation(client.StringVal(block.BlockId), strings.TrimSpace(BlockPlainText(block)))
+	}
+
+	for _, block := range c.blocks[start:end] {
+		if strings.Contains(BlockPlainText(block), quote) {
+			return c.uniqueLocation(client.StringVal(block.BlockId), quote)
+		}
+	}
+	if len(headingPath) > 0 {
+		return nil, fmt.Errorf("章节 %q 中未找到引用文本", strings.Join(headingPath, " > "))
+	}
+	return nil, fmt.Errorf("文档中未找到引用文本")
+}
+
+// uniqueLocation 确认引用文本在文档中只出现一次
+func (c *BlockToMarkdown) uniqueLocation(blockID, quote string) (*Location, error) {
+	if n := c.CountQuote(quote); n > 1 {
+		return nil, fmt.Errorf("引用文本 %q 在文档中出现 %d 次，无法确定评论位置，请引用更长的唯一片段", quote, n)
+	}
+	return &Location{BlockID: blockID, Quote: quote}, nil
+}
+
+// CountQuote 统计引用文本在整个文档中出现的次数（按块统计，不跨块匹配）
+func (c *BlockToMarkdown) CountQuote(quote string) int {
+	if quote == "" {
+		return 0
+	}
+	n := 0
+	for _, block := range c.blocks {
+		n += strings.Count(BlockPlainText(block), quote)
+	}
+	return n
+}
+
+// sectionRange 返回标题路径对应章节在块列表中的范围 [start, end)，start 为标题块本身
+func (c *BlockToMarkdown) sectionRange(headingPath []string) (int, int, error) {
+	entries := c.Outline()
+
+	// 每个标题的祖先链（含自身），用于匹配路径
+	var chain []OutlineEntry
+	target := -1
+	for i, e := range entries {
+		for len(chain) > 0 && chain[len(chain)-1].Level >= e.Level {
+			chain = chain[:len(chain)-1]
+		}
+		chain = append(chain, e)
+		if matchHeadingPath(chain, headingPath) {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return 0, 0, fmt.Errorf("未找到标题 %q", strings.Join(headingPath, " > "))
+	}
+
+	heading := entries[target]
+	start := -1
+	for i, block := range c.blocks {
+		if client.StringVal(block.BlockId) == heading.BlockID {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, 0, fmt.Errorf("未找到标题块 %s", heading.BlockID)
+	}
+
+	end := len(c.blocks)
+	for i := start + 1; i < len(c.blocks); i++ {
+		block := c.blocks[i]
+		if block.BlockType == nil {
+			continue
+		}
+		bt := BlockType(*block.BlockType)
+		if bt >= BlockTypeHeading1 && bt <= BlockTypeHeading9 && int(bt-BlockTypeHeading1)+1 <= heading.Level {
+			end = i
+			break
+		}
+	}
+	return start, end, nil
+}
+
+// matchHeadingPath 判断标题祖先链的末尾是否与路径匹配，标题可带或不带编号
+func matchHeadingPath(chain []OutlineEntry, path []string) bool {
+	if len(path) > len(chain) {
+		return false
+	}
+	offset := len(chain) - len(path)
+	for i, want := range path {
+		e := chain[offset+i]
+		want = strings.TrimSpace(want)
+		if want != e.Title && (e.Seq == "" || want != e.Seq+". "+e.Title) {
+			return false
+		}
+	}
+	return true
+}
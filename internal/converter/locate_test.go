@@ -0,0 +1,52 @@
+package converter
+
+import "testing"
+
+func TestLocate(t *testing.T) {
+	md := "# 概述\n\n使用 Redis 作为缓存。\n\n# 设计\n\n## 存储\n\n使用 Redis 保存会话。\n\n## 接口\n\n提供 REST 服务。\n"
+	blocks := blocksFromMarkdown(t, md)
+	textOf := func(id string) string {
+		for _, b := range blocks {
+			if b.BlockId != nil && *b.BlockId == id {
+				return BlockPlainText(b)
+			}
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name      string
+		path      []string
+		quote     string
+		wantText  string
+		wantQuote string
+		wantErr   bool
+	}{
+		{"全文唯一匹配", nil, "作为缓存", "使用 Redis 作为缓存。", "作为缓存", false},
+		{"限定章节", []string{"设计", "存储"}, "保存会话", "使用 Redis 保存会话。", "保存会话", false},
+		{"只给末级标题", []string{"存储"}, "Redis 保存", "使用 Redis 保存会话。", "Redis 保存", false},
+		{"全文出现多次", nil, "使用 Redis", "", "", true},
+		{"章节内唯一但全文出现多次", []string{"设计", "存储"}, "使用 Redis", "", "", true},
+		{"引用标题本身", []string{"接口"}, "", "接口", "接口", false},
+		{"章节外的文本", []string{"接口"}, "Redis", "", "", true},
+		{"标题不存在", []string{"附录"}, "", "", "", true},
+		{"文本不存在", nil, "MySQL", "", "", true},
+	}
+
+	for _, tt := range tests {
+		loc, err := NewBlockToMarkdown(blocks, ConvertOptions{}).Locate(tt.path, tt.quote)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: 期望返回错误, 实际定位到 %+v", tt.name, loc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: 定位失败: %v", tt.name, err)
+			continue
+		}
+		if got := textOf(loc.BlockID); got != tt.wantText || loc.Quote != tt.wantQuote {
+			t.Errorf("%s: 定位到 (%q, %q), 期望 (%q, %q)", tt.name, got, loc.Quote, tt.wantText, tt.wantQuote)
+		}
+	}
+}
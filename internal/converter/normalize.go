@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"fmt"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+// BuildDocumentBlocks 将 Markdown 转换结果展开为与文档 API 返回结构一致的扁平块列表
+// 为每个块分配本地 ID、补全父子关系和表格单元格，使其可以直接交给 BlockToMarkdown 转换，
+// 用于将本地 Markdown 与远端文档规范化为同一种输出后再比较
+func BuildDocumentBlocks(result *ConvertResult) []*larkdocx.Block {
+	b := &documentBlockBuilder{}
+	tableIdx := 0
+
+	for _, node := range result.BlockNodes {
+		var tableData *TableData
+		if node.Block.BlockType != nil && BlockType(*node.Block.BlockType) == BlockTypeTable && tableIdx < len(result.TableDatas) {
+			tableData = result.TableDatas[tableIdx]
+			tableIdx++
+		}
+		b.addNode(node, tableData)
+	}
+
+	return b.blocks
+}
+
+// documentBlockBuilder 按先序遍历顺序生成带本地 ID 的块
+type documentBlockBuilder struct {
+	blocks []*larkdocx.Block
+	nextID int
+}
+
+func (b *documentBlockBuilder) newID() string {
+	b.nextID++
+	return fmt.Sprintf("local_%d", b.nextID)
+}
+
+// addNode 添加一个块及其子块，返回块 ID
+func (b *documentBlockBuilder) addNode(node *BlockNode, tableData *TableData) string {
+	block := *node.Block
+	id := b.newID()
+	block.BlockId = &id
+	block.Children = nil
+	b.blocks = append(b.blocks, &block)
+
+	for _, child := range node.Children {
+		block.Children = append(block.Children, b.addNode(child, nil))
+	}
+
+	if tableData != nil && block.Table != nil {
+		table := *block.Table
+		table.Cells = b.addTableCells(tableData)
+		block.Table = &table
+	}
+
+	return id
+}
+
+// addTableCells 为表格数据生成单元格块，每个单元格包含一个文本块
+func (b *documentBlockBuilder) addTableCells(data *TableData) []string {
+	cellType := int(BlockTypeTableCell)
+	textType := int(BlockTypeText)
+
+	cells := make([]string, data.Rows*data.Cols)
+	for i := range cells {
+		var elements []*larkdocx.TextElement
+		if i < len(data.CellElements) && len(data.CellElements[i]) > 0 {
+			elements = data.CellElements[i]
+		} else if i < len(data.CellContents) && data.CellContents[i] != "" {
+			content := data.CellContents[i]
+			elements = []*larkdocx.TextElement{{TextRun: &larkdocx.TextRun{Content: &content}}}
+		}
+
+		cellID := b.newID()
+		textID := b.newID()
+		b.blocks = append(b.blocks,
+			&larkdocx.Block{
+				BlockId:   &cellID,
+				BlockType: &cellType,
+				TableCell: &larkdocx.TableCell{},
+				Children:  []string{textID},
+			},
+			&larkdocx.Block{
+				BlockId:   &textID,
+				BlockType: &textType,
+				Text:      &larkdocx.Text{Elements: elements},
+			},
+		)
+		cells[i] = cellID
+	}
+	return cells
+}
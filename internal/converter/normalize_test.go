@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDocumentBlocks_Roundtrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{"标题和段落", "# 标题\n\n正文"},
+		{"嵌套列表", "- 一级\n  - 二级"},
+		{"表格", "| 名称 | 值 |\n| --- | --- |\n| a | 1 |"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBlockToMarkdown(blocksFromMarkdown(t, tt.markdown), ConvertOptions{}).Convert()
+			if err != nil {
+				t.Fatalf("Block → Markdown 失败: %v", err)
+			}
+
+			if strings.TrimSpace(got) != tt.markdown {
+				t.Errorf("往返不一致:\n  输入: %q\n  输出: %q", tt.markdown, strings.TrimSpace(got))
+			}
+		})
+	}
+}
+
+func TestConvertTopLevel(t *testing.T) {
+	sections, err := NewBlockToMarkdown(blocksFromMarkdown(t, "# 标题\n\n- a\n  - b\n\n正文"), ConvertOptions{}).ConvertTopLevel()
+	if err != nil {
+		t.Fatalf("ConvertTopLevel() 返回错误: %v", err)
+	}
+
+	// 嵌套列表项包含在父列表块中
+	if len(sections) != 3 {
+		t.Fatalf("顶层块数 = %d, 期望 3: %+v", len(sections), sections)
+	}
+	if sections[1].BlockType != BlockTypeBullet || !strings.Contains(sections[1].Markdown, "b") {
+		t.Errorf("第 2 个顶层块 = %+v, 期望包含嵌套项的无序列表", sections[1])
+	}
+	for _, s := range sections {
+		if s.BlockID == "" {
+			t.Errorf("顶层块缺少 ID: %+v", s)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package converter
+
+import (
+	"strings"
+	"unicode"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+// OutlineEntry 表示文档大纲中的一个标题
+type OutlineEntry struct {
+	BlockID string `json:"block_id"`
+	Level   int    `json:"level"`         // 标题级别 1-9
+	Seq     string `json:"seq,omitempty"` // 标题编号（如 "2"），未开启编号时为空
+	Title   string `json:"title"`
+	Words   int    `json:"words"` // 章节字数（含子章节，不含标题本身）
+}
+
+// Outline 提取文档的标题大纲，标题编号与导出 Markdown 时一致
+// 章节字数统计从该标题到下一个同级或更高级标题之间的所有文本
+func (c *BlockToMarkdown) Outline() []OutlineEntry {
+	var entries []OutlineEntry
+	var open []int // 当前未结束的章节（entries 下标），级别严格递增
+
+	for _, block := range c.blocks {
+		if block.BlockType == nil {
+			continue
+		}
+		blockType := BlockType(*block.BlockType)
+
+		if blockType >= BlockTypeHeading1 && blockType <= BlockTypeHeading9 {
+			level := int(blockType) - int(BlockTypeHeading1) + 1
+			elements, style := getHeadingTextAndStyle(block, blockType)
+			seq := strings.TrimSuffix(c.computeHeadingSeq(level, style), ". ")
+
+			for len(open) > 0 && entries[open[len(open)-1]].Level >= level {
+				open = open[:len(open)-1]
+			}
+			entries = append(entries, OutlineEntry{
+				BlockID: client.StringVal(block.BlockId),
+				Level:   level,
+				Seq:     seq,
+				Title:   strings.TrimSpace(c.convertTextElementsRaw(elements)),
+			})
+			open = append(open, len(entries)-1)
+			continue
+		}
+
+		if len(open) == 0 {
+			continue
+		}
+		words := CountWords(c.convertTextElementsRaw(BlockTextElements(block))).Total()
+		for _, idx := range open {
+			entries[idx].Words += words
+		}
+	}
+
+	return entries
+}
+
+// BlockTextElements 返回文本类块（正文、标题、列表、代码、引用、待办、公式）的文本元素
+func BlockTextElements(block *larkdocx.Block) []*larkdocx.TextElement {
+	if block.BlockType == nil {
+		return nil
+	}
+
+	var text *larkdocx.Text
+	switch BlockType(*block.BlockType) {
+	case BlockTypeText:
+		text = block.Text
+	case BlockTypeHeading1, BlockTypeHeading2, BlockTypeHeading3,
+		BlockTypeHeading4, BlockTypeHeading5, BlockTypeHeading6,
+		BlockTypeHeading7, BlockTypeHeading8, BlockTypeHeading9:
+		elements, _ := getHeadingTextAndStyle(block, BlockType(*block.BlockType))
+		return elements
+	case BlockTypeBullet:
+		text = block.Bullet
+	case BlockTypeOrdered:
+		text = block.Ordered
+	case BlockTypeCode:
+		text = block.Code
+	case BlockTypeQuote:
+		text = block.Quote
+	case BlockTypeTodo:
+		text = block.Todo
+	case BlockTypeEquation:
+		text = block.Equation
+	}
+
+	if text == nil {
+		return nil
+	}
+	return text.Elements
+}
+
+// BlockPlainText 返回文本类块的纯文本内容（不含 Markdown 标记）
+func BlockPlainText(block *larkdocx.Block) string {
+	c := NewBlockToMarkdown([]*larkdocx.Block{block}, ConvertOptions{})
+	return c.convertTextElementsRaw(BlockTextElements(block))
+}
+
+// WordCount 表示文本的字数统计
+type WordCount struct {
+	CJK   int `json:"cjk"`   // 中日韩文字数（每个字计为一个字）
+	Latin int `json:"latin"` // 拉丁文单词数（连续字母和数字计为一个词）
+}
+
+// Total 返回总字数
+func (w WordCount) Total() int {
+	return w.CJK + w.Latin
+}
+
+// CountWords 统计文本字数，中日韩文字按字计数，拉丁文按单词计数
+func CountWords(text string) WordCount {
+	var wc WordCount
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			wc.CJK++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				wc.Latin++
+				inWord = true
+			}
+		case r == '\'' || r == '-' || r == '_':
+			// 单词内的连接符不打断单词
+		default:
+			inWord = false
+		}
+	}
+	return wc
+}
+
+// isCJK 判断是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"testing"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		text  string
+		cjk   int
+		latin int
+	}{
+		{"", 0, 0},
+		{"你好世界", 4, 0},
+		{"hello world", 0, 2},
+		{"使用 Go 语言编写 CLI 工具", 8, 2},
+		{"don't stop-words v1.2", 0, 4},
+	}
+
+	for _, tt := range tests {
+		got := CountWords(tt.text)
+		if got.CJK != tt.cjk || got.Latin != tt.latin {
+			t.Errorf("CountWords(%q) = %+v, 期望 {CJK:%d Latin:%d}", tt.text, got, tt.cjk, tt.latin)
+		}
+	}
+}
+
+func TestOutline(t *testing.T) {
+	heading := func(id string, bt BlockType, text string, seq string) *larkdocx.Block {
+		t := int(bt)
+		content := text
+		body := &larkdocx.Text{Elements: []*larkdocx.TextElement{{TextRun: &larkdocx.TextRun{Content: &content}}}}
+		if seq != "" {
+			body.Style = &larkdocx.TextStyle{Sequence: &seq}
+		}
+		b := &larkdocx.Block{BlockId: &id, BlockType: &t}
+		switch bt {
+		case BlockTypeHeading1:
+			b.Heading1 = body
+		case BlockTypeHeading2:
+			b.Heading2 = body
+		}
+		return b
+	}
+	text := func(id, content string) *larkdocx.Block {
+		t := int(BlockTypeText)
+		return &larkdocx.Block{BlockId: &id, BlockType: &t, Text: &larkdocx.Text{
+			Elements: []*larkdocx.TextElement{{TextRun: &larkdocx.TextRun{Content: &content}}},
+		}}
+	}
+
+	blocks := []*larkdocx.Block{
+		text("t0", "前言不计入任何章节"),
+		heading("h1", BlockTypeHeading1, "概述", "auto"),
+		text("t1", "一二三"),
+		heading("h2", BlockTypeHeading2, "背景", "auto"),
+		text("t2", "four five"),
+		heading("h3", BlockTypeHeading1, "设计", "auto"),
+		text("t3", "六"),
+	}
+
+	entries := NewBlockToMarkdown(blocks, ConvertOptions{}).Outline()
+	want := []OutlineEntry{
+		{BlockID: "h1", Level: 1, Seq: "1", Title: "概述", Words: 5},
+		{BlockID: "h2", Level: 2, Seq: "1", Title: "背景", Words: 2},
+		{BlockID: "h3", Level: 1, Seq: "2", Title: "设计", Words: 1},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("标题数 = %d, 期望 %d: %+v", len(entries), len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("第 %d 个标题 = %+v, 期望 %+v", i+1, entries[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/riba2534/feishu-cli/internal/client"
+)
+
+// 阅读速度：中日韩文字约 300 字/分钟，拉丁文约 200 词/分钟
+const (
+	readingSpeedCJK   = 300
+	readingSpeedLatin = 200
+)
+
+// 文档统计中的问题类型
+const (
+	IssueImageMissingAlt = "image_missing_alt"
+	IssueEmptyHeading    = "empty_heading"
+	IssueBrokenMention   = "broken_mention"
+	IssueDeepNesting     = "deep_nesting"
+	IssueOversizedTable  = "oversized_table"
+)
+
+// StatsOptions 文档统计选项
+type StatsOptions struct {
+	MaxDepth      int // 嵌套深度上限，超过时报告问题（0 表示不检查）
+	MaxTableCells int // 表格单元格数上限，超过时报告问题（0 表示不检查）
+}
+
+// StatsIssue 表示文档统计发现的问题
+type StatsIssue struct {
+	Type    string `json:"type"`
+	BlockID string `json:"block_id,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+// MentionRef 表示文档中的一个 @文档 引用
+type MentionRef struct {
+	BlockID string `json:"block_id"`
+	Token   string `json:"token"`
+	ObjType int    `json:"obj_type"`
+	Title   string `json:"title,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// DocStats 文档统计结果
+type DocStats struct {
+	Blocks         int            `json:"blocks"`      // 块总数（不含页面块）
+	BlockTypes     map[string]int `json:"block_types"` // 按块类型计数
+	Words          WordCount      `json:"words"`
+	Characters     int            `json:"characters"` // 非空白字符数
+	ReadingMinutes int            `json:"reading_minutes"`
+	MaxDepth       int            `json:"max_depth"` // 最大嵌套深度（顶层块为 1）
+	Images         int            `json:"images"`
+	Mentions       []MentionRef   `json:"mentions,omitempty"`
+	Issues         []StatsIssue   `json:"issues,omitempty"`
+	Errors         []string       `json:"errors,omitempty"` // 暂时无法校验的 @文档 引用（限流、网络错误等）
+}
+
+// Stats 统计文档的块类型、字数、阅读时间和结构问题
+// @文档引用的有效性需要调用 API 校验，这里只报告缺少 token 的引用，其余引用记录在 Mentions 中
+func (c *BlockToMarkdown) Stats(opts StatsOptions) *DocStats {
+	stats := &DocStats{BlockTypes: map[string]int{}}
+
+	for _, block := range c.blocks {
+		if block.BlockType == nil {
+			continue
+		}
+		blockType := BlockType(*block.BlockType)
+		if blockType == BlockTypePage {
+			continue
+		}
+		blockID := client.StringVal(block.BlockId)
+		stats.Blocks++
+		stats.BlockTypes[blockType.Name()]++
+
+		elements := BlockTextElements(block)
+		text := c.convertTextElementsRaw(elements)
+		wc := CountWords(text)
+		stats.Words.CJK += wc.CJK
+		stats.Words.Latin += wc.Latin
+		stats.Characters += countNonSpace(text)
+		c.collectMentions(stats, blockID, elements)
+
+		switch {
+		case blockType >= BlockTypeHeading1 && blockType <= BlockTypeHeading9:
+			if strings.TrimSpace(text) == "" {
+				stats.Issues = append(stats.Issues, StatsIssue{
+					Type:    IssueEmptyHeading,
+					BlockID: blockID,
+					Detail:  fmt.Sprintf("%s 标题内容为空", blockType.Name()),
+				})
+			}
+		case blockType == BlockTypeImage:
+			stats.Images++
+			if strings.TrimSpace(c.imageAlt(block)) == "" {
+				stats.Issues = append(stats.Issues, StatsIssue{
+					Type:    IssueImageMissingAlt,
+					BlockID: blockID,
+					Detail:  "图片缺少替代文本",
+				})
+			}
+		case blockType == BlockTypeTable:
+			if block.Table == nil || block.Table.Property == nil || opts.MaxTableCells <= 0 {
+				break
+			}
+			rows := client.IntVal(block.Table.Property.RowSize)
+			cols := client.IntVal(block.Table.Property.ColumnSize)
+			if rows*cols > opts.MaxTableCells {
+				stats.Issues = append(stats.Issues, StatsIssue{
+					Type:    IssueOversizedTable,
+					BlockID: blockID,
+					Detail:  fmt.Sprintf("表格 %d 行 x %d 列，共 %d 个单元格，超过上限 %d", rows, cols, rows*cols, opts.MaxTableCells),
+				})
+			}
+		}
+	}
+
+	c.walkDepth(stats, opts.MaxDepth)
+	stats.ReadingMinutes = ReadingMinutes(stats.Words)
+	return stats
+}
+
+// collectMentions 记录 @文档 引用，缺少 token 和链接的引用直接视为失效
+func (c *BlockToMarkdown) collectMentions(stats *DocStats, blockID string, elements []*larkdocx.TextElement) {
+	for _, elem := range elements {
+		if elem == nil || elem.MentionDoc == nil {
+			continue
+		}
+		ref := MentionRef{
+			BlockID: blockID,
+			Token:   client.StringVal(elem.MentionDoc.Token),
+			ObjType: client.IntVal(elem.MentionDoc.ObjType),
+			Title:   client.StringVal(elem.MentionDoc.Title),
+			URL:     client.StringVal(elem.MentionDoc.Url),
+		}
+		if ref.Token == "" && ref.URL == "" {
+			stats.Issues = append(stats.Issues, StatsIssue{
+				Type:    IssueBrokenMention,
+				BlockID: blockID,
+				Detail:  fmt.Sprintf("@文档 %q 缺少 token 和链接", ref.Title),
+			})
+			continue
+		}
+		stats.Mentions = append(stats.Mentions, ref)
+	}
+}
+
+// walkDepth 计算块树的最大嵌套深度，表格单元格和分栏列只是容器，不计入深度
+// 每条超过 maxDepth 的嵌套链只在第一个超限的块上报告一次
+func (c *BlockToMarkdown) walkDepth(stats *DocStats, maxDepth int) {
+	referenced := map[string]bool{}
+	for _, block := range c.blocks {
+		for _, childID := range block.Children {
+			referenced[childID] = true
+		}
+	}
+
+	var walk func(block *larkdocx.Block, depth int)
+	walk = func(block *larkdocx.Block, depth int) {
+		if block.BlockType != nil {
+			switch BlockType(*block.BlockType) {
+			case BlockTypeTableCell, BlockTypeGridColumn:
+				depth--
+			}
+		}
+		stats.MaxDepth = max(stats.MaxDepth, depth)
+		if maxDepth > 0 && depth == maxDepth+1 {
+			stats.Issues = append(stats.Issues, StatsIssue{
+				Type:    IssueDeepNesting,
+				BlockID: client.StringVal(block.BlockId),
+				Detail:  fmt.Sprintf("嵌套深度超过 %d 层", maxDepth),
+			})
+		}
+		for _, childID := range block.Children {
+			if child := c.blockMap[childID]; child != nil {
+				walk(child, depth+1)
+			}
+		}
+	}
+
+	for _, block := range c.blocks {
+		if referenced[client.StringVal(block.BlockId)] {
+			continue
+		}
+		// 页面块本身不计入深度，其子块为第 1 层
+		if block.BlockType != nil && BlockType(*block.BlockType) == BlockTypePage {
+			for _, childID := range block.Children {
+				if child := c.blockMap[childID]; child != nil {
+					walk(child, 1)
+				}
+			}
+			continue
+		}
+		walk(block, 1)
+	}
+}
+
+// ReadingMinutes 按字数估算阅读时间（分钟，向上取整，有内容时至少 1 分钟）
+func ReadingMinutes(wc WordCount) int {
+	if wc.Total() == 0 {
+		return 0
+	}
+	seconds := wc.CJK*60/readingSpeedCJK + wc.Latin*60/readingSpeedLatin
+	return max(1, (seconds+59)/60)
+}
+
+// countNonSpace 统计非空白字符数
+func countNonSpace(text string) int {
+	n := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"testing"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+func TestStats(t *testing.T) {
+	md := "# 标题\n\n#\n\n这是一段中文 with English words\n\n- 一\n  - 二\n    - 三\n\n| a | b |\n|---|---|\n| 1 | 2 |\n| 3 | 4 |\n"
+	stats := NewBlockToMarkdown(blocksFromMarkdown(t, md), ConvertOptions{}).Stats(StatsOptions{MaxDepth: 2, MaxTableCells: 4})
+
+	if stats.BlockTypes["heading1"] != 2 || stats.BlockTypes["bullet"] != 3 || stats.BlockTypes["table"] != 1 {
+		t.Errorf("块类型统计错误: %v", stats.BlockTypes)
+	}
+	if stats.MaxDepth != 3 {
+		t.Errorf("MaxDepth = %d, 期望 3", stats.MaxDepth)
+	}
+	if stats.ReadingMinutes != 1 {
+		t.Errorf("ReadingMinutes = %d, 期望 1", stats.ReadingMinutes)
+	}
+
+	counts := map[string]int{}
+	for _, issue := range stats.Issues {
+		counts[issue.Type]++
+	}
+	want := map[string]int{IssueEmptyHeading: 1, IssueDeepNesting: 1, IssueOversizedTable: 1}
+	for typ, n := range want {
+		if counts[typ] != n {
+			t.Errorf("问题 %s 数量 = %d, 期望 %d (全部问题: %+v)", typ, counts[typ], n, stats.Issues)
+		}
+	}
+}
+
+func TestStatsImagesAndMentions(t *testing.T) {
+	str := func(s string) *string { return &s }
+	typ := func(bt BlockType) *int { v := int(bt); return &v }
+
+	blocks := []*larkdocx.Block{
+		{BlockId: str("page"), BlockType: typ(BlockTypePage), Children: []string{"img1", "img2", "t1"}},
+		{BlockId: str("img1"), BlockType: typ(BlockTypeImage), Image: &larkdocx.Image{}},
+		{BlockId: str("img2"), BlockType: typ(BlockTypeImage), Image: &larkdocx.Image{}, Children: []string{"alt"}},
+		{BlockId: str("alt"), BlockType: typ(BlockTypeText), Text: &larkdocx.Text{
+			Elements: []*larkdocx.TextElement{{TextRun: &larkdocx.TextRun{Content: str("架构图")}}},
+		}},
+		{BlockId: str("t1"), BlockType: typ(BlockTypeText), Text: &larkdocx.Text{
+			Elements: []*larkdocx.TextElement{
+				{MentionDoc: &larkdocx.MentionDoc{Token: str("doxcnABC"), Title: str("设计文档")}},
+				{MentionDoc: &larkdocx.MentionDoc{Title: str("失效引用")}},
+			},
+		}},
+	}
+
+	stats := NewBlockToMarkdown(blocks, ConvertOptions{}).Stats(StatsOptions{})
+
+	if stats.Images != 2 {
+		t.Errorf("Images = %d, 期望 2", stats.Images)
+	}
+	if len(stats.Mentions) != 1 || stats.Mentions[0].Token != "doxcnABC" {
+		t.Errorf("Mentions = %+v, 期望只包含 doxcnABC", stats.Mentions)
+	}
+
+	got := map[string]string{}
+	for _, issue := range stats.Issues {
+		got[issue.Type] = issue.BlockID
+	}
+	if got[IssueImageMissingAlt] != "img1" || got[IssueBrokenMention] != "t1" || len(stats.Issues) != 2 {
+		t.Errorf("问题列表错误: %+v", stats.Issues)
+	}
+}
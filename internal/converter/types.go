@@ -1,5 +1,10 @@
 package converter
 
+import (
+	"context"
+	"fmt"
+)
+
 // BlockType represents Feishu block types
 type BlockType int
 
@@ -50,6 +55,61 @@ const (
 	BlockTypeUndefined      BlockType = 999
 )
 
+// blockTypeNames 块类型名称，用于统计和展示
+var blockTypeNames = map[BlockType]string{
+	BlockTypePage:           "page",
+	BlockTypeText:           "text",
+	BlockTypeHeading1:       "heading1",
+	BlockTypeHeading2:       "heading2",
+	BlockTypeHeading3:       "heading3",
+	BlockTypeHeading4:       "heading4",
+	BlockTypeHeading5:       "heading5",
+	BlockTypeHeading6:       "heading6",
+	BlockTypeHeading7:       "heading7",
+	BlockTypeHeading8:       "heading8",
+	BlockTypeHeading9:       "heading9",
+	BlockTypeBullet:         "bullet",
+	BlockTypeOrdered:        "ordered",
+	BlockTypeCode:           "code",
+	BlockTypeQuote:          "quote",
+	BlockTypeEquation:       "equation",
+	BlockTypeTodo:           "todo",
+	BlockTypeBitable:        "bitable",
+	BlockTypeCallout:        "callout",
+	BlockTypeChatCard:       "chat_card",
+	BlockTypeDiagram:        "diagram",
+	BlockTypeDivider:        "divider",
+	BlockTypeFile:           "file",
+	BlockTypeGrid:           "grid",
+	BlockTypeGridColumn:     "grid_column",
+	BlockTypeIframe:         "iframe",
+	BlockTypeImage:          "image",
+	BlockTypeISV:            "isv",
+	BlockTypeMindNote:       "mindnote",
+	BlockTypeSheet:          "sheet",
+	BlockTypeTable:          "table",
+	BlockTypeTableCell:      "table_cell",
+	BlockTypeView:           "view",
+	BlockTypeQuoteContainer: "quote_container",
+	BlockTypeTask:           "task",
+	BlockTypeOKR:            "okr",
+	BlockTypeOKRObjective:   "okr_objective",
+	BlockTypeOKRKeyResult:   "okr_key_result",
+	BlockTypeOKRProgress:    "okr_progress",
+	BlockTypeAddOns:         "add_ons",
+	BlockTypeJiraIssue:      "jira_issue",
+	BlockTypeWikiCatalog:    "wiki_catalog",
+	BlockTypeBoard:          "board",
+}
+
+// Name 返回块类型名称，未知类型返回 "unknown_<编号>"
+func (t BlockType) Name() string {
+	if name, ok := blockTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown_%d", int(t))
+}
+
 // DiagramType represents Feishu diagram types
 type DiagramType int
 
@@ -82,6 +142,7 @@ type ImageInfo struct {
 
 // ConvertOptions holds conversion options
 type ConvertOptions struct {
+	Context             context.Context // 下载图片等 API 调用使用的上下文，为空时使用 context.Background()
 	DownloadImages      bool
 	AssetsDir           string
 	UploadImages        bool
@@ -0,0 +1,240 @@
+// Package docsync 管理本地 Markdown 文件与飞书文档之间的映射和同步状态
+package docsync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultManifestName 默认清单文件名
+const DefaultManifestName = ".feishu.yaml"
+
+// stateFileName 同步状态文件名，与清单文件位于同一目录
+const stateFileName = ".feishu-state.json"
+
+// Manifest 项目清单，声明本地文件与飞书文档的映射关系
+type Manifest struct {
+	Folder    string   `yaml:"folder,omitempty"` // 新建文档的默认文件夹
+	Documents []*Entry `yaml:"documents"`
+
+	path string
+}
+
+// Entry 单个文件的映射
+// document_id 与 wiki_node 二选一，都为空时 push 会新建文档并回写 document_id
+type Entry struct {
+	Path       string `yaml:"path"`
+	DocumentID string `yaml:"document_id,omitempty"`
+	WikiNode   string `yaml:"wiki_node,omitempty"`
+	Folder     string `yaml:"folder,omitempty"`
+	Title      string `yaml:"title,omitempty"`
+}
+
+// FileState 记录某个文件上次同步时的本地哈希和远端版本
+type FileState struct {
+	DocumentID     string    `json:"document_id"`
+	LocalHash      string    `json:"local_hash"`
+	RemoteRevision int       `json:"remote_revision"`
+	SyncedAt       time.Time `json:"synced_at"`
+}
+
+// State 同步状态，按清单中的 path 索引
+type State struct {
+	Files map[string]*FileState `json:"files"`
+
+	path string
+}
+
+// LoadManifest 读取清单文件
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("清单文件不存在: %s", path)
+		}
+		return nil, fmt.Errorf("读取清单文件失败: %w", err)
+	}
+
+	m := &Manifest{path: path}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("解析清单文件失败: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, e := range m.Documents {
+		if e == nil || e.Path == "" {
+			return nil, fmt.Errorf("清单第 %d 项缺少 path", i+1)
+		}
+		if e.DocumentID != "" && e.WikiNode != "" {
+			return nil, fmt.Errorf("清单项 %s 不能同时指定 document_id 和 wiki_node", e.Path)
+		}
+		clean := filepath.ToSlash(filepath.Clean(e.Path))
+		if seen[clean] {
+			return nil, fmt.Errorf("清单中重复的 path: %s", e.Path)
+		}
+		seen[clean] = true
+		e.Path = clean
+	}
+
+	return m, nil
+}
+
+// Save 写回清单文件（用于回写新建文档的 document_id）
+func (m *Manifest) Save() error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("序列化清单失败: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("写入清单文件失败: %w", err)
+	}
+	return nil
+}
+
+// Dir 返回清单所在目录，清单中的 path 均相对于该目录
+func (m *Manifest) Dir() string {
+	return filepath.Dir(m.path)
+}
+
+// LocalPath 返回清单项对应的本地文件路径
+func (m *Manifest) LocalPath(e *Entry) string {
+	return filepath.Join(m.Dir(), filepath.FromSlash(e.Path))
+}
+
+// Filter 按路径筛选清单项，paths 为空时返回全部
+func (m *Manifest) Filter(paths []string) ([]*Entry, error) {
+	if len(paths) == 0 {
+		return m.Documents, nil
+	}
+
+	var result []*Entry
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		var found *Entry
+		for _, e := range m.Documents {
+			entryAbs, err := filepath.Abs(m.LocalPath(e))
+			if err == nil && entryAbs == abs {
+				found = e
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("文件不在清单中: %s", p)
+		}
+		result = append(result, found)
+	}
+	return result, nil
+}
+
+// LoadState 读取清单对应的同步状态，文件不存在时返回空状态
+func LoadState(m *Manifest) (*State, error) {
+	s := &State{
+		Files: make(map[string]*FileState),
+		path:  filepath.Join(m.Dir(), stateFileName),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("读取同步状态失败: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("解析同步状态失败: %w", err)
+	}
+	if s.Files == nil {
+		s.Files = make(map[string]*FileState)
+	}
+	return s, nil
+}
+
+// Save 写入同步状态
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化同步状态失败: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("写入同步状态失败: %w", err)
+	}
+	return nil
+}
+
+// Record 记录一次成功同步
+func (s *State) Record(path, documentID, localHash string, revision int) {
+	s.Files[path] = &FileState{
+		DocumentID:     documentID,
+		LocalHash:      localHash,
+		RemoteRevision: revision,
+		SyncedAt:       time.Now(),
+	}
+}
+
+// HashContent 计算文件内容哈希
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashFile 计算本地文件哈希，文件不存在时返回空字符串
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return HashContent(data), nil
+}
+
+// Status 文件同步状态
+type Status string
+
+const (
+	StatusClean          Status = "clean"           // 本地和远端均未变化
+	StatusLocalModified  Status = "local-modified"  // 仅本地有修改，可 push
+	StatusRemoteModified Status = "remote-modified" // 仅远端有修改，可 pull
+	StatusConflict       Status = "conflict"        // 两端都有修改
+	StatusUntracked      Status = "untracked"       // 从未同步过
+	StatusMissing        Status = "missing"         // 本地文件不存在
+)
+
+// Compare 根据本地哈希、远端版本和上次同步状态计算文件状态
+// remoteRevision 为 0 表示远端文档尚不存在
+func Compare(prev *FileState, localHash string, remoteRevision int) Status {
+	if localHash == "" {
+		if prev == nil {
+			return StatusUntracked
+		}
+		return StatusMissing
+	}
+	if prev == nil {
+		return StatusUntracked
+	}
+
+	localChanged := prev.LocalHash != localHash
+	remoteChanged := remoteRevision != 0 && prev.RemoteRevision != remoteRevision
+
+	switch {
+	case localChanged && remoteChanged:
+		return StatusConflict
+	case localChanged:
+		return StatusLocalModified
+	case remoteChanged:
+		return StatusRemoteModified
+	default:
+		return StatusClean
+	}
+}
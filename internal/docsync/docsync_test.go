@@ -0,0 +1,95 @@
+package docsync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultManifestName)
+	os.WriteFile(path, []byte("folder: fld1\ndocuments:\n  - path: ./docs/a.md\n    document_id: doxA\n  - path: docs/b.md\n    wiki_node: wikB\n"), 0644)
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() 返回错误: %v", err)
+	}
+	if m.Folder != "fld1" || len(m.Documents) != 2 {
+		t.Fatalf("清单内容不正确: %+v", m)
+	}
+	if m.Documents[0].Path != "docs/a.md" {
+		t.Errorf("path 应被规范化, got %q", m.Documents[0].Path)
+	}
+	if got := m.LocalPath(m.Documents[1]); got != filepath.Join(dir, "docs", "b.md") {
+		t.Errorf("LocalPath() = %q", got)
+	}
+}
+
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"缺少 path":  "documents:\n  - document_id: x\n",
+		"重复 path":  "documents:\n  - path: a.md\n  - path: ./a.md\n",
+		"同时指定两种目标": "documents:\n  - path: a.md\n    document_id: x\n    wiki_node: y\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), DefaultManifestName)
+			os.WriteFile(path, []byte(content), 0644)
+			if _, err := LoadManifest(path); err == nil {
+				t.Error("LoadManifest() 应返回错误")
+			}
+		})
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultManifestName)
+	os.WriteFile(path, []byte("documents:\n  - path: a.md\n"), 0644)
+	m, _ := LoadManifest(path)
+
+	s, err := LoadState(m)
+	if err != nil {
+		t.Fatalf("LoadState() 返回错误: %v", err)
+	}
+	s.Record("a.md", "doxA", "hash1", 7)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() 返回错误: %v", err)
+	}
+
+	loaded, err := LoadState(m)
+	if err != nil {
+		t.Fatalf("LoadState() 返回错误: %v", err)
+	}
+	fs := loaded.Files["a.md"]
+	if fs == nil || fs.DocumentID != "doxA" || fs.LocalHash != "hash1" || fs.RemoteRevision != 7 {
+		t.Errorf("状态往返不一致: %+v", fs)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	prev := &FileState{LocalHash: "h1", RemoteRevision: 5}
+	tests := []struct {
+		name     string
+		prev     *FileState
+		hash     string
+		revision int
+		expected Status
+	}{
+		{"未修改", prev, "h1", 5, StatusClean},
+		{"本地修改", prev, "h2", 5, StatusLocalModified},
+		{"远端修改", prev, "h1", 6, StatusRemoteModified},
+		{"双方修改", prev, "h2", 6, StatusConflict},
+		{"从未同步", nil, "h1", 5, StatusUntracked},
+		{"本地文件缺失", prev, "", 5, StatusMissing},
+		{"远端未创建", prev, "h1", 0, StatusClean},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(tt.prev, tt.hash, tt.revision); got != tt.expected {
+				t.Errorf("Compare() = %s, 期望 %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// step 过滤表达式中的一步：取字段、取下标或展开数组
+type step struct {
+	key     string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+// Filter 按 jq 风格的路径表达式过滤数据
+//
+// 支持的语法:
+//
+//	.              数据本身
+//	.items         取字段
+//	.items[0]      取下标，负数从末尾计
+//	.items[]       展开数组，也可写作 .items[*]
+//	.items[].name  展开后取每个元素的字段
+//	.a | .b        管道，依次应用
+//	$.items[*].id  JSONPath 写法，$ 等同于 .
+//
+// 表达式中展开过数组时返回 []any，否则返回单个值
+func Filter(v any, expr string) (any, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr == "." {
+		return v, nil
+	}
+
+	results := []any{v}
+	iterated := false
+	for _, stage := range splitPipe(expr) {
+		steps, err := parseSteps(stage)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range steps {
+			var next []any
+			for _, item := range results {
+				out, err := applyStep(item, s)
+				if err != nil {
+					return nil, fmt.Errorf("过滤表达式 %q 执行失败: %w", expr, err)
+				}
+				next = append(next, out...)
+			}
+			results = next
+			if s.iterate {
+				iterated = true
+			}
+		}
+	}
+
+	if iterated {
+		if results == nil {
+			results = []any{}
+		}
+		return results, nil
+	}
+	return results[0], nil
+}
+
+// splitPipe 按引号外的 | 拆分表达式
+func splitPipe(expr string) []string {
+	var stages []string
+	inQuote := false
+	start := 0
+	for i, r := range expr {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == '|' && !inQuote:
+			stages = append(stages, strings.TrimSpace(expr[start:i]))
+			start = i + 1
+		}
+	}
+	return append(stages, strings.TrimSpace(expr[start:]))
+}
+
+func parseSteps(expr string) ([]step, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("过滤表达式 %q 无效: %s", expr, reason)
+	}
+
+	s := strings.TrimPrefix(expr, "$")
+	if s == "" || s == "." {
+		return nil, nil
+	}
+	if s[0] != '.' && s[0] != '[' {
+		return nil, invalid("必须以 . 或 $ 开头")
+	}
+
+	var steps []step
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if s == "" {
+				return nil, invalid("字段名为空")
+			}
+			if s[0] == '[' {
+				continue
+			}
+			if s[0] == '"' {
+				end := strings.IndexByte(s[1:], '"')
+				if end < 0 {
+					return nil, invalid("引号未闭合")
+				}
+				steps = append(steps, step{key: s[1 : end+1]})
+				s = s[end+2:]
+				continue
+			}
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			key := strings.TrimSpace(s[:end])
+			if key == "" {
+				return nil, invalid("字段名为空")
+			}
+			if key == "*" {
+				steps = append(steps, step{iterate: true})
+			} else {
+				steps = append(steps, step{key: key})
+			}
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, invalid("缺少 ]")
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+			switch {
+			case inner == "" || inner == "*":
+				steps = append(steps, step{iterate: true})
+			case strings.HasPrefix(inner, `"`) || strings.HasPrefix(inner, "'"):
+				steps = append(steps, step{key: strings.Trim(inner, `"'`)})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, invalid(fmt.Sprintf("下标 %q 不是整数", inner))
+				}
+				steps = append(steps, step{index: n, isIndex: true})
+			}
+		default:
+			return nil, invalid(fmt.Sprintf("无法解析 %q", s))
+		}
+	}
+	return steps, nil
+}
+
+func applyStep(v any, s step) ([]any, error) {
+	if v == nil {
+		// 与 jq 一致，null 上取字段和下标得到 null
+		if s.iterate {
+			return nil, nil
+		}
+		return []any{nil}, nil
+	}
+
+	switch {
+	case s.iterate:
+		switch val := v.(type) {
+		case []any:
+			return val, nil
+		case *Object:
+			out := make([]any, 0, len(val.Keys))
+			for _, key := range val.Keys {
+				out = append(out, val.Values[key])
+			}
+			return out, nil
+		}
+		return nil, fmt.Errorf("无法展开 %s", typeName(v))
+	case s.isIndex:
+		list, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("无法对 %s 取下标", typeName(v))
+		}
+		i := s.index
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return []any{nil}, nil
+		}
+		return []any{list[i]}, nil
+	default:
+		obj, ok := v.(*Object)
+		if !ok {
+			return nil, fmt.Errorf("无法对 %s 取字段 %q", typeName(v), s.key)
+		}
+		value, _ := obj.Get(s.key)
+		return []any{value}, nil
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case *Object:
+		return "对象"
+	case []any:
+		return "数组"
+	case string:
+		return "字符串"
+	case bool:
+		return "布尔值"
+	case nil:
+		return "null"
+	}
+	return "数字"
+}
+
+// SelectFields 只保留指定字段，字段可用 a.b 访问嵌套对象
+// 数组中的每个对象分别处理，输出字段顺序与 fields 一致
+func SelectFields(v any, fields []string) any {
+	if len(fields) == 0 {
+		return v
+	}
+	switch val := v.(type) {
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = SelectFields(item, fields)
+		}
+		return out
+	case *Object:
+		obj := &Object{Values: map[string]any{}}
+		for _, field := range fields {
+			obj.Set(field, lookupField(val, field))
+		}
+		return obj
+	}
+	return v
+}
+
+func lookupField(obj *Object, field string) any {
+	if value, ok := obj.Get(field); ok {
+		return value
+	}
+	var cur any = obj
+	for _, part := range strings.Split(field, ".") {
+		o, ok := cur.(*Object)
+		if !ok {
+			return nil
+		}
+		cur, _ = o.Get(part)
+	}
+	return cur
+}
@@ -0,0 +1,311 @@
+// Package output 将命令结果统一渲染为 json、yaml、table、csv、tsv 或 jsonl
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 输出格式
+const (
+	FormatText  = "text" // 命令自己的可读文本
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+	FormatCSV   = "csv"
+	FormatTSV   = "tsv"
+	FormatJSONL = "jsonl"
+)
+
+// Formats 支持的输出格式
+var Formats = []string{FormatText, FormatJSON, FormatYAML, FormatTable, FormatCSV, FormatTSV, FormatJSONL}
+
+// Options 输出选项
+type Options struct {
+	Format    string   // 输出格式，空或 text 时输出 JSON
+	Fields    []string // 只输出这些字段
+	Filter    string   // jq 风格的过滤表达式
+	NoHeaders bool     // table/csv/tsv 不输出表头
+}
+
+// ParseFormat 校验输出格式，空字符串视为 text
+func ParseFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return FormatText, nil
+	}
+	for _, f := range Formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的输出格式: %s（可选: %s）", format, strings.Join(Formats, ", "))
+}
+
+// IsStructured 判断是否为机器可读的格式
+func IsStructured(format string) bool {
+	return format != "" && format != FormatText
+}
+
+// ParseFields 解析逗号分隔的字段列表
+func ParseFields(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Write 按选项渲染 v 并写入 w
+func Write(w io.Writer, v any, opts Options) error {
+	format := opts.Format
+	if format == "" || format == FormatText {
+		format = FormatJSON
+	}
+
+	// 不需要过滤时保持原始的 JSON 输出
+	if format == FormatJSON && opts.Filter == "" && len(opts.Fields) == 0 {
+		return writeJSON(w, v)
+	}
+
+	data, err := Normalize(v)
+	if err != nil {
+		return err
+	}
+	if data, err = Filter(data, opts.Filter); err != nil {
+		return err
+	}
+	if len(opts.Fields) > 0 {
+		// 分页结果按其中的记录选择字段
+		data = SelectFields(unwrapList(data), opts.Fields)
+	}
+
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, data)
+	case FormatJSONL:
+		return writeJSONL(w, data)
+	case FormatYAML:
+		return writeYAML(w, data)
+	case FormatTable:
+		return writeTable(w, data, opts)
+	case FormatCSV:
+		return writeCSV(w, data, opts, ',')
+	case FormatTSV:
+		return writeCSV(w, data, opts, '\t')
+	}
+	return fmt.Errorf("不支持的输出格式: %s", format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("JSON 序列化失败: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeJSONL 数组每个元素输出一行（规则同 unwrapList），其他值输出为单行
+func writeJSONL(w io.Writer, v any) error {
+	items, ok := unwrapList(v).([]any)
+	if !ok {
+		items = []any{v}
+	}
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("JSON 序列化失败: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlValue(v)); err != nil {
+		return fmt.Errorf("YAML 序列化失败: %w", err)
+	}
+	return enc.Close()
+}
+
+// rows 将数据展开为表头和行
+//
+//   - 对象数组：每个对象一行，列为所有对象字段的并集
+//   - 单个对象：一行
+//   - 标量数组：单列 value
+//   - 对象中只有一个数组字段（如 {"items": [...]}）时展开该数组
+func rows(v any) ([]string, [][]string) {
+	v = unwrapList(v)
+	if obj, ok := v.(*Object); ok {
+		v = []any{obj}
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return []string{"value"}, [][]string{{cellString(v)}}
+	}
+
+	var headers []string
+	seen := map[string]bool{}
+	for _, item := range list {
+		if obj, ok := item.(*Object); ok {
+			for _, key := range obj.Keys {
+				if !seen[key] {
+					seen[key] = true
+					headers = append(headers, key)
+				}
+			}
+		}
+	}
+	if len(headers) == 0 {
+		headers = []string{"value"}
+	}
+
+	out := make([][]string, 0, len(list))
+	for _, item := range list {
+		row := make([]string, len(headers))
+		if obj, ok := item.(*Object); ok {
+			for i, key := range headers {
+				row[i] = cellString(obj.Values[key])
+			}
+		} else {
+			row[0] = cellString(item)
+		}
+		out = append(out, row)
+	}
+	return headers, out
+}
+
+// unwrapList 对象只有一个数组字段、其余字段均为标量（如分页结果
+// {"items": [...], "has_more": false}）时返回该数组，否则原样返回
+func unwrapList(v any) any {
+	obj, ok := v.(*Object)
+	if !ok {
+		return v
+	}
+	var found []any
+	count := 0
+	for _, key := range obj.Keys {
+		switch val := obj.Values[key].(type) {
+		case []any:
+			found = val
+			count++
+		case *Object:
+			return v
+		}
+	}
+	if count != 1 {
+		return v
+	}
+	return found
+}
+
+// cellString 单元格内容，嵌套结构输出为紧凑 JSON
+func cellString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		if val {
+			return "true"
+		}
+		return "false"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func writeTable(w io.Writer, v any, opts Options) error {
+	headers, body := rows(v)
+	if !opts.NoHeaders {
+		upper := make([]string, len(headers))
+		for i, h := range headers {
+			upper[i] = strings.ToUpper(h)
+		}
+		body = append([][]string{upper}, body...)
+	}
+
+	widths := make([]int, len(headers))
+	for _, row := range body {
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "\r", ""), "\n", " ")
+			widths[i] = max(widths[i], displayWidth(row[i]))
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, row := range body {
+		for i, cell := range row {
+			buf.WriteString(cell)
+			if i < len(row)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)+2))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeCSV(w io.Writer, v any, opts Options, comma rune) error {
+	headers, body := rows(v)
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if !opts.NoHeaders {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(body); err != nil {
+		return fmt.Errorf("写入 CSV 失败: %w", err)
+	}
+	return nil
+}
+
+// displayWidth 终端显示宽度，中文等宽字符占两列
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			width++
+		case isWide(r):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+func isWide(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115F) ||
+		(r >= 0x2E80 && r <= 0xA4CF) ||
+		(r >= 0xAC00 && r <= 0xD7A3) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0xFE30 && r <= 0xFE4F) ||
+		(r >= 0xFF00 && r <= 0xFF60) ||
+		(r >= 0xFFE0 && r <= 0xFFE6) ||
+		(r >= 0x1F300 && r <= 0x1FAFF) ||
+		(r >= 0x20000 && r <= 0x3FFFD)
+}
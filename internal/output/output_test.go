@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testUser struct {
+	Name  string         `json:"name"`
+	Age   int            `json:"age"`
+	Dept  map[string]any `json:"dept,omitempty"`
+	Admin bool           `json:"admin"`
+}
+
+var testResult = map[string]any{
+	"items": []testUser{
+		{Name: "张三", Age: 25, Dept: map[string]any{"name": "研发"}},
+		{Name: "bob", Age: 30, Admin: true},
+	},
+	"has_more": false,
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "表格对齐中文并展开分页结果",
+			opts: Options{Format: FormatTable},
+			want: "NAME  AGE  DEPT             ADMIN\n" +
+				"张三  25   {\"name\":\"研发\"}  false\n" +
+				"bob   30                    true\n",
+		},
+		{
+			name: "CSV 选择字段并访问嵌套字段",
+			opts: Options{Format: FormatCSV, Fields: []string{"name", "dept.name"}},
+			want: "name,dept.name\n张三,研发\nbob,\n",
+		},
+		{
+			name: "TSV 不输出表头",
+			opts: Options{Format: FormatTSV, Fields: []string{"name", "age"}, NoHeaders: true},
+			want: "张三\t25\nbob\t30\n",
+		},
+		{
+			name: "JSONL 每行一个元素",
+			opts: Options{Format: FormatJSONL, Fields: []string{"name"}},
+			want: "{\"name\":\"张三\"}\n{\"name\":\"bob\"}\n",
+		},
+		{
+			name: "YAML 保持字段顺序",
+			opts: Options{Format: FormatYAML, Filter: ".items[1]"},
+			want: "name: bob\nage: 30\nadmin: true\n",
+		},
+		{
+			name: "过滤后输出 JSON",
+			opts: Options{Format: FormatJSON, Filter: ".items[].name"},
+			want: "[\n  \"张三\",\n  \"bob\"\n]\n",
+		},
+		{
+			name: "JSONPath 写法",
+			opts: Options{Format: FormatTable, Filter: "$.items[*].age", NoHeaders: true},
+			want: "25\n30\n",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, testResult, tt.opts); err != nil {
+			t.Errorf("%s: 返回错误: %v", tt.name, err)
+			continue
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s:\n得到:\n%s\n期望:\n%s", tt.name, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	data, err := Normalize(testResult)
+	if err != nil {
+		t.Fatalf("Normalize 返回错误: %v", err)
+	}
+
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{expr: ".", want: `{"has_more":false,"items":[{"name":"张三","age":25,"dept":{"name":"研发"},"admin":false},{"name":"bob","age":30,"admin":true}]}`},
+		{expr: ".has_more", want: `false`},
+		{expr: ".items[-1].name", want: `"bob"`},
+		{expr: ".items[5]", want: `null`},
+		{expr: ".items | .[0] | .dept.name", want: `"研发"`},
+		{expr: `.items[0]["age"]`, want: `25`},
+		{expr: ".items[].dept.name", want: `["研发",null]`},
+		{expr: ".missing.field", want: `null`},
+		{expr: ".has_more.x", wantErr: true},
+		{expr: ".items[x]", wantErr: true},
+		{expr: "items", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Filter(data, tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Filter(%q) 错误 = %v, 期望错误 = %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := writeJSONL(&buf, []any{got}); err != nil {
+			t.Fatalf("序列化失败: %v", err)
+		}
+		if s := buf.String(); s != tt.want+"\n" {
+			t.Errorf("Filter(%q) = %s, 期望 %s", tt.expr, s, tt.want)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	items := testResult["items"].([]testUser)
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "默认逐条输出 JSONL，不展开记录中的数组",
+			opts: Options{Fields: []string{"name", "dept"}},
+			want: "{\"name\":\"张三\",\"dept\":{\"name\":\"研发\"}}\n{\"name\":\"bob\",\"dept\":null}\n",
+		},
+		{
+			name: "过滤表达式作用于每条记录",
+			opts: Options{Format: FormatJSON, Filter: ".age"},
+			want: "25\n30\n",
+		},
+		{
+			name: "表格在 Close 时输出",
+			opts: Options{Format: FormatTable, Fields: []string{"name", "admin"}},
+			want: "NAME  ADMIN\n张三  false\nbob   true\n",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		s := NewStream(&buf, tt.opts)
+		for _, item := range items {
+			if err := s.Write(item); err != nil {
+				t.Fatalf("%s: Write 返回错误: %v", tt.name, err)
+			}
+		}
+		if err := s.Close(); err != nil {
+			t.Fatalf("%s: Close 返回错误: %v", tt.name, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s:\n得到:\n%s\n期望:\n%s", tt.name, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]string{"": FormatText, "JSON": FormatJSON, " table ": FormatTable} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = (%q, %v), 期望 %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) 应返回错误")
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Stream 逐条输出记录，用于分页获取全部结果
+//
+// json 和 jsonl 格式每条记录立即输出一行，内存占用与记录总数无关；
+// yaml、table、csv 和 tsv 需要全部记录才能确定列宽和表头，先缓存在 Close 时输出
+type Stream struct {
+	w        io.Writer
+	opts     Options
+	buffered []any
+}
+
+// NewStream 创建 Stream，Fields 和 Filter 作用于每条记录
+func NewStream(w io.Writer, opts Options) *Stream {
+	if opts.Format == "" || opts.Format == FormatText || opts.Format == FormatJSON {
+		opts.Format = FormatJSONL
+	}
+	return &Stream{w: w, opts: opts}
+}
+
+// Write 输出一条记录
+func (s *Stream) Write(v any) error {
+	data, err := Normalize(v)
+	if err != nil {
+		return err
+	}
+	if data, err = Filter(data, s.opts.Filter); err != nil {
+		return err
+	}
+	if len(s.opts.Fields) > 0 {
+		data = SelectFields(data, s.opts.Fields)
+	}
+
+	if s.opts.Format != FormatJSONL {
+		s.buffered = append(s.buffered, data)
+		return nil
+	}
+	line, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("JSON 序列化失败: %w", err)
+	}
+	_, err = fmt.Fprintln(s.w, string(line))
+	return err
+}
+
+// Close 输出缓存的记录
+func (s *Stream) Close() error {
+	if s.opts.Format == FormatJSONL {
+		return nil
+	}
+	items := s.buffered
+	if items == nil {
+		items = []any{}
+	}
+	s.buffered = nil
+	return Write(s.w, items, Options{Format: s.opts.Format, NoHeaders: s.opts.NoHeaders})
+}
@@ -0,0 +1,215 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 加密文件参数
+const (
+	fileVersion   = 1
+	kdfIterations = 600000 // PBKDF2-HMAC-SHA256 迭代次数，参考 OWASP 建议值
+	saltSize      = 16
+	keySize       = 32 // AES-256
+)
+
+// encryptedFile 加密文件的磁盘格式，data 为 AES-256-GCM 加密的 JSON 键值表
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// fileStore 口令加密的本地文件存储，所有密钥保存在同一个文件中
+type fileStore struct {
+	path       string
+	passphrase string
+}
+
+// FilePath 返回加密文件路径 ~/.feishu-cli/secrets.enc
+func FilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户目录失败: %w", err)
+	}
+	return filepath.Join(home, ".feishu-cli", "secrets.enc"), nil
+}
+
+func openFileStore(passphrase string) (*fileStore, error) {
+	path, err := FilePath()
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{path: path, passphrase: passphrase}, nil
+}
+
+func (s *fileStore) Get(key string) (string, error) {
+	values, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *fileStore) Set(key, value string) error {
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return s.save(values)
+}
+
+func (s *fileStore) Delete(key string) error {
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return nil
+	}
+	delete(values, key)
+	return s.save(values)
+}
+
+func (s *fileStore) Describe(key string) string {
+	return fmt.Sprintf("加密文件 %s（%s）", s.path, key)
+}
+
+// load 读取并解密文件，文件不存在时返回空表
+func (s *fileStore) load() (map[string]string, error) {
+	values := map[string]string{}
+	raw, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取加密文件失败: %w", err)
+	}
+
+	var f encryptedFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("解析加密文件失败: %w", err)
+	}
+	if f.Version != fileVersion || f.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("不支持的加密文件格式: version=%d, kdf=%s", f.Version, f.KDF)
+	}
+
+	gcm, err := newGCM(pbkdf2SHA256([]byte(s.passphrase), f.Salt, f.Iterations, keySize))
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("解密 %s 失败: 口令错误或文件已损坏", s.path)
+	}
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("解析加密文件内容失败: %w", err)
+	}
+	return values, nil
+}
+
+// save 使用新的盐和随机数重新加密并写入文件
+func (s *fileStore) save(values map[string]string) error {
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("序列化密钥失败: %w", err)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("生成随机数失败: %w", err)
+	}
+	gcm, err := newGCM(pbkdf2SHA256([]byte(s.passphrase), salt, kdfIterations, keySize))
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("生成随机数失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(&encryptedFile{
+		Version:    fileVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: kdfIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plain, nil),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化加密文件失败: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	// 先写临时文件再重命名，避免写入中断导致已有密钥丢失
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("写入加密文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("写入加密文件失败: %w", err)
+	}
+	return nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("初始化加密失败: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("初始化加密失败: %w", err)
+	}
+	return gcm, nil
+}
+
+// pbkdf2SHA256 按 RFC 8018 使用 HMAC-SHA256 从口令派生密钥
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, blocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = u[:0]
+			u = prf.Sum(u)
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
@@ -0,0 +1,123 @@
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// runCommand 执行外部命令，返回标准输出；测试时可替换
+var runCommand = func(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// KeyringAvailable 判断系统密钥环是否可用
+func KeyringAvailable() bool {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := exec.LookPath("security")
+		return err == nil
+	case "linux", "freebsd", "openbsd":
+		// 没有 D-Bus 会话（如 SSH 登录的构建机）时 Secret Service 无法解锁
+		if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+			return false
+		}
+		_, err := exec.LookPath("secret-tool")
+		return err == nil
+	}
+	return false
+}
+
+// keyringStore 通过系统命令访问密钥环
+type keyringStore struct {
+	goos string
+}
+
+func newKeyringStore() *keyringStore {
+	return &keyringStore{goos: runtime.GOOS}
+}
+
+func (s *keyringStore) Get(key string) (string, error) {
+	var (
+		out string
+		err error
+	)
+	if s.goos == "darwin" {
+		out, err = runCommand("", "security", "find-generic-password", "-s", serviceName, "-a", key, "-w")
+	} else {
+		out, err = runCommand("", "secret-tool", "lookup", "service", serviceName, "account", key)
+	}
+	if err != nil {
+		// 两个命令在找不到条目时都以非 0 状态退出且没有输出
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && out == "" {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("读取系统密钥环失败: %w", err)
+	}
+	if s.goos == "darwin" {
+		// security -w 会在末尾追加换行
+		out = trimNewline(out)
+	}
+	return out, nil
+}
+
+func (s *keyringStore) Set(key, value string) error {
+	var err error
+	if s.goos == "darwin" {
+		// 命令行参数对本机其他用户可见（ps），因此通过 security -i 从标准输入读取命令，密码不出现在参数中
+		command := strings.Join([]string{"add-generic-password", "-U",
+			"-s", securityQuote(serviceName), "-a", securityQuote(key),
+			"-l", securityQuote(serviceName + " " + key), "-w", securityQuote(value)}, " ")
+		if _, err = runCommand(command+"\n", "security", "-i"); err == nil {
+			// security -i 中的命令失败时进程仍可能正常退出，读取一次确认已写入
+			if got, getErr := s.Get(key); getErr != nil || got != value {
+				err = fmt.Errorf("写入后读取校验失败")
+			}
+		}
+	} else {
+		_, err = runCommand(value, "secret-tool", "store", "--label", serviceName+" "+key, "service", serviceName, "account", key)
+	}
+	if err != nil {
+		return fmt.Errorf("写入系统密钥环失败: %w", err)
+	}
+	return nil
+}
+
+// securityQuote 按 security -i 的命令行语法为参数加双引号
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (s *keyringStore) Delete(key string) error {
+	var err error
+	if s.goos == "darwin" {
+		_, err = runCommand("", "security", "delete-generic-password", "-s", serviceName, "-a", key)
+	} else {
+		_, err = runCommand("", "secret-tool", "clear", "service", serviceName, "account", key)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("删除系统密钥环条目失败: %w", err)
+	}
+	return nil
+}
+
+func (s *keyringStore) Describe(key string) string {
+	return fmt.Sprintf("系统密钥环（service=%s, account=%s）", serviceName, key)
+}
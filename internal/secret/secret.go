@@ -0,0 +1,113 @@
+// Package secret 提供应用密钥和用户 Token 的安全存储
+//
+// 支持两种后端:
+//   - keyring: 系统密钥环（Linux 通过 secret-tool 访问 Secret Service，macOS 使用钥匙串）
+//   - file:    使用口令加密的本地文件（AES-256-GCM），适用于没有桌面会话的 Linux 主机
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// 存储后端名称，对应配置项 secret_store
+const (
+	BackendAuto      = "auto"      // 优先使用系统密钥环，其次加密文件，都不可用时使用明文文件
+	BackendKeyring   = "keyring"   // 系统密钥环
+	BackendFile      = "file"      // 口令加密文件
+	BackendPlaintext = "plaintext" // 不使用安全存储（兼容旧版本的明文文件）
+)
+
+// serviceName 在系统密钥环中使用的服务名
+const serviceName = "feishu-cli"
+
+// PassphraseEnv 加密文件口令的环境变量；PassphraseFileEnv 指定从文件读取口令
+const (
+	PassphraseEnv     = "FEISHU_SECRET_PASSPHRASE"
+	PassphraseFileEnv = "FEISHU_SECRET_PASSPHRASE_FILE"
+)
+
+var (
+	// ErrNotFound 存储中没有对应的密钥
+	ErrNotFound = errors.New("密钥不存在")
+	// ErrNoStore 未启用安全存储，调用方应回退到明文文件
+	ErrNoStore = errors.New("未启用安全存储")
+)
+
+// Store 密钥存储
+type Store interface {
+	// Get 读取密钥，不存在时返回 ErrNotFound
+	Get(key string) (string, error)
+	// Set 写入密钥，已存在时覆盖
+	Set(key, value string) error
+	// Delete 删除密钥，不存在时不报错
+	Delete(key string) error
+	// Describe 返回密钥所在位置的描述，用于提示信息
+	Describe(key string) string
+}
+
+// Key 返回配置档案下的密钥名，如 default/app_secret、lark/user_token
+func Key(profile, name string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	return profile + "/" + name
+}
+
+// Open 按后端名称打开密钥存储
+// backend 为 plaintext，或为 auto 且没有可用的安全存储时返回 ErrNoStore
+func Open(backend string) (Store, error) {
+	switch backend {
+	case "", BackendAuto:
+		if KeyringAvailable() {
+			return newKeyringStore(), nil
+		}
+		if passphrase, _ := readPassphrase(); passphrase != "" {
+			return openFileStore(passphrase)
+		}
+		return nil, ErrNoStore
+	case BackendKeyring:
+		if !KeyringAvailable() {
+			return nil, fmt.Errorf("系统密钥环不可用: Linux 需要安装 secret-tool 并运行桌面会话（D-Bus），macOS 需要 security 命令；无桌面环境可改用 secret_store: file")
+		}
+		return newKeyringStore(), nil
+	case BackendFile:
+		passphrase, err := readPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, fmt.Errorf("加密文件存储需要口令，请设置环境变量 %s 或 %s", PassphraseEnv, PassphraseFileEnv)
+		}
+		return openFileStore(passphrase)
+	case BackendPlaintext:
+		return nil, ErrNoStore
+	default:
+		return nil, fmt.Errorf("不支持的 secret_store: %s（可选: auto, keyring, file, plaintext）", backend)
+	}
+}
+
+// readPassphrase 从环境变量读取加密文件口令
+func readPassphrase() (string, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+	path := os.Getenv(PassphraseFileEnv)
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取口令文件失败: %w", err)
+	}
+	return trimNewline(string(data)), nil
+}
+
+// trimNewline 去掉命令输出或文件内容末尾的换行
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package secret
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// RFC 7914 第 11 节的 PBKDF2-HMAC-SHA256 测试向量
+	tests := []struct {
+		password, salt string
+		iterations     int
+		keyLen         int
+		want           string
+	}{
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+	}
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLen))
+		if got != tt.want {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s, 期望 %s", tt.password, tt.salt, tt.iterations, got, tt.want)
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	s := &fileStore{path: path, passphrase: "correct horse"}
+
+	if _, err := s.Get("default/app_secret"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("文件不存在时 Get 应返回 ErrNotFound, 实际: %v", err)
+	}
+	if err := s.Set("default/app_secret", "s3cret"); err != nil {
+		t.Fatalf("Set 返回错误: %v", err)
+	}
+	if err := s.Set("lark/user_token", `{"access_token":"u-xxx"}`); err != nil {
+		t.Fatalf("Set 返回错误: %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	if strings.Contains(string(raw), "s3cret") || strings.Contains(string(raw), "u-xxx") {
+		t.Errorf("加密文件中出现明文: %s", raw)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("加密文件权限应为 0600: %v, %v", info.Mode(), err)
+	}
+
+	reopened := &fileStore{path: path, passphrase: "correct horse"}
+	if v, err := reopened.Get("default/app_secret"); err != nil || v != "s3cret" {
+		t.Errorf("Get = %q, %v, 期望 s3cret", v, err)
+	}
+	if err := reopened.Delete("default/app_secret"); err != nil {
+		t.Fatalf("Delete 返回错误: %v", err)
+	}
+	if _, err := reopened.Get("default/app_secret"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("删除后 Get 应返回 ErrNotFound, 实际: %v", err)
+	}
+	if v, _ := reopened.Get("lark/user_token"); v != `{"access_token":"u-xxx"}` {
+		t.Errorf("删除其他密钥后 lark/user_token = %q", v)
+	}
+
+	wrong := &fileStore{path: path, passphrase: "wrong"}
+	if _, err := wrong.Get("lark/user_token"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("口令错误时应返回解密错误, 实际: %v", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "")
+	t.Setenv(PassphraseEnv, "")
+	t.Setenv(PassphraseFileEnv, "")
+
+	if _, err := Open(BackendPlaintext); !errors.Is(err, ErrNoStore) {
+		t.Errorf("plaintext 应返回 ErrNoStore, 实际: %v", err)
+	}
+	if _, err := Open(BackendFile); err == nil {
+		t.Error("未设置口令时 file 后端应返回错误")
+	}
+	if _, err := Open("vault"); err == nil {
+		t.Error("未知后端应返回错误")
+	}
+
+	passFile := filepath.Join(t.TempDir(), "pass")
+	if err := os.WriteFile(passFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(PassphraseFileEnv, passFile)
+	store, err := Open(BackendFile)
+	if err != nil {
+		t.Fatalf("Open(file) 返回错误: %v", err)
+	}
+	if fs, ok := store.(*fileStore); !ok || fs.passphrase != "from-file" {
+		t.Errorf("应从口令文件读取口令并去掉换行: %#v", store)
+	}
+}
+
+func TestKeyringStore_DarwinSecretNotInArgs(t *testing.T) {
+	const value = `s3cr"et\value`
+	var stored string
+	old := runCommand
+	defer func() { runCommand = old }()
+	runCommand = func(stdin string, name string, args ...string) (string, error) {
+		for _, arg := range args {
+			if strings.Contains(arg, "s3cr") {
+				t.Fatalf("密钥出现在命令行参数中: %s %v", name, args)
+			}
+		}
+		switch {
+		case len(args) == 1 && args[0] == "-i":
+			stored = stdin
+			return "", nil
+		case len(args) > 0 && args[0] == "find-generic-password":
+			return value + "\n", nil
+		}
+		return "", nil
+	}
+
+	if err := (&keyringStore{goos: "darwin"}).Set("default/app_secret", value); err != nil {
+		t.Fatalf("Set() 返回错误: %v", err)
+	}
+	if !strings.Contains(stored, `-w "s3cr\"et\\value"`) {
+		t.Errorf("标准输入中的命令 = %q, 期望包含转义后的密钥", stored)
+	}
+}
@@ -0,0 +1,284 @@
+// Package textdiff 提供基于 Myers 算法的文本比较，支持行级统一格式和词级差异输出
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Kind 表示一条编辑操作的类型
+type Kind int
+
+const (
+	Equal Kind = iota
+	Delete
+	Insert
+)
+
+// Edit 表示将 a 转换为 b 的一步操作
+// A 为 a 中的下标（Insert 时为 -1），B 为 b 中的下标（Delete 时为 -1）
+type Edit struct {
+	Kind Kind
+	A    int
+	B    int
+}
+
+// Diff 计算两个序列的最短编辑脚本
+func Diff[T comparable](a, b []T) []Edit {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if maxD == 0 {
+		return nil
+	}
+
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// 回溯得到编辑脚本（逆序）
+	var edits []Edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		tv := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && tv[offset+k-1] < tv[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := tv[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Kind: Equal, A: x, B: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				edits = append(edits, Edit{Kind: Insert, A: -1, B: y})
+			} else {
+				x--
+				edits = append(edits, Edit{Kind: Delete, A: x, B: -1})
+			}
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// HasChanges 判断编辑脚本中是否包含修改
+func HasChanges(edits []Edit) bool {
+	for _, e := range edits {
+		if e.Kind != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Unified 生成统一格式 (unified diff) 的行级差异，context 为上下文行数
+// 两侧内容相同时返回空字符串
+func Unified(fromName, toName, a, b string, context int) string {
+	aLines := splitLines(a)
+	bLines := splitLines(b)
+	edits := Diff(aLines, bLines)
+	if !HasChanges(edits) {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for _, h := range hunks(edits, context) {
+		var aStart, aCount, bStart, bCount int
+		aStart, bStart = -1, -1
+		for _, e := range edits[h[0]:h[1]] {
+			if e.Kind != Insert {
+				if aStart < 0 {
+					aStart = e.A
+				}
+				aCount++
+			}
+			if e.Kind != Delete {
+				if bStart < 0 {
+					bStart = e.B
+				}
+				bCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(aStart, aCount, linesBefore(edits, h[0], true)),
+			hunkRange(bStart, bCount, linesBefore(edits, h[0], false)))
+
+		for _, e := range edits[h[0]:h[1]] {
+			switch e.Kind {
+			case Equal:
+				sb.WriteString(" " + aLines[e.A] + "\n")
+			case Delete:
+				sb.WriteString("-" + aLines[e.A] + "\n")
+			case Insert:
+				sb.WriteString("+" + bLines[e.B] + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// hunks 将编辑脚本划分为若干区块，返回每个区块在 edits 中的 [start, end) 范围
+func hunks(edits []Edit, context int) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			i++
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		// 向后扩展，直到连续的相同行超过 2*context
+		end := i
+		for end < len(edits) {
+			if edits[end].Kind != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].Kind == Equal {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end += min(context, run-end)
+				break
+			}
+			end = run
+		}
+		result = append(result, [2]int{start, end})
+		i = end
+	}
+	return result
+}
+
+// linesBefore 统计 edits[:idx] 中属于 a（或 b）的行数
+func linesBefore(edits []Edit, idx int, sideA bool) int {
+	count := 0
+	for _, e := range edits[:idx] {
+		if sideA && e.Kind != Insert || !sideA && e.Kind != Delete {
+			count++
+		}
+	}
+	return count
+}
+
+// hunkRange 格式化区块头中的行范围（1-based，空范围指向前一行）
+func hunkRange(start, count, before int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines 按行拆分文本，忽略末尾换行
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// Words 生成词级差异，删除部分用 [-...-] 标记，新增部分用 {+...+} 标记
+// 拉丁字母和数字按单词切分，中日韩文字按单字切分
+func Words(a, b string) string {
+	aTokens := tokenize(a)
+	bTokens := tokenize(b)
+	edits := Diff(aTokens, bTokens)
+
+	var sb strings.Builder
+	for i := 0; i < len(edits); {
+		kind := edits[i].Kind
+		var run strings.Builder
+		for i < len(edits) && edits[i].Kind == kind {
+			if kind == Insert {
+				run.WriteString(bTokens[edits[i].B])
+			} else {
+				run.WriteString(aTokens[edits[i].A])
+			}
+			i++
+		}
+		switch kind {
+		case Equal:
+			sb.WriteString(run.String())
+		case Delete:
+			sb.WriteString("[-" + run.String() + "-]")
+		case Insert:
+			sb.WriteString("{+" + run.String() + "+}")
+		}
+	}
+	return sb.String()
+}
+
+// tokenize 将文本切分为词、单个 CJK 字符、空白和标点
+func tokenize(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		j := i + 1
+		switch {
+		case isWordRune(r):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(r):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+// isWordRune 判断是否为拉丁单词字符（CJK 文字单独成词）
+func isWordRune(r rune) bool {
+	if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,110 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff_Reconstruct(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"相同", "abc", "abc"},
+		{"全部新增", "", "abc"},
+		{"全部删除", "abc", ""},
+		{"中间修改", "abcdef", "abXdef"},
+		{"交错", "ABCABBA", "CBABAC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := []rune(tt.a), []rune(tt.b)
+			edits := Diff(a, b)
+
+			var gotA, gotB []rune
+			for _, e := range edits {
+				switch e.Kind {
+				case Equal:
+					if a[e.A] != b[e.B] {
+						t.Fatalf("Equal 操作对应的元素不同: %q != %q", a[e.A], b[e.B])
+					}
+					gotA = append(gotA, a[e.A])
+					gotB = append(gotB, b[e.B])
+				case Delete:
+					gotA = append(gotA, a[e.A])
+				case Insert:
+					gotB = append(gotB, b[e.B])
+				}
+			}
+			if string(gotA) != tt.a || string(gotB) != tt.b {
+				t.Errorf("编辑脚本无法还原: a=%q b=%q", string(gotA), string(gotB))
+			}
+		})
+	}
+}
+
+func TestDiff_Minimal(t *testing.T) {
+	// 经典示例的最短编辑距离为 5
+	edits := Diff([]rune("ABCABBA"), []rune("CBABAC"))
+	changes := 0
+	for _, e := range edits {
+		if e.Kind != Equal {
+			changes++
+		}
+	}
+	if changes != 5 {
+		t.Errorf("编辑次数 = %d, 期望 5", changes)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "# 标题\n\n第一段\n\n第二段\n"
+	b := "# 标题\n\n第一段（已修改）\n\n第二段\n"
+
+	got := Unified("a.md", "b.md", a, b, 1)
+	want := "--- a.md\n+++ b.md\n@@ -2,3 +2,3 @@\n \n-第一段\n+第一段（已修改）\n \n"
+	if got != want {
+		t.Errorf("Unified() =\n%s\n期望:\n%s", got, want)
+	}
+
+	if Unified("a", "b", a, a, 3) != "" {
+		t.Error("内容相同时应返回空字符串")
+	}
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, "line")
+	}
+	a := strings.Join(lines, "\n")
+	lines[1] = "first"
+	lines[18] = "second"
+	b := strings.Join(lines, "\n")
+
+	got := Unified("a", "b", a, b, 2)
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("区块数 = %d, 期望 2:\n%s", n, got)
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"英文单词", "hello world", "hello there", "hello [-world-]{+there+}"},
+		{"中文单字", "今天天气好", "今天天气很好", "今天天气{+很+}好"},
+		{"相同", "same", "same", "same"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Words(tt.a, tt.b); got != tt.want {
+				t.Errorf("Words() = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+}
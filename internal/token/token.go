@@ -2,10 +2,14 @@ package token
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/riba2534/feishu-cli/internal/config"
+	"github.com/riba2534/feishu-cli/internal/secret"
 )
 
 // UserToken 存储用户访问令牌信息
@@ -14,8 +18,13 @@ type UserToken struct {
 	RefreshToken string `json:"refresh_token"`
 	ExpiresAt    int64  `json:"expires_at"`
 	ExpiresIn    int64  `json:"expires_in"`
+	APIVersion   string `json:"api_version,omitempty"` // 签发 token 的接口，刷新时使用相同的接口
+	Scope        string `json:"scope,omitempty"`       // 用户授权的 scope，空格分隔
 }
 
+// APIVersionOAuthV2 通过 OAuth 2.0 标准接口（PKCE 登录）签发的 token
+const APIVersionOAuthV2 = "oauth_v2"
+
 // TokenResponse 飞书 OAuth API 响应
 type TokenResponse struct {
 	Code int    `json:"code"`
@@ -24,6 +33,7 @@ type TokenResponse struct {
 		AccessToken  string `json:"access_token"`
 		RefreshToken string `json:"refresh_token"`
 		ExpiresIn    int64  `json:"expires_in"`
+		Scope        string `json:"scope"`
 	} `json:"data"`
 }
 
@@ -35,21 +45,66 @@ type RefreshResponse struct {
 		AccessToken  string `json:"access_token"`
 		RefreshToken string `json:"refresh_token"`
 		ExpiresIn    int64  `json:"expires_in"`
+		Scope        string `json:"scope"`
 	} `json:"data"`
 }
 
-// tokenFilePath 返回 token 文件路径
-func tokenFilePath() (string, error) {
+// FilePath 返回当前配置档案的明文 token 文件路径（未启用安全存储时使用）
+// 顶层默认配置使用 ~/.lark_user_token，命名档案使用 ~/.feishu-cli/tokens/<profile>.json
+func FilePath() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("获取用户目录失败: %w", err)
 	}
+	if profile := config.Get().Profile; profile != "" {
+		return filepath.Join(home, ".feishu-cli", "tokens", profile+".json"), nil
+	}
 	return filepath.Join(home, ".lark_user_token"), nil
 }
 
-// SaveToken 保存 token 到文件
+// DisplayPath 返回用于提示信息的 token 保存位置
+// 启用安全存储时返回密钥环或加密文件的描述，否则返回明文文件路径
+func DisplayPath() string {
+	if store, err := openStore(); err == nil {
+		return store.Describe(storeKey())
+	}
+	path, err := FilePath()
+	if err != nil {
+		return "~/.lark_user_token"
+	}
+	return path
+}
+
+// openStore 打开配置的安全存储，未启用时返回 secret.ErrNoStore
+func openStore() (secret.Store, error) {
+	return secret.Open(config.Get().SecretStore)
+}
+
+// storeKey 返回当前配置档案的 token 在安全存储中的密钥名
+func storeKey() string {
+	return secret.Key(config.Get().Profile, "user_token")
+}
+
+// SaveToken 保存 token
+// 启用安全存储时保存到密钥环或加密文件，并删除旧的明文文件；否则写入明文文件
 func SaveToken(token *UserToken) error {
-	path, err := tokenFilePath()
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化 token 失败: %w", err)
+	}
+
+	store, err := openStore()
+	if err == nil {
+		if err := store.Set(storeKey(), string(data)); err != nil {
+			return err
+		}
+		return removePlaintext()
+	}
+	if !errors.Is(err, secret.ErrNoStore) {
+		return err
+	}
+
+	path, err := FilePath()
 	if err != nil {
 		return err
 	}
@@ -60,11 +115,6 @@ func SaveToken(token *UserToken) error {
 		return fmt.Errorf("创建目录失败: %w", err)
 	}
 
-	data, err := json.MarshalIndent(token, "", "  ")
-	if err != nil {
-		return fmt.Errorf("序列化 token 失败: %w", err)
-	}
-
 	// 使用 0600 权限，仅所有者可读写
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("写入 token 文件失败: %w", err)
@@ -73,9 +123,22 @@ func SaveToken(token *UserToken) error {
 	return nil
 }
 
-// LoadToken 从文件加载 token
+// LoadToken 加载 token，安全存储中没有时回退到明文文件（兼容旧版本保存的 token）
 func LoadToken() (*UserToken, error) {
-	path, err := tokenFilePath()
+	store, err := openStore()
+	if err == nil {
+		data, err := store.Get(storeKey())
+		if err == nil {
+			return parseToken([]byte(data))
+		}
+		if !errors.Is(err, secret.ErrNotFound) {
+			return nil, err
+		}
+	} else if !errors.Is(err, secret.ErrNoStore) {
+		return nil, err
+	}
+
+	path, err := FilePath()
 	if err != nil {
 		return nil, err
 	}
@@ -88,14 +151,56 @@ func LoadToken() (*UserToken, error) {
 		return nil, fmt.Errorf("读取 token 文件失败: %w", err)
 	}
 
+	return parseToken(data)
+}
+
+func parseToken(data []byte) (*UserToken, error) {
 	var token UserToken
 	if err := json.Unmarshal(data, &token); err != nil {
 		return nil, fmt.Errorf("解析 token 文件失败: %w", err)
 	}
-
 	return &token, nil
 }
 
+// MigrateToken 将明文文件中的 token 移入安全存储并删除明文文件
+// 返回值表示是否进行了迁移；未启用安全存储时返回 secret.ErrNoStore
+func MigrateToken() (bool, error) {
+	if _, err := openStore(); err != nil {
+		return false, err
+	}
+	path, err := FilePath()
+	if err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("读取 token 文件失败: %w", err)
+	}
+	token, err := parseToken(data)
+	if err != nil {
+		return false, err
+	}
+	if err := SaveToken(token); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removePlaintext 删除明文 token 文件
+func removePlaintext() error {
+	path, err := FilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除明文 token 文件失败: %w", err)
+	}
+	return nil
+}
+
 // IsExpired 检查 token 是否过期（提前 5 分钟视为过期）
 func (t *UserToken) IsExpired() bool {
 	if t.ExpiresAt == 0 {
@@ -142,30 +247,21 @@ func (t *UserToken) FormatExpiry() string {
 	return fmt.Sprintf("%s (过期时间: %s)", timeStr, expiryTime.Format("2006-01-02 15:04:05"))
 }
 
-// DeleteToken 删除 token 文件
+// DeleteToken 删除 token（安全存储和明文文件）
 func DeleteToken() error {
-	path, err := tokenFilePath()
-	if err != nil {
-		return err
-	}
-
-	if err := os.Remove(path); err != nil {
-		if os.IsNotExist(err) {
-			return nil
+	store, err := openStore()
+	if err == nil {
+		if err := store.Delete(storeKey()); err != nil {
+			return err
 		}
-		return fmt.Errorf("删除 token 文件失败: %w", err)
+	} else if !errors.Is(err, secret.ErrNoStore) {
+		return err
 	}
-
-	return nil
+	return removePlaintext()
 }
 
-// TokenExists 检查 token 文件是否存在
+// TokenExists 检查 token 是否存在
 func TokenExists() bool {
-	path, err := tokenFilePath()
-	if err != nil {
-		return false
-	}
-
-	_, err = os.Stat(path)
+	_, err := LoadToken()
 	return err == nil
 }